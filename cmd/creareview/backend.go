@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// newReviewer creates the reviewer selected by --findings-file or --backend.
+func newReviewer() (*review.Reviewer, error) {
+	if *findingsFile != "" {
+		return review.NewFileReviewer(*findingsFile), nil
+	}
+
+	if review.Backend(*backend) == review.BackendAuto {
+		return review.NewReviewerAuto(parseBackendOrder(*backendOrder))
+	}
+
+	return review.NewReviewer(review.Backend(*backend))
+}
+
+// parseBackendOrder parses a comma-separated backend list, ignoring blanks.
+func parseBackendOrder(value string) []review.Backend {
+	var order []review.Backend
+
+	for name := range strings.SplitSeq(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			order = append(order, review.Backend(name))
+		}
+	}
+
+	return order
+}
+
+// printBackendList writes a table of backends and whether each is available, for --list-backends.
+func printBackendList(w io.Writer, statuses []review.BackendStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BACKEND\tSTATUS\tREASON")
+
+	for _, s := range statuses {
+		state := "available"
+		if !s.Available {
+			state = "unavailable"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Backend, state, s.Reason)
+	}
+
+	return tw.Flush()
+}
+
+// estimateBackend returns the backend to price an estimate for;
+// auto assumes the first backend in --backend-order.
+func estimateBackend() review.Backend {
+	if review.Backend(*backend) != review.BackendAuto {
+		return review.Backend(*backend)
+	}
+
+	if order := parseBackendOrder(*backendOrder); len(order) > 0 {
+		return order[0]
+	}
+
+	return review.BackendClaude
+}
+
+// printEstimate prints the token and cost estimate for --estimate.
+func printEstimate(reviewCtx *rcontext.ReviewContext, promptTemplate string) error {
+	est, err := review.EstimateCost(reviewCtx, estimateBackend(), reviewOptions(reviewCtx.Config, promptTemplate))
+	if err != nil {
+		return fmt.Errorf("estimate: %w", err)
+	}
+
+	cost := "unknown (no price for model)"
+	if est.Priced {
+		cost = fmt.Sprintf("$%.4f", est.Cost)
+	}
+
+	_, err = fmt.Fprintf(stdout, "Files: %d\nModel: %s\nEstimated tokens: ~%d in / ~%d out\nEstimated cost: %s\n",
+		len(reviewCtx.ChangedFiles), est.Model, est.InputTokens, est.OutputTokens, cost)
+
+	return err
+}
+
+// effectiveModel returns the model for this run: --model, else the
+// --env-model or config models entry for the current environment (see
+// rcontext.ModelEnvironment), else empty for the backend default. cfg may
+// be nil. --env-model is checked by validateFlags.
+func effectiveModel(cfg *rcontext.Config) string {
+	flagModels, _ := rcontext.ParseEnvModels(*envModel)
+
+	var cfgModels map[string]string
+	if cfg != nil {
+		cfgModels = cfg.Models
+	}
+
+	return rcontext.ResolveModel(*model, flagModels, cfgModels, rcontext.ModelEnvironment(os.Getenv))
+}
+
+// reviewOptions returns the review options from the flags, the team config
+// and the prompt template.
+func reviewOptions(cfg *rcontext.Config, promptTemplate string) review.Options {
+	opts := review.Options{
+		Model:           effectiveModel(cfg),
+		Env:             env,
+		Retries:         *retries,
+		RetryDelayMS:    *retryDelayMS,
+		RetryMaxDelayMS: *retryMaxMS,
+		PromptTemplate:  promptTemplate,
+		Warnings:        os.Stderr,
+
+		RequireFindingsFormat: *requireFmt,
+	}
+
+	if cfg != nil {
+		opts.Instructions = cfg.Instructions
+		opts.PathInstructions = cfg.PathInstructions
+		opts.Categories = cfg.Categories
+		opts.SeverityDefaults = cfg.Severities
+		opts.Focus = cfg.Focus
+	}
+
+	return opts
+}
+
+// absConfigFiles returns the --config files as absolute paths.
+func absConfigFiles() ([]string, error) {
+	files := make([]string, 0, len(configs))
+
+	for _, c := range configs {
+		abs, err := filepath.Abs(c)
+		if err != nil {
+			return nil, fmt.Errorf("resolve config %s: %w", c, err)
+		}
+
+		files = append(files, abs)
+	}
+
+	return files, nil
+}
+
+// loadPromptTemplate reads and validates a --prompt-template file.
+// Returns an empty string (the built-in template) when path is empty.
+func loadPromptTemplate(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read prompt template: %w", err)
+	}
+
+	if _, err := review.ParsePromptTemplate(string(data)); err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// newInvocation records the effective options of this run for the session.
+// Only env variable names are kept; their values may be secrets.
+func newInvocation(cfg *rcontext.Config) *session.Invocation {
+	inv := &session.Invocation{
+		Backend:     *backend,
+		Model:       effectiveModel(cfg),
+		ReviewType:  *reviewType,
+		BaseBranch:  *baseBranch,
+		BaseCommit:  *baseCommit,
+		HeadCommit:  *headCommit,
+		MaxFiles:    *maxFiles,
+		Sort:        *sortBy,
+		Profile:     *profile,
+		ConfigFiles: slices.Clone(configs),
+		EnvKeys:     slices.Sorted(maps.Keys(env)),
+	}
+
+	if *findingsFile != "" {
+		inv.Backend = string(review.BackendFile)
+	}
+
+	if cfg != nil {
+		inv.Instructions = cfg.Instructions
+	}
+
+	return inv
+}
+
+// parseGates parses the --gate specs and validates --fail-on.
+func parseGates(specs []string, failOn string) ([]review.Gate, error) {
+	if failOn != "" && !review.ValidSeverity(failOn) {
+		return nil, fmt.Errorf("invalid --fail-on %q, expected error, warning or suggestion", failOn)
+	}
+
+	gates := make([]review.Gate, 0, len(specs))
+
+	for _, spec := range specs {
+		g, err := review.ParseGate(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		gates = append(gates, g)
+	}
+
+	return gates, nil
+}
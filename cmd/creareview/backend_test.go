@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/review"
+)
+
+func TestParseBackendOrder(t *testing.T) {
+	got := parseBackendOrder(" codex, ,claude ")
+	if len(got) != 2 || got[0] != review.BackendCodex || got[1] != review.BackendClaude {
+		t.Errorf("parseBackendOrder() = %v, want [codex claude]", got)
+	}
+
+	if got := parseBackendOrder(""); len(got) != 0 {
+		t.Errorf("parseBackendOrder(\"\") = %v, want empty", got)
+	}
+}
+
+func TestPrintBackendList(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := printBackendList(&buf, []review.BackendStatus{
+		{Backend: review.BackendClaude, Available: true},
+		{Backend: review.BackendCodex, Reason: "codex not found in PATH"},
+	})
+	if err != nil {
+		t.Fatalf("printBackendList() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("printBackendList() = %q, want header + 2 rows", buf.String())
+	}
+
+	if !strings.HasPrefix(lines[0], "BACKEND") {
+		t.Errorf("header = %q", lines[0])
+	}
+
+	if fields := strings.Fields(lines[1]); len(fields) != 2 || fields[0] != "claude" || fields[1] != "available" {
+		t.Errorf("claude row = %q", lines[1])
+	}
+
+	if !strings.Contains(lines[2], "unavailable") || !strings.HasSuffix(lines[2], "codex not found in PATH") {
+		t.Errorf("codex row = %q", lines[2])
+	}
+}
+
+func TestLoadPromptTemplate(t *testing.T) {
+	if got, err := loadPromptTemplate(""); err != nil || got != "" {
+		t.Errorf("loadPromptTemplate(\"\") = %q, %v, want built-in", got, err)
+	}
+
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.tmpl")
+	if err := os.WriteFile(valid, []byte("{{range .ChangedFiles}}{{changedFile .}}{{end}}"), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	if got, err := loadPromptTemplate(valid); err != nil || got == "" {
+		t.Errorf("loadPromptTemplate(valid) = %q, %v, want template text", got, err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.tmpl")
+	if err := os.WriteFile(invalid, []byte("{{if .Diff}"), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	if _, err := loadPromptTemplate(invalid); err == nil {
+		t.Error("expected error for invalid template")
+	}
+
+	if _, err := loadPromptTemplate(filepath.Join(dir, "missing.tmpl")); err == nil {
+		t.Error("expected error for missing template")
+	}
+}
+
+func TestNewInvocationOmitsEnvValues(t *testing.T) {
+	env = envVars{"API_TOKEN": "s3cret", "DEBUG": "1"}
+	t.Cleanup(func() { env = nil })
+
+	inv := newInvocation(&rcontext.Config{Instructions: "Focus on auth"})
+
+	if got := strings.Join(inv.EnvKeys, ","); got != "API_TOKEN,DEBUG" {
+		t.Errorf("EnvKeys = %q, want %q", got, "API_TOKEN,DEBUG")
+	}
+
+	if inv.Instructions != "Focus on auth" {
+		t.Errorf("Instructions = %q, want config instructions", inv.Instructions)
+	}
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	if strings.Contains(string(data), "s3cret") {
+		t.Errorf("invocation leaks env values: %s", data)
+	}
+}
+
+func TestParseGates(t *testing.T) {
+	gates, err := parseGates([]string{"security:0", "style:5"}, "error")
+	if err != nil {
+		t.Fatalf("parseGates() error = %v", err)
+	}
+
+	if len(gates) != 2 || gates[0].String() != "security:0" || gates[1].String() != "style:5" {
+		t.Errorf("gates = %v, want [security:0 style:5]", gates)
+	}
+
+	if _, err := parseGates([]string{"security"}, ""); err == nil {
+		t.Error("expected error for a gate without a count")
+	}
+
+	if _, err := parseGates(nil, "critical"); err == nil {
+		t.Error("expected error for an unknown --fail-on severity")
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// Actions for --on-budget.
+const (
+	onBudgetStop = "stop"
+	onBudgetWarn = "warn"
+)
+
+// errBudgetExceeded reports that a review would go over --daily-file-budget.
+var errBudgetExceeded = errors.New("daily file budget exceeded")
+
+// checkFileBudget enforces --daily-file-budget before reviewing n files. It
+// counts the files store's sessions reviewed in the 24 hours before now; when
+// n more would go over the budget it fails with --on-budget stop or writes a
+// warning to w with --on-budget warn.
+func checkFileBudget(w io.Writer, store *session.Store, n int, now time.Time) error {
+	if *dailyBudget <= 0 || n == 0 {
+		return nil
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+
+	used := session.FilesReviewedSince(sessions, now.Add(-session.BudgetWindow))
+	if used+n <= *dailyBudget {
+		return nil
+	}
+
+	msg := fmt.Sprintf("reviewing %d files would bring the last 24h to %d, over --daily-file-budget %d",
+		n, used+n, *dailyBudget)
+
+	if *onBudget == onBudgetWarn {
+		fmt.Fprintf(w, "warning: %s\n", msg)
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", errBudgetExceeded, msg)
+}
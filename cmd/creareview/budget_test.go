@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestCheckFileBudget(t *testing.T) {
+	oldBudget, oldOn := *dailyBudget, *onBudget
+	t.Cleanup(func() { *dailyBudget, *onBudget = oldBudget, oldOn })
+
+	store, err := session.NewStore("/test/project", t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	if err := store.Create(&session.Session{FilesReviewed: 8}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		budget   int
+		onBudget string
+		files    int
+		now      time.Time
+		wantErr  bool
+		wantWarn bool
+	}{
+		{"no budget", 0, onBudgetStop, 100, now, false, false},
+		{"within budget", 10, onBudgetStop, 2, now, false, false},
+		{"over budget stops", 10, onBudgetStop, 3, now, true, false},
+		{"over budget warns", 10, onBudgetWarn, 3, now, false, true},
+		{"old sessions roll out of the window", 10, onBudgetStop, 3, now.Add(25 * time.Hour), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*dailyBudget, *onBudget = tt.budget, tt.onBudget
+
+			var sb strings.Builder
+
+			err := checkFileBudget(&sb, store, tt.files, tt.now)
+			if tt.wantErr != errors.Is(err, errBudgetExceeded) {
+				t.Errorf("checkFileBudget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := strings.HasPrefix(sb.String(), "warning: "); got != tt.wantWarn {
+				t.Errorf("warning = %q, want one: %v", sb.String(), tt.wantWarn)
+			}
+		})
+	}
+}
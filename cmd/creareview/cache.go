@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// responseCacheDir returns the response cache directory in store's state dir.
+func responseCacheDir(store *session.Store) string {
+	return filepath.Join(store.StateDir, "cache")
+}
+
+// responseCache returns the response cache for --cache and --no-cache, or
+// nil when neither is set. --no-cache skips cached responses but stores the
+// new one, refreshing the entry.
+func responseCache(store *session.Store) *review.ResponseCache {
+	if (!*useCache && !*noCache) || store == nil {
+		return nil
+	}
+
+	cache := review.NewResponseCache(responseCacheDir(store), *cacheTTL)
+	cache.Refresh = *noCache
+
+	return cache
+}
+
+// clearResponseCache removes every cached response for --cache-clear.
+func clearResponseCache(w io.Writer, store *session.Store) error {
+	removed, err := review.NewResponseCache(responseCacheDir(store), 0).Clear()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "Removed %d cached responses\n", removed)
+
+	return err
+}
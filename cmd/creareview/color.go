@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Color modes for --color.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// validateColorMode checks the --color value.
+func validateColorMode(mode string) error {
+	switch mode {
+	case colorAuto, colorAlways, colorNever:
+		return nil
+	default:
+		return fmt.Errorf("invalid --color %q, expected auto, always or never", mode)
+	}
+}
+
+// useColor reports whether output written to f should be colored.
+// --no-color wins; auto colors only terminals, honoring NO_COLOR and TERM=dumb,
+// so pipes and files get plain output unless --color always is given.
+func useColor(mode string, noColor bool, f *os.File) bool {
+	if noColor {
+		return false
+	}
+
+	switch mode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	default:
+		return os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb" && isTerminal(f)
+	}
+}
+
+// isTerminal reports whether f is a character device such as a TTY.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateColorMode(t *testing.T) {
+	for _, mode := range []string{"auto", "always", "never"} {
+		if err := validateColorMode(mode); err != nil {
+			t.Errorf("validateColorMode(%q) error = %v", mode, err)
+		}
+	}
+
+	if err := validateColorMode("sometimes"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		name    string
+		mode    string
+		noColor bool
+		want    bool
+	}{
+		{"auto on a file", colorAuto, false, false},
+		{"always on a file", colorAlways, false, true},
+		{"never", colorNever, false, false},
+		{"no-color overrides always", colorAlways, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := useColor(tt.mode, tt.noColor, f); got != tt.want {
+				t.Errorf("useColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/review"
+)
+
+// stdout is where reports go. It records whether anything was written, so a
+// run that fails after its report doesn't print a second JSON document.
+var stdout = &trackedWriter{w: os.Stdout}
+
+// trackedWriter is a writer that remembers whether it was written to.
+type trackedWriter struct {
+	w       io.Writer
+	written bool
+}
+
+// Write writes p to the underlying writer.
+func (t *trackedWriter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		t.written = true
+	}
+
+	return t.w.Write(p)
+}
+
+// errorOutput is the JSON object printed on stdout when a run with JSON output fails.
+type errorOutput struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// wantJSONError reports whether a failed run should also print err as JSON
+// on stdout: the output format is JSON and no report went to stdout already.
+// Gate failures and interrupted reviews print their report before failing.
+func wantJSONError(err error) bool {
+	if stdout.written {
+		return false
+	}
+
+	if errors.Is(err, review.ErrGateFailed) || errors.Is(err, review.ErrInterrupted) {
+		return false
+	}
+
+	if *findingTmpl != "" {
+		return false
+	}
+
+	format, fmtErr := resolveFormat()
+
+	return fmtErr == nil && format == output.FormatJSON
+}
+
+// writeJSONError writes err and its exit code as an errorOutput,
+// on one line with --compact-json.
+func writeJSONError(w io.Writer, err error, code int) error {
+	enc := json.NewEncoder(w)
+	if !*compactJSON {
+		enc.SetIndent("", "  ")
+	}
+
+	return enc.Encode(errorOutput{Error: err.Error(), Code: code})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/review"
+)
+
+func TestWriteJSONError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSONError(&buf, errors.New("not a git repository"), exitError); err != nil {
+		t.Fatalf("writeJSONError() error = %v", err)
+	}
+
+	var got errorOutput
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+
+	if got.Error != "not a git repository" || got.Code != exitError {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestWriteJSONErrorCompact(t *testing.T) {
+	t.Cleanup(func() { *compactJSON = false })
+
+	*compactJSON = true
+
+	var buf bytes.Buffer
+	if err := writeJSONError(&buf, errors.New("boom"), exitError); err != nil {
+		t.Fatalf("writeJSONError() error = %v", err)
+	}
+
+	if got, want := buf.String(), `{"error":"boom","code":1}`+"\n"; got != want {
+		t.Errorf("compact output = %q, want %q", got, want)
+	}
+}
+
+func TestWantJSONErrorAfterReport(t *testing.T) {
+	oldStdout := stdout
+	t.Cleanup(func() { stdout = oldStdout })
+
+	var buf bytes.Buffer
+	stdout = &trackedWriter{w: &buf}
+
+	if !wantJSONError(errors.New("write output file: permission denied")) {
+		t.Fatal("wantJSONError() = false before anything was written")
+	}
+
+	// The report went out, then writing an --output file failed
+	fmt.Fprintln(stdout, `{"findings":[]}`)
+
+	if wantJSONError(errors.New("write output file: permission denied")) {
+		t.Error("wantJSONError() = true after a report was written, want a single JSON document")
+	}
+}
+
+func TestWantJSONError(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		plain  bool
+		err    error
+		want   bool
+	}{
+		{"default format", "", false, errors.New("boom"), true},
+		{"json format", "json", false, errors.New("boom"), true},
+		{"plain flag", "", true, errors.New("boom"), false},
+		{"checkstyle format", "checkstyle", false, errors.New("boom"), false},
+		{"invalid format", "yaml", false, errors.New("boom"), false},
+		{"gate failed", "json", false, fmt.Errorf("%w: bug has 1 finding (max 0)", review.ErrGateFailed), false},
+		{"interrupted", "json", false, fmt.Errorf("run review: %w", review.ErrInterrupted), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldFormat, oldPlain := *formatName, *plain
+			t.Cleanup(func() { *formatName, *plain = oldFormat, oldPlain })
+
+			*formatName, *plain = tt.format, tt.plain
+
+			if got := wantJSONError(tt.err); got != tt.want {
+				t.Errorf("wantJSONError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/review"
+)
+
+// Exit codes. Scripts can use these to tell a missing backend (skip) from a real failure.
+const (
+	exitOK                 = 0
+	exitError              = 1
+	exitBackendUnavailable = 3
+	exitConfigError        = 4
+	exitGateFailed         = 5
+)
+
+// exitCode maps an error from run to a process exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, review.ErrGateFailed):
+		return exitGateFailed
+	case errors.Is(err, review.ErrBackendUnavailable):
+		return exitBackendUnavailable
+	case errors.Is(err, rcontext.ErrInvalidConfig), errors.Is(err, review.ErrUnknownBackend):
+		return exitConfigError
+	default:
+		return exitError
+	}
+}
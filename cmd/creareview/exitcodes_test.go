@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/review"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, exitOK},
+		{"generic", errors.New("boom"), exitError},
+		{"backend unavailable", fmt.Errorf("init reviewer: %w", review.ErrBackendUnavailable), exitBackendUnavailable},
+		{"unknown backend", fmt.Errorf("init reviewer: %w", review.ErrUnknownBackend), exitConfigError},
+		{"gate failed", fmt.Errorf("%w: security has 1 finding (max 0)", review.ErrGateFailed), exitGateFailed},
+		{"invalid config", fmt.Errorf("gather context: load config: %w", rcontext.ErrInvalidConfig), exitConfigError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewReviewerUnknownBackendExitCode(t *testing.T) {
+	_, err := review.NewReviewer(review.Backend("openai"))
+	if got := exitCode(err); got != exitConfigError {
+		t.Errorf("exitCode() = %d, want %d", got, exitConfigError)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+// wholeFileMode reports whether files are reviewed in full instead of a diff:
+// --one-shot, or --files-from, which implies it.
+func wholeFileMode() bool {
+	return *oneShot || *filesFrom != ""
+}
+
+// readPathList reads newline-separated paths from r, trimming surrounding
+// whitespace and skipping blank lines.
+func readPathList(r io.Reader) ([]string, error) {
+	var paths []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if p := strings.TrimSpace(scanner.Text()); p != "" {
+			paths = append(paths, p)
+		}
+	}
+
+	return paths, scanner.Err()
+}
+
+// filesFromPaths reads the --files-from list (stdin for "-") and returns the
+// files to review as absolute paths. Listed paths are relative to the repo
+// root, as printed by git diff --name-only, or absolute. Files that don't
+// exist (e.g. deleted in the diff), directories and files git doesn't track
+// are skipped with a warning on warn.
+func filesFromPaths(ctx context.Context, repoRoot, source string, stdin io.Reader, warn io.Writer) ([]string, error) {
+	r := stdin
+
+	if source != "-" {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("%w: --files-from: %w", rcontext.ErrInvalidConfig, err)
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	listed, err := readPathList(r)
+	if err != nil {
+		return nil, fmt.Errorf("read --files-from: %w", err)
+	}
+
+	rels := make([]string, 0, len(listed))
+
+	for _, p := range listed {
+		rel, err := repoRelPath(repoRoot, repoRoot, p, "--files-from", false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+		}
+
+		info, err := os.Stat(filepath.Join(repoRoot, filepath.FromSlash(rel)))
+
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			fmt.Fprintf(warn, "warning: skipping %s: no such file\n", p)
+		case err != nil:
+			fmt.Fprintf(warn, "warning: skipping %s: %v\n", p, err)
+		case info.IsDir():
+			fmt.Fprintf(warn, "warning: skipping %s: is a directory\n", p)
+		default:
+			rels = append(rels, rel)
+		}
+	}
+
+	tracked, err := rcontext.TrackedFiles(ctx, repoRoot, rels)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	for _, rel := range rels {
+		if !tracked[rel] {
+			fmt.Fprintf(warn, "warning: skipping %s: not tracked by git\n", rel)
+
+			continue
+		}
+
+		paths = append(paths, filepath.Join(repoRoot, filepath.FromSlash(rel)))
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: --files-from lists no tracked files", rcontext.ErrInvalidConfig)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestReadPathList(t *testing.T) {
+	got, err := readPathList(strings.NewReader("a.go\n\n  pkg/b.go \r\n\nc.go"))
+	if err != nil {
+		t.Fatalf("readPathList() error = %v", err)
+	}
+
+	want := []string{"a.go", "pkg/b.go", "c.go"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("readPathList() = %q, want %q", got, want)
+	}
+}
+
+func TestFilesFromPaths(t *testing.T) {
+	root := testutil.GitRepo(t)
+	testutil.WriteFiles(t, root, map[string]string{
+		"tracked.go":   "package x\n",
+		"pkg/lib.go":   "package x\n",
+		"untracked.go": "package x\n",
+	})
+	testutil.Git(t, root, "add", "tracked.go", "pkg/lib.go")
+
+	var warn bytes.Buffer
+
+	list := "tracked.go\npkg/lib.go\nuntracked.go\ndeleted.go\npkg\n"
+
+	got, err := filesFromPaths(context.Background(), root, "-", strings.NewReader(list), &warn)
+	if err != nil {
+		t.Fatalf("filesFromPaths() error = %v", err)
+	}
+
+	want := []string{filepath.Join(root, "tracked.go"), filepath.Join(root, "pkg", "lib.go")}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("filesFromPaths() = %q, want %q", got, want)
+	}
+
+	for _, msg := range []string{
+		"skipping untracked.go: not tracked by git",
+		"skipping deleted.go: no such file",
+		"skipping pkg: is a directory",
+	} {
+		if !strings.Contains(warn.String(), msg) {
+			t.Errorf("warnings missing %q:\n%s", msg, warn.String())
+		}
+	}
+
+	t.Run("from a file", func(t *testing.T) {
+		listFile := filepath.Join(t.TempDir(), "files.txt")
+		if err := os.WriteFile(listFile, []byte("tracked.go\n"), 0o644); err != nil {
+			t.Fatalf("write list: %v", err)
+		}
+
+		got, err := filesFromPaths(context.Background(), root, listFile, nil, &warn)
+		if err != nil || len(got) != 1 {
+			t.Errorf("filesFromPaths() = %q, %v; want tracked.go", got, err)
+		}
+	})
+
+	for name, list := range map[string]string{
+		"nothing tracked":  "untracked.go\n",
+		"empty list":       "\n",
+		"outside the repo": "../elsewhere.go\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := filesFromPaths(context.Background(), root, "-", strings.NewReader(list), &warn)
+			if !errors.Is(err, rcontext.ErrInvalidConfig) {
+				t.Errorf("filesFromPaths() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+
+	if _, err := filesFromPaths(context.Background(), root, filepath.Join(root, "missing.txt"), nil, &warn); !errors.Is(err, rcontext.ErrInvalidConfig) {
+		t.Errorf("missing list file: error = %v, want ErrInvalidConfig", err)
+	}
+}
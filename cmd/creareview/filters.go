@@ -0,0 +1,333 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/crealfy/crea-review/pkg/batch"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/priority"
+)
+
+// scoreFilter holds the post-scoring filters applied before sorting and the max-files cut.
+// Files matching always are exempt from every filter.
+type scoreFilter struct {
+	minLines  int
+	minScore  float64
+	skipTests bool
+	always    []string
+}
+
+// filterCounts records how many files each filter dropped.
+type filterCounts struct {
+	belowFloor   int
+	belowScore   int
+	testsSkipped int
+}
+
+// total returns the number of files dropped by all filters.
+func (c filterCounts) total() int {
+	return c.belowFloor + c.belowScore + c.testsSkipped
+}
+
+// applyScoreFilters runs the min-lines, min-score and skip-tests filters in order.
+func applyScoreFilters(scores []priority.Score, f scoreFilter) ([]priority.Score, filterCounts) {
+	var counts filterCounts
+
+	kept := scores
+	kept, counts.belowFloor = applyMinLines(kept, f.minLines)
+	kept, counts.belowScore = applyMinScore(kept, f.minScore)
+	kept, counts.testsSkipped = skipTestFiles(kept, f.skipTests)
+
+	if len(f.always) == 0 || len(kept) == len(scores) {
+		return kept, counts
+	}
+
+	// Restore always-review files in their original order
+	keep := make(map[string]bool, len(kept))
+	for _, s := range kept {
+		keep[s.Path] = true
+	}
+
+	result := make([]priority.Score, 0, len(scores))
+
+	for _, s := range scores {
+		if keep[s.Path] {
+			result = append(result, s)
+
+			continue
+		}
+
+		if !batch.MatchesAny(s.Path, f.always) {
+			continue
+		}
+
+		result = append(result, s)
+
+		switch {
+		case s.LinesChanged < f.minLines && !s.IsCriticalPath:
+			counts.belowFloor--
+		case f.minScore > 0 && s.Total < f.minScore:
+			counts.belowScore--
+		default:
+			counts.testsSkipped--
+		}
+	}
+
+	return result, counts
+}
+
+// resolveScope converts --path to a directory relative to repoRoot. The
+// directory may no longer exist, e.g. when the diff deletes it.
+func resolveScope(repoRoot, workDir string) (string, error) {
+	if *scopePath == "" {
+		return "", nil
+	}
+
+	scope, err := repoRelPath(repoRoot, workDir, *scopePath, "--path", false)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	return scope, nil
+}
+
+// scopeFiles keeps the changed files under the repo-relative directory scope
+// (from --path) and returns how many fell outside it. An empty scope or "."
+// keeps everything.
+func scopeFiles(files []rcontext.FileContent, scope string) ([]rcontext.FileContent, int) {
+	if scope == "" || scope == "." {
+		return files, 0
+	}
+
+	kept := make([]rcontext.FileContent, 0, len(files))
+
+	for _, f := range files {
+		if inScope(f.Path, scope) {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept, len(files) - len(kept)
+}
+
+// scopeDiff drops the file sections of diff outside scope, so the prompt
+// carries the same files as scopeFiles.
+func scopeDiff(diff, scope string) string {
+	if scope == "" || scope == "." {
+		return diff
+	}
+
+	return rcontext.FilterDiff(diff, func(p string) bool { return inScope(p, scope) })
+}
+
+// inScope reports whether the repo-relative path is scope or lies under it.
+func inScope(p, scope string) bool {
+	return p == scope || strings.HasPrefix(p, scope+"/")
+}
+
+// validateGlobs checks that each --always-review pattern is a valid glob.
+func validateGlobs(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid --always-review pattern %q: %w", p, err)
+		}
+	}
+
+	return nil
+}
+
+// applySample caps how many files scorer scores at --sample, for changes
+// too large to score in full. Files matching always are kept; the others
+// are sampled with --sample-strategy and the rest excluded from scoring
+// alongside excludeFiles. It warns when it samples and returns the number of
+// files left out, which stay for --continue.
+func applySample(scorer *priority.Scorer, files []rcontext.FileContent, excludeFiles, always []string) int {
+	if *sampleSize <= 0 || len(files) <= *sampleSize {
+		return 0
+	}
+
+	strategy, _ := priority.ParseSampleStrategy(*sampleStrat)
+
+	var pinned, pool []rcontext.FileContent
+
+	for _, f := range files {
+		if batch.MatchesAny(f.Path, always) {
+			pinned = append(pinned, f)
+		} else {
+			pool = append(pool, f)
+		}
+	}
+
+	var sample []rcontext.FileContent
+	if budget := *sampleSize - len(pinned); budget > 0 {
+		sample = scorer.Sample(pool, budget, strategy)
+	}
+
+	sampled := make(map[string]bool, len(sample))
+	for _, f := range sample {
+		sampled[f.Path] = true
+	}
+
+	exclude := slices.Clone(excludeFiles)
+	for _, f := range pool {
+		if !sampled[f.Path] {
+			exclude = append(exclude, f.Path)
+		}
+	}
+
+	scorer.WithExclude(exclude)
+
+	fmt.Fprintf(os.Stderr, "warning: %d files changed; scoring a %s sample of %d (--sample)\n",
+		len(files), strategy, len(pinned)+len(sample))
+
+	return len(pool) - len(sample)
+}
+
+// reportFiltered reports through progress how many files each score filter
+// dropped.
+func reportFiltered(progress func(string), counts filterCounts) {
+	if counts.belowFloor > 0 {
+		progress(fmt.Sprintf("   Skipping %d files with fewer than %d changed lines", counts.belowFloor, *minLines))
+	}
+	if counts.belowScore > 0 {
+		progress(fmt.Sprintf("   Skipping %d files scoring below %.1f", counts.belowScore, *minScore))
+	}
+	if counts.testsSkipped > 0 {
+		progress(fmt.Sprintf("   Skipping %d test files", counts.testsSkipped))
+	}
+}
+
+// explainScores writes one line per selected file saying why it made the
+// cut, for --explain. Files matching always are marked as forced in.
+func explainScores(w io.Writer, scores []priority.Score, always []string) {
+	for _, s := range scores {
+		reason := s.Explain()
+		if batch.MatchesAny(s.Path, always) {
+			reason += " (always-review)"
+		}
+
+		fmt.Fprintf(w, "   %s: included: %s\n", s.Path, reason)
+	}
+}
+
+// applyMaxFiles applies --max-files to scores, failing with --on-limit stop
+// and reporting the cut through progress otherwise.
+func applyMaxFiles(progress func(string), scores []priority.Score) ([]priority.Score, error) {
+	if *maxFiles <= 0 || len(scores) <= *maxFiles {
+		return scores, nil
+	}
+
+	if *onLimit == "stop" {
+		return nil, fmt.Errorf("too many files: %d (max %d). Use --on-limit continue or increase --max-files",
+			len(scores), *maxFiles)
+	}
+
+	filesToReview := batch.Limit(scores, *maxFiles, alwaysReview)
+	progress(fmt.Sprintf("   Reviewing top %d files (by priority), %d remaining",
+		len(filesToReview), len(scores)-len(filesToReview)))
+
+	return filesToReview, nil
+}
+
+// applyMinLines drops files with fewer than minLines changed lines.
+// Critical-path files are always kept regardless of size.
+// Returns the kept scores and the number of files dropped.
+func applyMinLines(scores []priority.Score, minLines int) ([]priority.Score, int) {
+	if minLines <= 0 {
+		return scores, 0
+	}
+
+	kept := make([]priority.Score, 0, len(scores))
+
+	for _, s := range scores {
+		if s.LinesChanged < minLines && !s.IsCriticalPath {
+			continue
+		}
+
+		kept = append(kept, s)
+	}
+
+	return kept, len(scores) - len(kept)
+}
+
+// applyMinScore drops files whose total priority score is below minScore.
+// Returns the kept scores and the number of files dropped.
+func applyMinScore(scores []priority.Score, minScore float64) ([]priority.Score, int) {
+	if minScore <= 0 {
+		return scores, 0
+	}
+
+	kept := make([]priority.Score, 0, len(scores))
+
+	for _, s := range scores {
+		if s.Total < minScore {
+			continue
+		}
+
+		kept = append(kept, s)
+	}
+
+	return kept, len(scores) - len(kept)
+}
+
+// skipTestFiles drops test files when skip is set. It runs after scoring, so
+// source files keep the HasTests signal from the tests being dropped.
+// Returns the kept scores and the number of files dropped.
+func skipTestFiles(scores []priority.Score, skip bool) ([]priority.Score, int) {
+	if !skip {
+		return scores, 0
+	}
+
+	kept := make([]priority.Score, 0, len(scores))
+
+	for _, s := range scores {
+		if priority.IsTestFile(s.Path) {
+			continue
+		}
+
+		kept = append(kept, s)
+	}
+
+	return kept, len(scores) - len(kept)
+}
+
+// sortScores sorts files based on the sort order.
+func sortScores(scores []priority.Score, sortOrder string) []priority.Score {
+	switch sortOrder {
+	case "alpha":
+		// Sort alphabetically by path
+		for i := range scores {
+			for j := i + 1; j < len(scores); j++ {
+				if scores[j].Path < scores[i].Path {
+					scores[i], scores[j] = scores[j], scores[i]
+				}
+			}
+		}
+	case "size":
+		// Biggest changes first; ties keep priority order
+		slices.SortStableFunc(scores, func(a, b priority.Score) int {
+			return cmp.Compare(b.LinesChanged, a.LinesChanged)
+		})
+	case "none":
+		// Keep original order (no sorting needed, but scores come pre-sorted by priority)
+		// Return as-is from gather order
+	default: // "priority"
+		// Already sorted by priority from ScoreFiles
+	}
+
+	return scores
+}
+
+// keepSelected narrows reviewCtx to the selected files and records in its
+// stats how many of the scored and skipped files were left out.
+func keepSelected(reviewCtx *rcontext.ReviewContext, selected []priority.Score, scored, skipped int) {
+	reviewCtx.ChangedFiles = batch.Files(reviewCtx.ChangedFiles, selected)
+	reviewCtx.Stats.ReviewedFiles = len(reviewCtx.ChangedFiles)
+	reviewCtx.Stats.SkippedFiles = scored - len(reviewCtx.ChangedFiles) + skipped
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/priority"
+)
+
+func TestApplyMinLines(t *testing.T) {
+	scores := []priority.Score{
+		{Path: "config.yaml", LinesChanged: 1},
+		{Path: "pkg/auth/session.go", LinesChanged: 1, IsCriticalPath: true},
+		{Path: "main.go", LinesChanged: 12},
+		{Path: "util.go", LinesChanged: 5},
+	}
+
+	tests := []struct {
+		name        string
+		minLines    int
+		wantPaths   []string
+		wantDropped int
+	}{
+		{
+			name:      "disabled",
+			minLines:  0,
+			wantPaths: []string{"config.yaml", "pkg/auth/session.go", "main.go", "util.go"},
+		},
+		{
+			name:        "floor keeps critical paths",
+			minLines:    5,
+			wantPaths:   []string{"pkg/auth/session.go", "main.go", "util.go"},
+			wantDropped: 1,
+		},
+		{
+			name:        "high floor",
+			minLines:    100,
+			wantPaths:   []string{"pkg/auth/session.go"},
+			wantDropped: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dropped := applyMinLines(scores, tt.minLines)
+
+			if dropped != tt.wantDropped {
+				t.Errorf("dropped = %d, want %d", dropped, tt.wantDropped)
+			}
+
+			if len(got) != len(tt.wantPaths) {
+				t.Fatalf("got %d scores, want %d", len(got), len(tt.wantPaths))
+			}
+
+			for i, want := range tt.wantPaths {
+				if got[i].Path != want {
+					t.Errorf("got[%d].Path = %q, want %q", i, got[i].Path, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipTestFiles(t *testing.T) {
+	files := []rcontext.FileContent{
+		{Path: "pkg/auth/handler.go", LinesAdded: 20},
+		{Path: "pkg/auth/handler_test.go", LinesAdded: 30},
+		{Path: "web/app.test.js", LinesAdded: 5},
+		{Path: "pkg/util/helper.go", LinesAdded: 10},
+	}
+
+	scores, err := priority.NewScorer(t.TempDir()).ScoreFiles(context.Background(), files)
+	if err != nil {
+		t.Fatalf("ScoreFiles() error = %v", err)
+	}
+
+	if kept, dropped := skipTestFiles(scores, false); len(kept) != 4 || dropped != 0 {
+		t.Errorf("skip=false kept %d, dropped %d, want 4 and 0", len(kept), dropped)
+	}
+
+	kept, dropped := skipTestFiles(scores, true)
+	if dropped != 2 || len(kept) != 2 {
+		t.Fatalf("kept %d, dropped %d, want 2 and 2", len(kept), dropped)
+	}
+
+	for _, s := range kept {
+		if priority.IsTestFile(s.Path) {
+			t.Errorf("test file %s was not skipped", s.Path)
+		}
+
+		if s.Path == "pkg/auth/handler.go" && !s.HasTests {
+			t.Error("handler.go should keep HasTests=true when its test is skipped from review")
+		}
+	}
+}
+
+func TestApplyScoreFilters(t *testing.T) {
+	scores := []priority.Score{
+		{Path: "pkg/auth/login.go", Total: 80, LinesChanged: 40, IsCriticalPath: true},
+		{Path: "pkg/auth/login_test.go", Total: 55, LinesChanged: 30},
+		{Path: "pkg/api/handler.go", Total: 45, LinesChanged: 20},
+		{Path: "README.md", Total: 10, LinesChanged: 2},
+	}
+
+	tests := []struct {
+		name       string
+		filter     scoreFilter
+		wantPaths  []string
+		wantCounts filterCounts
+	}{
+		{
+			name:      "no filters",
+			wantPaths: []string{"pkg/auth/login.go", "pkg/auth/login_test.go", "pkg/api/handler.go", "README.md"},
+		},
+		{
+			name:       "min score",
+			filter:     scoreFilter{minScore: 50},
+			wantPaths:  []string{"pkg/auth/login.go", "pkg/auth/login_test.go"},
+			wantCounts: filterCounts{belowScore: 2},
+		},
+		{
+			name:       "min score is inclusive",
+			filter:     scoreFilter{minScore: 45},
+			wantPaths:  []string{"pkg/auth/login.go", "pkg/auth/login_test.go", "pkg/api/handler.go"},
+			wantCounts: filterCounts{belowScore: 1},
+		},
+		{
+			name:       "min lines counted before min score",
+			filter:     scoreFilter{minLines: 5, minScore: 50},
+			wantPaths:  []string{"pkg/auth/login.go", "pkg/auth/login_test.go"},
+			wantCounts: filterCounts{belowFloor: 1, belowScore: 1},
+		},
+		{
+			name:       "all filters",
+			filter:     scoreFilter{minLines: 5, minScore: 40, skipTests: true},
+			wantPaths:  []string{"pkg/auth/login.go", "pkg/api/handler.go"},
+			wantCounts: filterCounts{belowFloor: 1, testsSkipped: 1},
+		},
+		{
+			name:       "always-review files skip filters",
+			filter:     scoreFilter{minLines: 5, minScore: 50, skipTests: true, always: []string{"*.md", "pkg/api/*"}},
+			wantPaths:  []string{"pkg/auth/login.go", "pkg/api/handler.go", "README.md"},
+			wantCounts: filterCounts{testsSkipped: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, counts := applyScoreFilters(scores, tt.filter)
+
+			if counts != tt.wantCounts {
+				t.Errorf("counts = %+v, want %+v", counts, tt.wantCounts)
+			}
+
+			if counts.total() != len(scores)-len(got) {
+				t.Errorf("total() = %d, want %d", counts.total(), len(scores)-len(got))
+			}
+
+			if len(got) != len(tt.wantPaths) {
+				t.Fatalf("got %d scores, want %d", len(got), len(tt.wantPaths))
+			}
+
+			for i, want := range tt.wantPaths {
+				if got[i].Path != want {
+					t.Errorf("got[%d].Path = %q, want %q", i, got[i].Path, want)
+				}
+			}
+		})
+	}
+}
+
+func TestScopeFiles(t *testing.T) {
+	files := []rcontext.FileContent{
+		{Path: "services/billing/invoice.go"},
+		{Path: "services/billing-v2/main.go"},
+		{Path: "services/billing"},
+		{Path: "README.md"},
+	}
+
+	tests := []struct {
+		scope   string
+		want    []string
+		outside int
+	}{
+		{"", []string{"services/billing/invoice.go", "services/billing-v2/main.go", "services/billing", "README.md"}, 0},
+		{".", []string{"services/billing/invoice.go", "services/billing-v2/main.go", "services/billing", "README.md"}, 0},
+		{"services/billing", []string{"services/billing/invoice.go", "services/billing"}, 2},
+		{"services/search", nil, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			got, outside := scopeFiles(files, tt.scope)
+
+			var paths []string
+			for _, f := range got {
+				paths = append(paths, f.Path)
+			}
+
+			if !slices.Equal(paths, tt.want) || outside != tt.outside {
+				t.Errorf("scopeFiles(%q) = %v, %d; want %v, %d", tt.scope, paths, outside, tt.want, tt.outside)
+			}
+		})
+	}
+}
+
+func TestScopeDiff(t *testing.T) {
+	diff := "diff --git a/services/billing/invoice.go b/services/billing/invoice.go\n+a\n" +
+		"diff --git a/services/billing-v2/main.go b/services/billing-v2/main.go\n+b\n"
+
+	if got := scopeDiff(diff, "."); got != diff {
+		t.Errorf("scopeDiff(.) = %q, want the whole diff", got)
+	}
+
+	want := "diff --git a/services/billing/invoice.go b/services/billing/invoice.go\n+a\n"
+	if got := scopeDiff(diff, "services/billing"); got != want {
+		t.Errorf("scopeDiff(services/billing) = %q, want %q", got, want)
+	}
+}
+
+func TestExplainScores(t *testing.T) {
+	scores := []priority.Score{
+		{Path: "pkg/auth/login.go", Total: 60, LinesChanged: 10, IsCriticalPath: true},
+		{Path: "docs/readme.md", Total: 5, LinesChanged: 2},
+	}
+
+	var sb strings.Builder
+
+	explainScores(&sb, scores, []string{"docs/*"})
+
+	want := "   pkg/auth/login.go: included: score 60.0: critical-path, 10 lines changed\n" +
+		"   docs/readme.md: included: score 5.0: 2 lines changed (always-review)\n"
+	if sb.String() != want {
+		t.Errorf("explainScores() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
+
+func TestApplySample(t *testing.T) {
+	oldSize, oldStrat := *sampleSize, *sampleStrat
+	t.Cleanup(func() { *sampleSize, *sampleStrat = oldSize, oldStrat })
+
+	files := []rcontext.FileContent{
+		{Path: "a/one.go", LinesAdded: 50},
+		{Path: "a/two.go", LinesAdded: 40},
+		{Path: "b/three.go", LinesAdded: 30},
+		{Path: "docs/guide.md", LinesAdded: 1},
+		{Path: "reviewed.go", LinesAdded: 99},
+	}
+
+	tests := []struct {
+		name          string
+		size          int
+		strategy      string
+		always        []string
+		wantUnsampled int
+		wantScored    []string
+	}{
+		{"off", 0, "stratified", nil, 0, []string{"a/one.go", "a/two.go", "b/three.go", "docs/guide.md"}},
+		{"largest", 2, "largest", nil, 2, []string{"a/one.go", "a/two.go"}},
+		{"always-review kept", 2, "largest", []string{"docs/*"}, 2, []string{"a/one.go", "docs/guide.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*sampleSize, *sampleStrat = tt.size, tt.strategy
+
+			scorer := priority.NewScorer(t.TempDir()).WithExclude([]string{"reviewed.go"})
+
+			// reviewed.go stands for a file from a continued session: gathered
+			// files never include it, but its exclusion must survive
+			if got := applySample(scorer, files[:4], []string{"reviewed.go"}, tt.always); got != tt.wantUnsampled {
+				t.Errorf("applySample() = %d, want %d", got, tt.wantUnsampled)
+			}
+
+			scores, err := scorer.ScoreFiles(context.Background(), files)
+			if err != nil {
+				t.Fatalf("ScoreFiles() error = %v", err)
+			}
+
+			var got []string
+			for _, s := range scores {
+				got = append(got, s.Path)
+			}
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.wantScored) {
+				t.Errorf("scored %v, want %v", got, tt.wantScored)
+			}
+		})
+	}
+}
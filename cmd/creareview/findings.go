@@ -0,0 +1,68 @@
+package main
+
+import (
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/review"
+)
+
+// findingPolicy holds the parsed flags that act on findings after a review.
+type findingPolicy struct {
+	gates    []review.Gate
+	baseline *review.Baseline
+}
+
+// newFindingPolicy parses --gate and --fail-on and loads --baseline,
+// so mistakes surface before the review runs.
+func newFindingPolicy() (findingPolicy, error) {
+	gates, err := parseGates(gateSpecs, *failOn)
+	if err != nil {
+		return findingPolicy{}, err
+	}
+
+	policy := findingPolicy{gates: gates}
+
+	if *baselineFile != "" {
+		if policy.baseline, err = review.LoadBaseline(*baselineFile); err != nil {
+			return findingPolicy{}, err
+		}
+	}
+
+	return policy, nil
+}
+
+// apply post-processes findings in order: profile focus, --collapse-ranges,
+// finding IDs, --write-baseline, --baseline, gates, doc URLs and --context. The gate
+// error is returned separately so the report can be written before it fails the run.
+// --max-findings is left to buildOutput, so the session keeps every finding.
+// Partial results from an interrupted review go through apply too, but don't
+// write a baseline.
+func (p findingPolicy) apply(result *review.Result, reviewCtx *rcontext.ReviewContext) (gateErr, err error) {
+	result.Findings = review.FilterCategories(result.Findings, reviewCtx.Config.Focus)
+
+	if *collapseRanges {
+		result.Findings = review.CollapseRanges(result.Findings)
+	}
+
+	review.AssignIDs(result.Findings)
+
+	// The new baseline covers everything found, including already baselined
+	// findings; a partial review would drop the ones it didn't get to
+	if *writeBaseline != "" && !result.Partial {
+		if err := review.WriteBaseline(*writeBaseline, result.Findings); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Findings, result.BaselinedFindings = p.baseline.Filter(result.Findings)
+
+	// Gates see every new finding, including those --max-findings drops from the report
+	gateErr = review.CheckGates(result.Findings, p.gates, *failOn)
+
+	review.AttachDocURLs(result.Findings, reviewCtx.Config.DocURLs)
+
+	if *contextLines > 0 {
+		review.AttachContext(result.Findings, reviewCtx.RepoPath, *contextLines)
+	}
+
+	return gateErr, nil
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/priority"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// envVars is a flag.Value that collects KEY=VALUE pairs.
+type envVars map[string]string
+
+func (e *envVars) String() string {
+	if e == nil || *e == nil {
+		return ""
+	}
+
+	var pairs []string
+	for k, v := range *e {
+		pairs = append(pairs, k+"="+v)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (e *envVars) Set(value string) error {
+	if *e == nil {
+		*e = make(map[string]string)
+	}
+
+	k, v, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("invalid env format %q, expected KEY=VALUE", value)
+	}
+
+	(*e)[k] = v
+
+	return nil
+}
+
+// stringList is a flag.Value that collects repeated string values.
+type stringList []string
+
+func (s *stringList) String() string {
+	if s == nil {
+		return ""
+	}
+
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
+
+// validateFlags checks flag combinations and values that don't need the repo.
+// Errors wrap rcontext.ErrInvalidConfig.
+func validateFlags() error {
+	// --version returns before validation, so --json here has nothing to format
+	if *versionJSON {
+		return fmt.Errorf("%w: --json requires --version (use --format json for review output)", rcontext.ErrInvalidConfig)
+	}
+
+	if *withLinters && *linterCmd == "" {
+		return fmt.Errorf("%w: --with-linters requires --linter to specify the linter command", rcontext.ErrInvalidConfig)
+	}
+
+	if *lintBaseline && !*withLinters {
+		return fmt.Errorf("%w: --lint-baseline requires --with-linters", rcontext.ErrInvalidConfig)
+	}
+
+	if wholeFileMode() && *continueFrom > 0 {
+		return fmt.Errorf("%w: --one-shot and --files-from can't be used with --continue", rcontext.ErrInvalidConfig)
+	}
+
+	if *cacheTTL < 0 {
+		return fmt.Errorf("%w: --cache-ttl must not be negative", rcontext.ErrInvalidConfig)
+	}
+
+	if wholeFileMode() && (*useCache || *noCache) {
+		return fmt.Errorf("%w: --cache and --no-cache can't be used with --one-shot or --files-from", rcontext.ErrInvalidConfig)
+	}
+
+	if *formatSess < 0 {
+		return fmt.Errorf("%w: --format-session must be a session ID", rcontext.ErrInvalidConfig)
+	}
+
+	if wholeFileMode() && *formatSess > 0 {
+		return fmt.Errorf("%w: --format-session can't be used with --one-shot or --files-from", rcontext.ErrInvalidConfig)
+	}
+
+	if _, err := loadFixPromptTemplate(); err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	if *sampleSize < 0 {
+		return fmt.Errorf("%w: --sample must be >= 0", rcontext.ErrInvalidConfig)
+	}
+
+	if _, err := priority.ParseSampleStrategy(*sampleStrat); err != nil {
+		return fmt.Errorf("%w: --sample-strategy: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	if _, err := rcontext.ParseEnvModels(*envModel); err != nil {
+		return fmt.Errorf("%w: --env-model: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	if _, err := rcontext.ParseContentMode(*contentMode); err != nil {
+		return fmt.Errorf("%w: --content: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	if *contentCtx < 0 {
+		return fmt.Errorf("%w: --content-context must be >= 0", rcontext.ErrInvalidConfig)
+	}
+
+	if wholeFileMode() && *contentMode != string(rcontext.ContentNone) {
+		return fmt.Errorf("%w: --one-shot and --files-from always embed whole files; --content can't be used with them", rcontext.ErrInvalidConfig)
+	}
+
+	if wholeFileMode() && *badge {
+		return fmt.Errorf("%w: --badge can't be used with --one-shot or --files-from", rcontext.ErrInvalidConfig)
+	}
+
+	if *dailyBudget < 0 {
+		return fmt.Errorf("%w: --daily-file-budget must be >= 0", rcontext.ErrInvalidConfig)
+	}
+
+	if *onBudget != onBudgetStop && *onBudget != onBudgetWarn {
+		return fmt.Errorf("%w: invalid --on-budget %q, expected stop or warn", rcontext.ErrInvalidConfig, *onBudget)
+	}
+
+	if wholeFileMode() && *dailyBudget > 0 {
+		return fmt.Errorf("%w: --daily-file-budget can't be used with --one-shot or --files-from", rcontext.ErrInvalidConfig)
+	}
+
+	if wholeFileMode() && *scoreOnly {
+		return fmt.Errorf("%w: --score-only needs a diff and can't be used with --one-shot or --files-from", rcontext.ErrInvalidConfig)
+	}
+
+	if wholeFileMode() && *addedOnly {
+		return fmt.Errorf("%w: --added-only needs a diff and can't be used with --one-shot or --files-from", rcontext.ErrInvalidConfig)
+	}
+
+	if err := validateSessionFlags(); err != nil {
+		return err
+	}
+
+	if *stateInRepo && *stateDir != "" {
+		return fmt.Errorf("%w: --state-in-repo and --state-dir are mutually exclusive", rcontext.ErrInvalidConfig)
+	}
+
+	if *prNumber < 0 {
+		return fmt.Errorf("%w: --pr must be a pull request number", rcontext.ErrInvalidConfig)
+	}
+
+	if *prNumber > 0 && (*baseBranch != "" || *baseCommit != "" || *headCommit != "" || *continueFrom > 0 || wholeFileMode()) {
+		return fmt.Errorf("%w: --pr sets the range and can't be used with --base, --base-commit, --head-commit, --continue, --one-shot or --files-from", rcontext.ErrInvalidConfig)
+	}
+
+	if *untracked && (*headCommit != "" || *baseBranch != "" || *prNumber > 0 || wholeFileMode() ||
+		(*baseCommit == "" && *reviewType == "committed")) {
+		return fmt.Errorf("%w: --include-untracked needs the working tree as head (-t all or uncommitted, or --base-commit without --head-commit)", rcontext.ErrInvalidConfig)
+	}
+
+	if *headCommit != "" && *baseCommit == "" && *baseBranch == "" {
+		return fmt.Errorf("%w: --head-commit requires --base-commit or --base", rcontext.ErrInvalidConfig)
+	}
+
+	if *contextLines < 0 {
+		return fmt.Errorf("%w: --context must be >= 0", rcontext.ErrInvalidConfig)
+	}
+
+	if err := validateColorMode(*colorMode); err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	if err := validateGlobs(alwaysReview); err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	return nil
+}
+
+// newGatherOptions builds the diff-mode gather options from the flags.
+// Files in excludeFiles (already reviewed, for --continue) are skipped.
+// --lint-baseline caches base commit linter findings in store's state dir.
+func newGatherOptions(excludeFiles []string, store *session.Store) (rcontext.GatherOptions, error) {
+	content, err := rcontext.ParseContentMode(*contentMode)
+	if err != nil {
+		return rcontext.GatherOptions{}, fmt.Errorf("%w: --content: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	defaults := rcontext.DefaultGatherOptions()
+
+	opts := rcontext.GatherOptions{
+		BaseCommit:          *baseCommit,
+		HeadCommit:          *headCommit,
+		BaseBranch:          *baseBranch,
+		ReviewType:          *reviewType,
+		IncludeLinters:      *withLinters,
+		IncludePRTemplate:   *withPRTmpl,
+		IncludeCommits:      *withCommits,
+		IncludeEditorConfig: *withEditorCf,
+		LinterCommand:       *linterCmd,
+		LintAll:             *lintAll,
+		LinterEnv:           env,
+		MaxFiles:            0, // Don't limit here, we'll do it after scoring
+		SkipDeleted:         *skipDeleted,
+		AddedOnly:           *addedOnly,
+		IncludeUntracked:    *untracked,
+		ExcludeFiles:        excludeFiles,
+		Profile:             *profile,
+		Content:             content,
+		ContentContext:      *contentCtx,
+		MaxFileLines:        defaults.MaxFileLines,
+		MaxLineBytes:        defaults.MaxLineBytes,
+		MaxFileBytes:        defaults.MaxFileBytes,
+	}
+
+	files, err := absConfigFiles()
+	if err != nil {
+		return rcontext.GatherOptions{}, err
+	}
+
+	opts.ConfigFiles = files
+
+	if *lintBaseline && store != nil {
+		opts.LintBaselineDir = filepath.Join(store.StateDir, "lint-baselines")
+	}
+
+	return opts, nil
+}
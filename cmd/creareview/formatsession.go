@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// renderSession renders stored session id with formatter and writes the
+// --output files, for --format-session. Nothing is reviewed again: the
+// findings are rendered as saved.
+func renderSession(w io.Writer, store *session.Store, id int, formatter *output.Formatter,
+	displayBase string, targets []outputTarget,
+) error {
+	sess, err := store.Load(id)
+	if err != nil {
+		return fmt.Errorf("load session %d: %w", id, err)
+	}
+
+	out, err := buildOutput(&review.Result{Findings: sess.Findings}, sess, displayBase)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Render(w, out); err != nil {
+		return fmt.Errorf("format output: %w", err)
+	}
+
+	return writeOutputFiles(targets, out)
+}
+
+// printBadge writes a shields.io endpoint badge for the latest session's
+// findings, for --badge.
+func printBadge(w io.Writer, store *session.Store) error {
+	latest, err := store.LoadLatest()
+	if err != nil {
+		return fmt.Errorf("load latest session: %w", err)
+	}
+
+	sess, err := store.Load(latest.ID)
+	if err != nil {
+		return fmt.Errorf("load session %d: %w", latest.ID, err)
+	}
+
+	return output.WriteBadge(w, output.NewBadge(sess))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestRenderSession(t *testing.T) {
+	store, err := session.NewStore(t.TempDir(), t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	sess := &session.Session{
+		BaseCommit:    "abc123",
+		FilesReviewed: 1,
+		Status:        session.StatusCompleted,
+		Files:         []string{"main.go"},
+		Findings: []session.Finding{
+			{ID: "1a2b3c4d", File: "main.go", Line: 12, Severity: "error", Category: "bug", Description: "nil map write"},
+		},
+	}
+	if err := store.Create(sess); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	var jsonOut bytes.Buffer
+	if err := renderSession(&jsonOut, store, sess.ID, output.NewFormatter(output.FormatJSON), "", nil); err != nil {
+		t.Fatalf("renderSession(json) error = %v", err)
+	}
+
+	var got output.Output
+	if err := json.Unmarshal(jsonOut.Bytes(), &got); err != nil {
+		t.Fatalf("JSON output doesn't parse: %v\n%s", err, jsonOut.String())
+	}
+
+	if got.SessionID != sess.ID || got.BaseCommit != "abc123" || len(got.Findings) != 1 || got.Findings[0] != sess.Findings[0] {
+		t.Errorf("JSON output = %+v, want session %d with its finding", got, sess.ID)
+	}
+
+	var plainOut bytes.Buffer
+	if err := renderSession(&plainOut, store, sess.ID, output.NewFormatter(output.FormatPlain).WithNoColor(), "", nil); err != nil {
+		t.Fatalf("renderSession(plain) error = %v", err)
+	}
+
+	for _, want := range []string{"File: main.go:12", "ID: 1a2b3c4d", "nil map write"} {
+		if !strings.Contains(plainOut.String(), want) {
+			t.Errorf("plain output missing %q:\n%s", want, plainOut.String())
+		}
+	}
+
+	if err := renderSession(&plainOut, store, 99, output.NewFormatter(output.FormatPlain), "", nil); err == nil {
+		t.Error("renderSession() of a missing session should fail")
+	}
+}
+
+func TestPrintBadge(t *testing.T) {
+	store, err := session.NewStore(t.TempDir(), t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := printBadge(&out, store); err == nil {
+		t.Error("printBadge() without sessions should fail")
+	}
+
+	for _, findings := range [][]session.Finding{
+		{{File: "a.go", Severity: "error"}},
+		{{File: "a.go", Severity: "warning"}, {File: "b.go", Severity: "suggestion"}},
+	} {
+		if err := store.Create(&session.Session{Status: session.StatusCompleted, Findings: findings}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	out.Reset()
+	if err := printBadge(&out, store); err != nil {
+		t.Fatalf("printBadge() error = %v", err)
+	}
+
+	want := `{"schemaVersion":1,"label":"review","message":"2 issues","color":"orange"}` + "\n"
+	if out.String() != want {
+		t.Errorf("printBadge() = %s, want %s", out.String(), want)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+// defaultGitHubAPIURL is the GitHub REST API; GITHUB_API_URL overrides it
+// for GitHub Enterprise.
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// errNoGitHubToken reports --pr without a token to read the pull request with.
+var errNoGitHubToken = fmt.Errorf("%w: --pr needs a GitHub token in GITHUB_TOKEN or GH_TOKEN", rcontext.ErrInvalidConfig)
+
+// pullRequest is the part of a GitHub pull request --pr needs.
+type pullRequest struct {
+	Base struct {
+		SHA string `json:"sha"`
+	} `json:"base"`
+	Head struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+// githubToken returns the token from GITHUB_TOKEN or GH_TOKEN.
+func githubToken() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+
+	return os.Getenv("GH_TOKEN")
+}
+
+// githubAPIURL returns the API base URL, without a trailing slash.
+func githubAPIURL() string {
+	if u := os.Getenv("GITHUB_API_URL"); u != "" {
+		return strings.TrimRight(u, "/")
+	}
+
+	return defaultGitHubAPIURL
+}
+
+// parseGitHubRemote returns the owner and repository named by a remote URL
+// such as git@github.com:owner/repo.git or https://github.com/owner/repo.
+func parseGitHubRemote(remote string) (string, string, error) {
+	var path string
+
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return "", "", fmt.Errorf("parse remote URL %q: %w", remote, err)
+		}
+
+		path = u.Path
+	case strings.Contains(remote, ":"):
+		// scp-like syntax: [user@]host:owner/repo.git
+		path = remote[strings.Index(remote, ":")+1:]
+	default:
+		return "", "", fmt.Errorf("remote %q is a local path, not a GitHub URL", remote)
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return "", "", fmt.Errorf("remote URL %q doesn't name an owner/repo", remote)
+	}
+
+	return parts[len(parts)-2], parts[len(parts)-1], nil
+}
+
+// fetchPullRequest reads pull request number from the GitHub API at apiURL.
+func fetchPullRequest(ctx context.Context, client *http.Client, apiURL, token, owner, repo string, number int) (*pullRequest, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", apiURL, url.PathEscape(owner), url.PathEscape(repo), number)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch pull request #%d: %w", number, err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch pull request #%d: %w", number, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return nil, fmt.Errorf("fetch pull request %s/%s#%d: %s: %s",
+			owner, repo, number, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var pr pullRequest
+	if err := json.NewDecoder(resp.Body).Decode(&pr); err != nil {
+		return nil, fmt.Errorf("parse pull request #%d: %w", number, err)
+	}
+
+	if pr.Base.SHA == "" || pr.Head.SHA == "" {
+		return nil, fmt.Errorf("pull request #%d has no base or head commit", number)
+	}
+
+	return &pr, nil
+}
+
+// resolvePullRequest looks up pull request number on the GitHub repository
+// behind remote and returns the range to review: the merge base of the PR's
+// base and head, as GitHub's own diff uses, and the head commit. Commits
+// missing locally are fetched from remote.
+func resolvePullRequest(ctx context.Context, repoRoot, remote string, number int) (string, string, error) {
+	token := githubToken()
+	if token == "" {
+		return "", "", errNoGitHubToken
+	}
+
+	remoteURL, err := git.RemoteURL(ctx, repoRoot, remote)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: --pr: no remote %q: %w", rcontext.ErrInvalidConfig, remote, err)
+	}
+
+	owner, repo, err := parseGitHubRemote(remoteURL)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: --pr: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	pr, err := fetchPullRequest(ctx, http.DefaultClient, githubAPIURL(), token, owner, repo, number)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The head of a fork's PR is only reachable through refs/pull/N/head
+	if !hasCommit(ctx, repoRoot, pr.Head.SHA) || !hasCommit(ctx, repoRoot, pr.Base.SHA) {
+		if _, err := gitOutput(ctx, repoRoot, "fetch", "--quiet", remote,
+			fmt.Sprintf("refs/pull/%d/head", number), pr.Base.SHA); err != nil {
+			return "", "", fmt.Errorf("fetch pull request #%d from %s: %w", number, remote, err)
+		}
+	}
+
+	base, err := mergeBase(ctx, repoRoot, pr.Base.SHA, pr.Head.SHA)
+	if err != nil {
+		return "", "", fmt.Errorf("find merge base of pull request #%d: %w", number, err)
+	}
+
+	return base, pr.Head.SHA, nil
+}
+
+// hasCommit reports whether sha is a commit in the local repository. Every
+// commit is its own ancestor; a missing one makes git.IsAncestor fail.
+func hasCommit(ctx context.Context, repoRoot, sha string) bool {
+	ok, err := git.IsAncestor(ctx, repoRoot, sha, sha)
+
+	return err == nil && ok
+}
+
+// mergeBase returns the merge base of base and head. A PR that is up to date
+// with its base, the usual case, is answered by git.IsAncestor.
+func mergeBase(ctx context.Context, repoRoot, base, head string) (string, error) {
+	ok, err := git.IsAncestor(ctx, repoRoot, base, head)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		return base, nil
+	}
+
+	return gitOutput(ctx, repoRoot, "merge-base", base, head)
+}
+
+// gitOutput runs git in repoRoot and returns its trimmed stdout. Errors
+// include git's stderr. It covers what crea-pipe/pkg/git has no helper for:
+// fetching a pull request refspec and the merge base of diverged commits.
+func gitOutput(ctx context.Context, repoRoot string, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", append([]string{"-C", repoRoot}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+)
+
+func TestParseGitHubRemote(t *testing.T) {
+	tests := []struct {
+		remote      string
+		owner, repo string
+		wantErr     bool
+	}{
+		{remote: "git@github.com:crealfy/crea-review.git", owner: "crealfy", repo: "crea-review"},
+		{remote: "https://github.com/crealfy/crea-review", owner: "crealfy", repo: "crea-review"},
+		{remote: "https://github.com/crealfy/crea-review.git/", owner: "crealfy", repo: "crea-review"},
+		{remote: "ssh://git@github.example.com:2222/team/app.git", owner: "team", repo: "app"},
+		{remote: "https://github.com/crealfy", wantErr: true},
+		{remote: "/srv/git/app.git", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		owner, repo, err := parseGitHubRemote(tt.remote)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseGitHubRemote(%q) error = %v, wantErr %v", tt.remote, err, tt.wantErr)
+
+			continue
+		}
+
+		if owner != tt.owner || repo != tt.repo {
+			t.Errorf("parseGitHubRemote(%q) = %q, %q; want %q, %q", tt.remote, owner, repo, tt.owner, tt.repo)
+		}
+	}
+}
+
+func TestFetchPullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+
+			return
+		}
+
+		if r.URL.Path != "/repos/crealfy/crea-review/pulls/42" {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"number":42,"base":{"sha":"aaa"},"head":{"sha":"bbb"}}`)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	pr, err := fetchPullRequest(ctx, server.Client(), server.URL, "secret", "crealfy", "crea-review", 42)
+	if err != nil {
+		t.Fatalf("fetchPullRequest() error = %v", err)
+	}
+
+	if pr.Base.SHA != "aaa" || pr.Head.SHA != "bbb" {
+		t.Errorf("fetchPullRequest() = base %q, head %q; want aaa, bbb", pr.Base.SHA, pr.Head.SHA)
+	}
+
+	_, err = fetchPullRequest(ctx, server.Client(), server.URL, "secret", "crealfy", "crea-review", 7)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("missing PR error = %v, want a 404", err)
+	}
+
+	_, err = fetchPullRequest(ctx, server.Client(), server.URL, "wrong", "crealfy", "crea-review", 42)
+	if err == nil || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("bad token error = %v, want the API message", err)
+	}
+}
+
+func TestResolvePullRequestNoToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+
+	_, _, err := resolvePullRequest(context.Background(), t.TempDir(), "origin", 1)
+	if !errors.Is(err, errNoGitHubToken) {
+		t.Errorf("resolvePullRequest() error = %v, want errNoGitHubToken", err)
+	}
+}
+
+func TestResolvePullRequest(t *testing.T) {
+	root := testutil.GitRepo(t)
+	git := func(args ...string) string { return testutil.Git(t, root, args...) }
+
+	git("remote", "add", "origin", "git@github.com:crealfy/crea-review.git")
+	git("commit", "-q", "--allow-empty", "-m", "fork point")
+	forkPoint := git("rev-parse", "HEAD")
+	git("commit", "-q", "--allow-empty", "-m", "feature")
+	head := git("rev-parse", "HEAD")
+	git("checkout", "-q", "-b", "main", forkPoint)
+	git("commit", "-q", "--allow-empty", "-m", "main moved on")
+	base := git("rev-parse", "HEAD")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"base":{"sha":%q},"head":{"sha":%q}}`, base, head)
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_TOKEN", "secret")
+	t.Setenv("GITHUB_API_URL", server.URL+"/")
+
+	gotBase, gotHead, err := resolvePullRequest(context.Background(), root, "origin", 3)
+	if err != nil {
+		t.Fatalf("resolvePullRequest() error = %v", err)
+	}
+
+	// The range starts at the merge base, so main's later commits aren't reviewed
+	if gotBase != forkPoint || gotHead != head {
+		t.Errorf("resolvePullRequest() = %s..%s, want %s..%s", gotBase, gotHead, forkPoint, head)
+	}
+}
+
+func TestMergeBaseAndHasCommit(t *testing.T) {
+	ctx := context.Background()
+	root := testutil.GitRepo(t)
+	git := func(args ...string) string { return testutil.Git(t, root, args...) }
+
+	git("commit", "-q", "--allow-empty", "-m", "base")
+	base := git("rev-parse", "HEAD")
+	git("commit", "-q", "--allow-empty", "-m", "feature")
+	head := git("rev-parse", "HEAD")
+
+	// An up-to-date PR's base is its own merge base
+	if got, err := mergeBase(ctx, root, base, head); err != nil || got != base {
+		t.Errorf("mergeBase() = %q, %v; want %q", got, err, base)
+	}
+
+	if !hasCommit(ctx, root, head) {
+		t.Errorf("hasCommit(%s) = false, want true", head)
+	}
+
+	if hasCommit(ctx, root, "0123456789abcdef0123456789abcdef01234567") {
+		t.Error("hasCommit() = true for a missing commit")
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// finishInterrupted saves an interrupted review and writes its partial
+// findings to w, post-processed by policy like a finished review. The session
+// stays in_progress, so --continue reviews its files again. It returns
+// reviewErr for the exit status.
+func finishInterrupted(w io.Writer, saver sessionSaver, sess *session.Session, result *review.Result,
+	reviewCtx *rcontext.ReviewContext, policy findingPolicy, format output.Format, displayBase string, reviewErr error,
+) error {
+	// The run fails anyway, so the gate result doesn't matter
+	if _, err := policy.apply(result, reviewCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to process partial findings: %v\n", err)
+	}
+
+	sess.Findings = result.Findings
+	sess.Error = "interrupted"
+
+	if err := saver.Save(sess); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to save session %d: %v\n", sess.ID, err)
+	}
+
+	out, err := buildOutput(result, sess, displayBase)
+	if err == nil {
+		var formatter *output.Formatter
+
+		if formatter, err = newStdoutFormatter(format); err == nil {
+			err = formatter.Render(w, out)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to print partial findings: %v\n", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "\nReview interrupted with %d partial findings", len(result.Findings))
+
+	if sess.ID > 0 {
+		fmt.Fprintf(os.Stderr, " (session %d); run 'creareview --continue %d' to review its files again", sess.ID, sess.ID)
+	}
+
+	fmt.Fprintln(os.Stderr)
+
+	return fmt.Errorf("run review: %w", reviewErr)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// recordSessions is a sessionSaver that keeps the last saved session.
+type recordSessions struct {
+	saved *session.Session
+}
+
+func (r *recordSessions) Create(*session.Session) error { return nil }
+
+func (r *recordSessions) Save(sess *session.Session) error {
+	r.saved = sess
+
+	return nil
+}
+
+func TestFinishInterruptedAppliesPolicy(t *testing.T) {
+	oldWrite := *writeBaseline
+	t.Cleanup(func() { *writeBaseline = oldWrite })
+
+	dir := t.TempDir()
+	known := session.Finding{File: "old.go", Line: 3, Severity: "warning", Category: "bug", Description: "known issue"}
+
+	baselinePath := filepath.Join(dir, "baseline.json")
+	if err := review.WriteBaseline(baselinePath, []session.Finding{known}); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+
+	baseline, err := review.LoadBaseline(baselinePath)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+
+	*writeBaseline = filepath.Join(dir, "new-baseline.json")
+
+	result := &review.Result{
+		Partial: true,
+		Findings: []session.Finding{
+			{File: "main.go", Line: 12, Severity: "error", Category: "bug", Description: "nil map write"},
+			known,
+			{File: "main.go", Line: 20, Severity: "suggestion", Category: "style", Description: "rename"},
+		},
+	}
+	reviewCtx := &rcontext.ReviewContext{Config: &rcontext.Config{Focus: []string{"bug"}}}
+	saver := &recordSessions{}
+	interrupt := errors.New("signal: interrupt")
+
+	var stdout bytes.Buffer
+
+	err = finishInterrupted(&stdout, saver, &session.Session{}, result, reviewCtx,
+		findingPolicy{baseline: baseline}, output.FormatJSON, "", interrupt)
+	if !errors.Is(err, interrupt) {
+		t.Fatalf("finishInterrupted() error = %v, want the review error", err)
+	}
+
+	// Focus drops the style finding and the baseline drops the known one
+	if saver.saved == nil || len(saver.saved.Findings) != 1 || saver.saved.Findings[0].Line != 12 {
+		t.Fatalf("saved findings = %+v, want only main.go:12", saver.saved)
+	}
+
+	if saver.saved.Findings[0].ID == "" {
+		t.Error("partial finding has no ID")
+	}
+
+	if result.BaselinedFindings != 1 {
+		t.Errorf("BaselinedFindings = %d, want 1", result.BaselinedFindings)
+	}
+
+	if !bytes.Contains(stdout.Bytes(), []byte(saver.saved.Findings[0].ID)) {
+		t.Errorf("report is missing the finding ID:\n%s", stdout.String())
+	}
+
+	// A partial review must not replace the baseline with an incomplete one
+	if _, err := os.Stat(*writeBaseline); !os.IsNotExist(err) {
+		t.Errorf("--write-baseline was written for a partial review (stat error = %v)", err)
+	}
+}
@@ -8,11 +8,9 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
+	"time"
 
-	"github.com/crealfy/crea-pipe/pkg/agent"
-	"github.com/crealfy/crea-pipe/pkg/git"
 	rcontext "github.com/crealfy/crea-review/pkg/context"
 	"github.com/crealfy/crea-review/pkg/output"
 	"github.com/crealfy/crea-review/pkg/priority"
@@ -20,71 +18,112 @@ import (
 	"github.com/crealfy/crea-review/pkg/session"
 )
 
-// envVars is a flag.Value that collects KEY=VALUE pairs.
-type envVars map[string]string
-
-func (e *envVars) String() string {
-	if e == nil || *e == nil {
-		return ""
-	}
-
-	var pairs []string
-	for k, v := range *e {
-		pairs = append(pairs, k+"="+v)
-	}
-
-	return strings.Join(pairs, ",")
-}
-
-func (e *envVars) Set(value string) error {
-	if *e == nil {
-		*e = make(map[string]string)
-	}
-
-	k, v, ok := strings.Cut(value, "=")
-	if !ok {
-		return fmt.Errorf("invalid env format %q, expected KEY=VALUE", value)
-	}
-
-	(*e)[k] = v
-
-	return nil
-}
-
 // CLI flags.
 var (
 	// CodeRabbit-compatible flags.
-	reviewType = flag.String("t", "all", "Review type: all, committed, uncommitted")
-	baseBranch = flag.String("base", "", "Base branch for comparison")
-	baseCommit = flag.String("base-commit", "", "Base commit for comparison")
-	cwd        = flag.String("cwd", "", "Working directory")
-	plain      = flag.Bool("plain", false, "Output plain text format")
-	promptOnly = flag.Bool("prompt-only", false, "Output minimal prompt for piping")
-	noColor    = flag.Bool("no-color", false, "Disable colored output")
+	reviewType   = flag.String("t", "all", "Review type: all, committed, uncommitted")
+	baseBranch   = flag.String("base", "", "Base ref for comparison (branch, origin/main, tag or SHA)")
+	baseCommit   = flag.String("base-commit", "", "Base commit for comparison")
+	headCommit   = flag.String("head-commit", "", "Head commit for comparison (requires --base-commit or --base)")
+	prNumber     = flag.Int("pr", 0, "Review GitHub pull request N (token from GITHUB_TOKEN or GH_TOKEN)")
+	prRemote     = flag.String("pr-remote", "origin", "Remote of the GitHub repository for --pr")
+	cwd          = flag.String("cwd", "", "Working directory")
+	configs      stringList
+	outputs      stringList
+	gateSpecs    stringList
+	alwaysReview stringList
+	tags         stringList
+	plain        = flag.Bool("plain", false, "Output plain text format")
+	promptOnly   = flag.Bool("prompt-only", false, "Output minimal prompt for piping")
+	noColor      = flag.Bool("no-color", false, "Disable colored output (same as --color never)")
+	colorMode    = flag.String("color", colorAuto, "Colorize output: auto (terminals only), always, never")
+	stripANSI    = flag.Bool("strip-ansi", false, "Remove terminal escape sequences from formatted output")
+	formatName   = flag.String("format", "", "Output format: json, plain, prompt-only, checkstyle, jsonl")
+	compactJSON  = flag.Bool("compact-json", false, "Emit single-line JSON without indentation")
+	scopePath    = flag.String("path", "", "Only review changes under this directory (relative to the working directory)")
+	pathBase     = flag.String("path-base", "", "Show finding paths relative to this directory (must be inside the repo)")
+	promptHeader = flag.Bool("prompt-header", false, "Prefix --prompt-only output with a session/commit header")
+	findingTmpl  = flag.String("finding-template", "", "Render each finding with this text/template instead of --format")
+
+	// Prompt template.
+	promptTemplateFile  = flag.String("prompt-template", "", "Render the review prompt from this text/template file")
+	fixPromptFile       = flag.String("fix-prompt-template", "", "Render the implementation prompt from this text/template file")
+	printPromptTemplate = flag.Bool("print-prompt-template", false, "Print the built-in prompt template and exit")
+	listBackends        = flag.Bool("list-backends", false, "List AI backends and whether each is available, then exit")
+	showVersion         = flag.Bool("version", false, "Print version, commit and Go version, then exit")
+	versionJSON         = flag.Bool("json", false, "With --version, print the version info as JSON (requires --version)")
 
 	// creareview specific flags.
-	backend      = flag.String("backend", "claude", "AI backend: claude, codex")
+	backend      = flag.String("backend", "claude", "AI backend: claude, codex, auto")
+	backendOrder = flag.String("backend-order", "claude,codex", "Backends --backend auto tries, in order")
+	requireFmt   = flag.Bool("require-findings-format", false, "Fail when a substantial AI response has no parseable FINDING blocks")
+	findingsFile = flag.String("findings-file", "", "Load findings from a JSON file instead of calling the AI")
 	withLinters  = flag.Bool("with-linters", false, "Include linter output")
+	withPRTmpl   = flag.Bool("with-pr-template", false, "Include the pull request template as a reviewer checklist")
+	withEditorCf = flag.Bool("with-editorconfig", false, "Include .editorconfig style settings for the changed files")
+	withCommits  = flag.Bool("review-commits", false, "Also review the commit messages in the range")
 	linterCmd    = flag.String("linter", "", "Linter command to run (requires --with-linters)")
+	lintBaseline = flag.Bool("lint-baseline", false, "Only include linter findings not present at the base commit")
 	lintAll      = flag.Bool("lint-all", false, "Lint entire repo instead of just changed files")
+	skipDeleted  = flag.Bool("skip-deleted", false, "Exclude deleted files from review")
+	addedOnly    = flag.Bool("added-only", false, "Review only added lines; skip files that only delete")
+	untracked    = flag.Bool("include-untracked", false, "Also review untracked, non-ignored files as added files")
+	skipTests    = flag.Bool("skip-tests", false, "Exclude test files from review (they still count toward HasTests)")
+	redact       = flag.Bool("redact", false, "Mask secrets in the diff and file contents sent to the model")
 	quiet        = flag.Bool("quiet", false, "Suppress progress messages")
+	timeout      = flag.Duration("timeout", 0, "Abort the whole run after this long, e.g. 10m (0 = no limit)")
+	estimate     = flag.Bool("estimate", false, "Print estimated tokens and cost without calling the AI")
+	oneShot      = flag.Bool("one-shot", false, "Review the given files in full, without a diff or session")
+	filesFrom    = flag.String("files-from", "", "Review the files listed in this file (- for stdin) in full; implies --one-shot")
+	contentMode  = flag.String("content", string(rcontext.ContentNone), "Embed changed file content: none, diff (changed regions), full")
+	contentCtx   = flag.Int("content-context", rcontext.DefaultContentContext, "Lines of context around each changed region with --content diff")
+	suggestRevs  = flag.Bool("suggest-reviewers", false, "Append the CODEOWNERS owners of the reviewed files to the report")
+	dumpDiff     = flag.String("dump-diff", "", "Write the resolved diff and file list to this file (- for stderr)")
 
 	// File limit and sorting.
-	maxFiles = flag.Int("max-files", 15, "Max files per review batch")
-	onLimit  = flag.String("on-limit", "continue", "When over max-files: continue, stop")
-	sortBy   = flag.String("sort", "priority", "Sort: priority, alpha, none")
+	maxFiles       = flag.Int("max-files", 15, "Max files per review batch")
+	minLines       = flag.Int("min-lines", 0, "Skip files with fewer changed lines (critical paths are always kept)")
+	minScore       = flag.Float64("min-score", 0, "Skip files with a priority score below this (0-100)")
+	sampleSize     = flag.Int("sample", 0, "Score only a sample of N files when more changed (0 = score all)")
+	sampleStrat    = flag.String("sample-strategy", "stratified", "How --sample picks files: stratified, largest")
+	scoreOnly      = flag.Bool("score-only", false, "Print the priority scores of the files that pass the filters as JSON and exit")
+	explain        = flag.Bool("explain", false, "Print why each reviewed file was included, from its priority score")
+	scoreByHunks   = flag.Bool("score-by-hunks", false, "Size files by number of hunks instead of lines changed when scoring")
+	maxFindings    = flag.Int("max-findings", 0, "Keep only the N most severe findings (0 = unlimited)")
+	contextLines   = flag.Int("context", 0, "Show N source lines around each finding in the text report")
+	collapseRanges = flag.Bool("collapse-ranges", false, "Merge identical findings on consecutive lines into one range")
+	failOn         = flag.String("fail-on", "", "Fail when any finding is at or above this severity: error, warning, suggestion")
+	baselineFile   = flag.String("baseline", "", "Suppress findings listed in this baseline file")
+	writeBaseline  = flag.String("write-baseline", "", "Write the fingerprints of this run's findings to a baseline file")
+	onLimit        = flag.String("on-limit", "continue", "When over max-files: continue, stop")
+	dailyBudget    = flag.Int("daily-file-budget", 0, "Cap files reviewed per rolling 24h across sessions (0 = no cap)")
+	onBudget       = flag.String("on-budget", onBudgetStop, "When over --daily-file-budget: stop, warn")
+	sortBy         = flag.String("sort", "priority", "Sort: priority, alpha, size, none")
 
 	// Session flags.
 	continueFrom = flag.Int("continue", 0, "Continue from session N")
 	listSessions = flag.Bool("list-sessions", false, "List all sessions")
+	badge        = flag.Bool("badge", false, "Print a shields.io badge JSON for the latest session's findings, then exit")
+	formatSess   = flag.Int("format-session", 0, "Render session N's stored findings in --format without reviewing again")
 	stateDir     = flag.String("state-dir", "", "Override state directory")
+	useCache     = flag.Bool("cache", false, "Reuse the AI response of an identical earlier review")
+	noCache      = flag.Bool("no-cache", false, "Call the AI even when a cached response exists, and cache the new one")
+	cacheTTL     = flag.Duration("cache-ttl", review.DefaultCacheTTL, "How long cached responses are reused")
+	cacheClear   = flag.Bool("cache-clear", false, "Remove all cached AI responses, then exit")
+	stateInRepo  = flag.Bool("state-in-repo", false, "Keep session state in <repo>/.creareview")
+	noSession    = flag.Bool("no-session", false, "Don't read or write sessions (for ephemeral CI)")
+
+	// Review profile.
+	profile = flag.String("profile", "", "Apply a named profile from review.yaml (instructions, categories, weights)")
 
-	// Model override.
-	model = flag.String("model", "", "Model override")
+	// Model override and per-environment models.
+	model    = flag.String("model", "", "Model override")
+	envModel = flag.String("env-model", "", "Models per environment, e.g. ci=claude-opus,local=claude-haiku")
 
 	// Retry configuration.
 	retries      = flag.Int("retries", 0, "Number of retries on transient failures")
-	retryDelayMS = flag.Int("retry-delay", 1000, "Delay between retries in ms")
+	retryDelayMS = flag.Int("retry-delay", 1000, "Base delay between retries in ms (exponential backoff with jitter)")
+	retryMaxMS   = flag.Int("retry-max-delay", review.DefaultRetryMaxDelayMS, "Max delay between retries in ms")
 
 	// Environment variables.
 	env envVars
@@ -92,6 +131,12 @@ var (
 
 func init() {
 	flag.Var(&env, "env", "Environment variable KEY=VALUE (repeatable)")
+	flag.Var(&configs, "c", "Config or instruction file (repeatable)")
+	flag.Var(&configs, "config", "Config or instruction file (repeatable)")
+	flag.Var(&outputs, "output", "Also write output as format=path (repeatable)")
+	flag.Var(&gateSpecs, "gate", "Fail when a category exceeds a count, as category:count (repeatable)")
+	flag.Var(&tags, "tag", "Label the session with this tag; with --list-sessions, show only sessions tagged with it (repeatable)")
+	flag.Var(&alwaysReview, "always-review", "Always review changed files matching this glob, even past --max-files (repeatable)")
 }
 
 func main() {
@@ -105,19 +150,75 @@ func realMain() int {
 	if err := run(ctx); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 
-		return 1
+		code := exitCode(err)
+
+		// Scripts reading JSON from stdout get a parseable failure too
+		if wantJSONError(err) {
+			_ = writeJSONError(os.Stdout, err, code)
+		}
+
+		return code
 	}
 
-	return 0
+	return exitOK
 }
 
 func run(ctx context.Context) error {
 	flag.Usage = usage
 	flag.Parse()
 
-	// Validate linter flags
-	if *withLinters && *linterCmd == "" {
-		return errors.New("--with-linters requires --linter to specify the linter command")
+	if *showVersion {
+		return printVersion(stdout, currentVersion(), *versionJSON || *formatName == string(output.FormatJSON))
+	}
+
+	if *printPromptTemplate {
+		_, err := fmt.Fprint(stdout, review.DefaultPromptTemplate)
+
+		return err
+	}
+
+	if *listBackends {
+		return printBackendList(stdout, review.CheckBackends())
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	if err := validateFlags(); err != nil {
+		return err
+	}
+
+	// Determine output format
+	format, err := resolveFormat()
+	if err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	if format == output.FormatPromptOnly {
+		*promptOnly = true
+	}
+
+	outputTargets, err := parseOutputTargets(outputs)
+	if err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	if _, err := newStdoutFormatter(format); err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	policy, err := newFindingPolicy()
+	if err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+	}
+
+	promptTemplate, err := loadPromptTemplate(*promptTemplateFile)
+	if err != nil {
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
 	}
 
 	// Resolve working directory
@@ -132,25 +233,51 @@ func run(ctx context.Context) error {
 	}
 
 	// Get repo root
-	repoRoot, err := git.RepoRoot(ctx, workDir)
+	repoRoot, err := rcontext.RepoRoot(ctx, workDir)
+	if errors.Is(err, rcontext.ErrGitNotFound) {
+		return err
+	}
+
 	if err != nil {
 		return fmt.Errorf("not a git repository: %w", err)
 	}
 
-	// Initialize session store
-	store, err := session.NewStore(repoRoot, *stateDir)
+	displayBase, err := resolvePathBase(repoRoot, workDir, *pathBase)
 	if err != nil {
-		return fmt.Errorf("init session store: %w", err)
+		return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
 	}
 
-	// Handle list-sessions
-	if *listSessions {
-		sessions, err := store.List()
-		if err != nil {
-			return fmt.Errorf("list sessions: %w", err)
+	scope, err := resolveScope(repoRoot, workDir)
+	if err != nil {
+		return err
+	}
+
+	if wholeFileMode() {
+		return runOneShot(ctx, repoRoot, workDir, flag.Args(), format, displayBase, promptTemplate, outputTargets, policy)
+	}
+
+	// Initialize session store; --no-session keeps everything in memory
+	var (
+		store *session.Store
+		saver sessionSaver = discardSessions{}
+	)
+
+	if !*noSession {
+		if store, err = openSessionStore(repoRoot); err != nil {
+			return err
 		}
 
-		return output.FormatSessionList(os.Stdout, sessions)
+		saver = store
+	}
+
+	if done, err := runStoreCommand(store, format, displayBase, outputTargets); done {
+		return err
+	}
+
+	if *prNumber > 0 {
+		if *baseCommit, *headCommit, err = resolvePullRequest(ctx, repoRoot, *prRemote, *prNumber); err != nil {
+			return err
+		}
 	}
 
 	// Handle --continue flag
@@ -169,14 +296,6 @@ func run(ctx context.Context) error {
 		}
 	}
 
-	// Determine output format
-	format := output.FormatJSON
-	if *plain {
-		format = output.FormatPlain
-	} else if *promptOnly {
-		format = output.FormatPromptOnly
-	}
-
 	// Progress output
 	progress := func(msg string) {
 		if !*quiet && !*promptOnly {
@@ -187,15 +306,9 @@ func run(ctx context.Context) error {
 	// Gather context
 	progress("[1/4] Gathering context...")
 
-	gatherOpts := rcontext.GatherOptions{
-		BaseCommit:     *baseCommit,
-		BaseBranch:     *baseBranch,
-		ReviewType:     *reviewType,
-		IncludeLinters: *withLinters,
-		LinterCommand:  *linterCmd,
-		LintAll:        *lintAll,
-		MaxFiles:       0, // Don't limit here, we'll do it after scoring
-		ExcludeFiles:   excludeFiles,
+	gatherOpts, err := newGatherOptions(excludeFiles, store)
+	if err != nil {
+		return err
 	}
 
 	reviewCtx, err := rcontext.Gather(ctx, repoRoot, gatherOpts)
@@ -203,6 +316,27 @@ func run(ctx context.Context) error {
 		return fmt.Errorf("gather context: %w", err)
 	}
 
+	// Debug output is written regardless of --quiet
+	if *dumpDiff != "" {
+		if err := writeDiffDump(reviewCtx, *dumpDiff); err != nil {
+			return err
+		}
+	}
+
+	if reviewCtx.SameCommit {
+		progress("Base and head are the same commit; nothing to review.")
+
+		return nil
+	}
+
+	var outOfScope int
+
+	reviewCtx.ChangedFiles, outOfScope = scopeFiles(reviewCtx.ChangedFiles, scope)
+	reviewCtx.Diff = scopeDiff(reviewCtx.Diff, scope)
+	if outOfScope > 0 {
+		progress(fmt.Sprintf("   Skipping %d files outside %s", outOfScope, scope))
+	}
+
 	if len(reviewCtx.ChangedFiles) == 0 {
 		progress("No changes to review.")
 
@@ -211,205 +345,153 @@ func run(ctx context.Context) error {
 
 	progress(fmt.Sprintf("   Found %d changed files", len(reviewCtx.ChangedFiles)))
 
+	if *redact {
+		redactor, err := rcontext.NewRedactor(reviewCtx.Config.RedactPatterns)
+		if err != nil {
+			return fmt.Errorf("redact: %w", err)
+		}
+
+		progress(fmt.Sprintf("   Redacted %d secrets", reviewCtx.Redact(redactor)))
+	}
+
 	// Score files by priority
 	progress("[2/4] Scoring files by priority...")
 
-	scorer := priority.NewScorer(repoRoot)
+	scorer := priority.NewScorer(repoRoot).WithExclude(excludeFiles).WithHunkScoring(*scoreByHunks).WithAddedOnly(*addedOnly)
+	if err := scorer.ApplyConfig(reviewCtx.Config); err != nil {
+		return fmt.Errorf("apply config: %w", err)
+	}
+
+	unsampled := applySample(scorer, reviewCtx.ChangedFiles, excludeFiles, alwaysReview)
 
 	scores, err := scorer.ScoreFiles(ctx, reviewCtx.ChangedFiles)
 	if err != nil {
 		return fmt.Errorf("score files: %w", err)
 	}
 
+	// Drop trivially small, low-risk and (optionally) test files
+	scores, counts := applyScoreFilters(scores, scoreFilter{
+		minLines:  *minLines,
+		minScore:  *minScore,
+		skipTests: *skipTests,
+		always:    alwaysReview,
+	})
+	reportFiltered(progress, counts)
+
+	filteredOut := counts.total() + outOfScope + unsampled
+
 	// Apply sorting
 	scores = sortScores(scores, *sortBy)
 
-	// Apply max files limit
-	filesToReview := scores
-	if *maxFiles > 0 && len(filesToReview) > *maxFiles {
-		if *onLimit == "stop" {
-			return fmt.Errorf("too many files: %d (max %d). Use --on-limit continue or increase --max-files",
-				len(filesToReview), *maxFiles)
-		}
+	if *scoreOnly {
+		return priority.WriteJSON(stdout, scores)
+	}
+
+	filesToReview, err := applyMaxFiles(progress, scores)
+	if err != nil {
+		return err
+	}
 
-		filesToReview = filesToReview[:*maxFiles]
-		progress(fmt.Sprintf("   Reviewing top %d files (by priority), %d remaining",
-			*maxFiles, len(scores)-*maxFiles))
+	if *explain {
+		explainScores(os.Stderr, filesToReview, alwaysReview)
 	}
 
 	// Filter context to only include files we're reviewing
-	reviewCtx.ChangedFiles = filterFiles(reviewCtx.ChangedFiles, filesToReview)
-	reviewCtx.Stats.ReviewedFiles = len(reviewCtx.ChangedFiles)
-	reviewCtx.Stats.SkippedFiles = len(scores) - len(reviewCtx.ChangedFiles)
+	keepSelected(reviewCtx, filesToReview, len(scores), filteredOut)
 
-	// Create session
-	totalInDiff := len(scores)
-	if *continueFrom > 0 {
-		totalInDiff += len(excludeFiles) // Include previously reviewed files
+	if *estimate {
+		return printEstimate(reviewCtx, promptTemplate)
 	}
 
-	sess := &session.Session{
-		BaseCommit:       reviewCtx.BaseCommit,
-		HeadCommit:       reviewCtx.HeadCommit,
-		TotalFilesInDiff: totalInDiff,
-		FilesReviewed:    len(reviewCtx.ChangedFiles),
-		FilesRemaining:   len(scores) - len(reviewCtx.ChangedFiles),
-		Status:           session.StatusInProgress,
-		ContinuedFrom:    *continueFrom,
+	if err := checkFileBudget(os.Stderr, store, len(reviewCtx.ChangedFiles), time.Now()); err != nil {
+		return err
 	}
 
-	for _, f := range reviewCtx.ChangedFiles {
-		sess.Files = append(sess.Files, f.Path)
-	}
+	// Create session
+	sess := newReviewSession(reviewCtx, len(scores), filteredOut, unsampled, len(excludeFiles))
 
-	if err := store.Create(sess); err != nil {
+	if err := saver.Create(sess); err != nil {
 		return fmt.Errorf("create session: %w", err)
 	}
 
-	progress(fmt.Sprintf("   Session %d created", sess.ID))
+	if sess.ID > 0 {
+		progress(fmt.Sprintf("   Session %d created", sess.ID))
+	}
 
 	// Run review
 	progress("[3/4] Running AI review...")
 
-	reviewer, err := review.NewReviewer(review.Backend(*backend))
+	reviewer, err := newReviewer()
 	if err != nil {
 		return fmt.Errorf("init reviewer: %w", err)
 	}
 
-	reviewOpts := review.Options{
-		Model:        *model,
-		Env:          env,
-		Retries:      *retries,
-		RetryDelayMS: *retryDelayMS,
+	if review.Backend(*backend) == review.BackendAuto && *findingsFile == "" {
+		progress(fmt.Sprintf("   Using %s backend", reviewer.Backend()))
 	}
 
-	if !*quiet && !*promptOnly {
-		reviewOpts.StreamHandler = func(event agent.Event) {
-			// Could show progress dots or status here
+	reviewOpts := reviewOptions(reviewCtx.Config, promptTemplate)
+	reviewOpts.Cache = responseCache(store)
+
+	spin := startSpinner(&reviewOpts)
+	result, err := reviewer.Review(ctx, reviewCtx, reviewOpts)
+	spin.stop()
+	if errors.Is(err, review.ErrInterrupted) {
+		return finishInterrupted(stdout, saver, sess, result, reviewCtx, policy, format, displayBase, err)
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s: %w", *timeout, err)
 		}
+
+		return failSession(saver, sess, err)
 	}
 
-	result, err := reviewer.Review(ctx, reviewCtx, reviewOpts)
+	if result.Cached {
+		progress("   Using cached response")
+	}
+
+	gateErr, err := policy.apply(result, reviewCtx)
 	if err != nil {
-		return fmt.Errorf("run review: %w", err)
+		return err
 	}
 
 	// Update session with findings
 	sess.Findings = result.Findings
 	sess.Status = session.StatusCompleted
 
-	if err := store.Save(sess); err != nil {
+	if err := saver.Save(sess); err != nil {
 		return fmt.Errorf("save session: %w", err)
 	}
 
 	// Format output
 	progress("[4/4] Formatting output...")
 
-	formatter := output.NewFormatter(format)
-	if *noColor {
-		formatter = formatter.WithNoColor()
-	}
-
-	if err := formatter.Format(os.Stdout, result, sess); err != nil {
-		return fmt.Errorf("format output: %w", err)
+	out, err := buildOutput(result, sess, displayBase)
+	if err != nil {
+		return err
 	}
-
-	// Show continuation hint
-	if sess.FilesRemaining > 0 && !*promptOnly {
-		fmt.Fprintf(os.Stderr, "\nRun 'creareview --continue %d' for next batch (%d files remaining)\n",
-			sess.ID, sess.FilesRemaining)
+	out.PrimaryLanguage = reviewCtx.PrimaryLanguage
+	if err := suggestReviewers(out, repoRoot, reviewCtx.ChangedFiles); err != nil {
+		return err
 	}
 
-	return nil
-}
-
-// sortScores sorts files based on the sort order.
-func sortScores(scores []priority.Score, sortOrder string) []priority.Score {
-	switch sortOrder {
-	case "alpha":
-		// Sort alphabetically by path
-		for i := range scores {
-			for j := i + 1; j < len(scores); j++ {
-				if scores[j].Path < scores[i].Path {
-					scores[i], scores[j] = scores[j], scores[i]
-				}
-			}
-		}
-	case "none":
-		// Keep original order (no sorting needed, but scores come pre-sorted by priority)
-		// Return as-is from gather order
-	default: // "priority"
-		// Already sorted by priority from ScoreFiles
+	formatter, err := newStdoutFormatter(format)
+	if err != nil {
+		return err
 	}
 
-	return scores
-}
-
-// filterFiles returns only the files that match the scored files.
-func filterFiles(files []rcontext.FileContent, scores []priority.Score) []rcontext.FileContent {
-	scoreMap := make(map[string]bool)
-	for _, s := range scores {
-		scoreMap[s.Path] = true
+	if err := formatter.Render(stdout, out); err != nil {
+		return fmt.Errorf("format output: %w", err)
 	}
 
-	var result []rcontext.FileContent
-
-	for _, f := range files {
-		if scoreMap[f.Path] {
-			result = append(result, f)
-		}
+	if err := writeOutputFiles(outputTargets, out); err != nil {
+		return err
 	}
 
-	return result
-}
+	printContinueHint(sess)
 
-func usage() {
-	fmt.Fprintf(os.Stderr, `creareview - AI Code Review Tool
-
-Usage: creareview [flags]
-
-CodeRabbit-compatible flags:
-  -t string           Review type: all, committed, uncommitted (default "all")
-  --base string       Base branch for comparison
-  --base-commit string Base commit for comparison
-  --cwd string        Working directory
-  --plain             Output plain text format
-  --prompt-only       Output minimal prompt for piping to crea-pipe
-  --no-color          Disable colored output
-
-creareview specific flags:
-  --backend string    AI backend: claude, codex (default "claude")
-  -env KEY=VALUE      Environment variable (repeatable)
-  --with-linters      Include linter output
-  --linter string     Linter command to run (requires --with-linters)
-  --lint-all          Lint entire repo instead of just changed files
-  --quiet             Suppress progress messages
-  --model string      Model override
-  --retries int       Number of retries on transient failures (default 0)
-  --retry-delay int   Delay between retries in ms (default 1000)
-
-File limit and sorting:
-  --max-files int     Max files per review batch (default 15)
-  --on-limit string   When over max-files: continue, stop (default "continue")
-  --sort string       Sort files: priority, alpha, none (default "priority")
-
-Session management:
-  --continue int      Continue from session N
-  --list-sessions     List all sessions
-  --state-dir string  Override state directory
-
-Examples:
-  # Review uncommitted changes
-  creareview -t uncommitted --plain
-
-  # Compare against main branch
-  creareview --base main --prompt-only | crea-pipe --auto-approve
-
-  # Include golangci-lint findings in review
-  creareview --base main --with-linters --linter "golangci-lint run --out-format json"
-
-  # Continue from previous session
-  creareview --continue 1
-
-`)
+	// Report the gate last so the full output is written first
+	return gateErr
 }
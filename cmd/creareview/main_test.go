@@ -3,7 +3,6 @@ package main
 import (
 	"testing"
 
-	rcontext "github.com/crealfy/crea-review/pkg/context"
 	"github.com/crealfy/crea-review/pkg/priority"
 )
 
@@ -172,6 +171,20 @@ func TestSortScores(t *testing.T) {
 				return s[0].Path == "z.go" && s[1].Path == "a.go" && s[2].Path == "m.go"
 			},
 		},
+		{
+			name: "size sort - biggest first, stable on ties",
+			scores: []priority.Score{
+				{Path: "small.go", Total: 90, LinesChanged: 5},
+				{Path: "big.go", Total: 80, LinesChanged: 500},
+				{Path: "mid-a.go", Total: 70, LinesChanged: 50},
+				{Path: "mid-b.go", Total: 60, LinesChanged: 50},
+			},
+			sort: "size",
+			verify: func(s []priority.Score) bool {
+				return s[0].Path == "big.go" && s[1].Path == "mid-a.go" &&
+					s[2].Path == "mid-b.go" && s[3].Path == "small.go"
+			},
+		},
 		{
 			name:   "empty slice",
 			scores: []priority.Score{},
@@ -201,108 +214,3 @@ func TestSortScores(t *testing.T) {
 		})
 	}
 }
-
-func TestFilterFiles(t *testing.T) {
-	tests := []struct {
-		name   string
-		files  []rcontext.FileContent
-		scores []priority.Score
-		want   int
-	}{
-		{
-			name: "all files matched",
-			files: []rcontext.FileContent{
-				{Path: "a.go"},
-				{Path: "b.go"},
-				{Path: "c.go"},
-			},
-			scores: []priority.Score{
-				{Path: "a.go", Total: 90},
-				{Path: "b.go", Total: 80},
-				{Path: "c.go", Total: 70},
-			},
-			want: 3,
-		},
-		{
-			name: "some files matched",
-			files: []rcontext.FileContent{
-				{Path: "a.go"},
-				{Path: "b.go"},
-				{Path: "c.go"},
-				{Path: "d.go"},
-			},
-			scores: []priority.Score{
-				{Path: "a.go", Total: 90},
-				{Path: "c.go", Total: 70},
-			},
-			want: 2,
-		},
-		{
-			name: "no files matched",
-			files: []rcontext.FileContent{
-				{Path: "a.go"},
-				{Path: "b.go"},
-			},
-			scores: []priority.Score{
-				{Path: "x.go", Total: 90},
-			},
-			want: 0,
-		},
-		{
-			name:   "empty files",
-			files:  []rcontext.FileContent{},
-			scores: []priority.Score{{Path: "a.go", Total: 90}},
-			want:   0,
-		},
-		{
-			name: "empty scores",
-			files: []rcontext.FileContent{
-				{Path: "a.go"},
-			},
-			scores: []priority.Score{},
-			want:   0,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := filterFiles(tt.files, tt.scores)
-			if len(got) != tt.want {
-				t.Errorf("filterFiles() = %d files, want %d", len(got), tt.want)
-			}
-		})
-	}
-}
-
-func TestFilterFilesPaths(t *testing.T) {
-	files := []rcontext.FileContent{
-		{Path: "a.go"},
-		{Path: "b.go"},
-		{Path: "c.go"},
-	}
-	scores := []priority.Score{
-		{Path: "a.go", Total: 90},
-		{Path: "c.go", Total: 70},
-	}
-
-	got := filterFiles(files, scores)
-	if len(got) != 2 {
-		t.Fatalf("filterFiles() = %d files, want 2", len(got))
-	}
-
-	// Verify the correct files were returned
-	paths := make(map[string]bool)
-	for _, f := range got {
-		paths[f.Path] = true
-	}
-
-	if !paths["a.go"] {
-		t.Error("missing a.go in result")
-	}
-	if !paths["c.go"] {
-		t.Error("missing c.go in result")
-	}
-	if paths["b.go"] {
-		t.Error("b.go should not be in result")
-	}
-}
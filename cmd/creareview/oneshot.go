@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/output"
+)
+
+// runOneShot reviews whole files for --one-shot: no diff, scoring, batching
+// or session. Paths are relative to workDir; files listed by --files-from
+// are added to them.
+func runOneShot(ctx context.Context, repoRoot, workDir string, paths []string, format output.Format,
+	displayBase, promptTemplate string, outputTargets []outputTarget, policy findingPolicy,
+) error {
+	files := make([]string, 0, len(paths))
+	for _, p := range paths {
+		abs, err := resolveOneShotPath(workDir, p)
+		if err != nil {
+			return fmt.Errorf("%w: %w", rcontext.ErrInvalidConfig, err)
+		}
+
+		files = append(files, abs)
+	}
+
+	if *filesFrom != "" {
+		listed, err := filesFromPaths(ctx, repoRoot, *filesFrom, os.Stdin, os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, listed...)
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("%w: --one-shot requires at least one file", rcontext.ErrInvalidConfig)
+	}
+
+	configFiles, err := absConfigFiles()
+	if err != nil {
+		return err
+	}
+
+	gatherOpts := rcontext.DefaultGatherOptions()
+	gatherOpts.ConfigFiles = configFiles
+	gatherOpts.Profile = *profile
+
+	reviewCtx, err := rcontext.GatherFiles(ctx, workDir, files, gatherOpts)
+	if err != nil {
+		return fmt.Errorf("gather files: %w", err)
+	}
+
+	if *redact {
+		redactor, err := rcontext.NewRedactor(reviewCtx.Config.RedactPatterns)
+		if err != nil {
+			return fmt.Errorf("redact: %w", err)
+		}
+
+		reviewCtx.Redact(redactor)
+	}
+
+	reviewer, err := newReviewer()
+	if err != nil {
+		return fmt.Errorf("init reviewer: %w", err)
+	}
+
+	result, err := reviewer.Review(ctx, reviewCtx, reviewOptions(reviewCtx.Config, promptTemplate))
+	if err != nil {
+		return fmt.Errorf("review: %w", err)
+	}
+
+	gateErr, err := policy.apply(result, reviewCtx)
+	if err != nil {
+		return err
+	}
+
+	out, err := buildOutput(result, nil, displayBase)
+	if err != nil {
+		return err
+	}
+
+	out.PrimaryLanguage = reviewCtx.PrimaryLanguage
+
+	if err := suggestReviewers(out, repoRoot, reviewCtx.ChangedFiles); err != nil {
+		return err
+	}
+
+	formatter, err := newStdoutFormatter(format)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Render(stdout, out); err != nil {
+		return err
+	}
+
+	if err := writeOutputFiles(outputTargets, out); err != nil {
+		return err
+	}
+
+	return gateErr
+}
+
+// resolveOneShotPath returns the absolute, symlink-resolved path of a file argument.
+func resolveOneShotPath(workDir, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workDir, path)
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", path, err)
+	}
+
+	return resolved, nil
+}
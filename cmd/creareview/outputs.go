@@ -0,0 +1,248 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// outputTarget is an extra output file requested with --output format=path.
+type outputTarget struct {
+	format output.Format
+	path   string
+}
+
+// parseOutputTargets parses --output values of the form format=path.
+func parseOutputTargets(specs []string) ([]outputTarget, error) {
+	targets := make([]outputTarget, 0, len(specs))
+
+	for _, spec := range specs {
+		name, path, ok := strings.Cut(spec, "=")
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid --output %q, expected format=path", spec)
+		}
+
+		format, err := output.ParseFormat(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --output %q: %w", spec, err)
+		}
+
+		targets = append(targets, outputTarget{format: format, path: path})
+	}
+
+	return targets, nil
+}
+
+// newFormatter creates a formatter for writing to w with the output flags applied.
+func newFormatter(format output.Format, w *os.File) *output.Formatter {
+	formatter := output.NewFormatter(format)
+	if !useColor(*colorMode, *noColor, w) {
+		formatter = formatter.WithNoColor()
+	}
+
+	if *compactJSON {
+		formatter = formatter.WithCompactJSON()
+	}
+
+	if *stripANSI {
+		formatter = formatter.WithStripANSI()
+	}
+
+	if *promptHeader {
+		formatter = formatter.WithPromptHeader()
+	}
+
+	return formatter
+}
+
+// newStdoutFormatter creates the formatter for the main output on stdout,
+// rendering findings with --finding-template when set. Files written with
+// --output keep their own formats.
+func newStdoutFormatter(format output.Format) (*output.Formatter, error) {
+	formatter := newFormatter(format, os.Stdout)
+	if *findingTmpl == "" {
+		return formatter, nil
+	}
+
+	tmpl, err := output.ParseFindingTemplate(*findingTmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatter.WithFindingTemplate(tmpl), nil
+}
+
+// buildOutput builds the output for result with finding paths relative to
+// displayBase, keeping the --max-findings most severe findings and rendering
+// the implementation prompt with --fix-prompt-template when set. Only the
+// report is capped; result and the session keep every finding.
+func buildOutput(result *review.Result, sess *session.Session, displayBase string) (*output.Output, error) {
+	capped := *result
+	capped.Findings, capped.OmittedFindings = review.CapFindings(result.Findings, *maxFindings)
+
+	out := output.RelativeTo(output.BuildOutput(&capped, sess), displayBase)
+
+	tmpl, err := loadFixPromptTemplate()
+	if err != nil || tmpl == nil || len(out.Findings) == 0 {
+		return out, err
+	}
+
+	out.ImplementationPrompt, err = output.RenderFixPrompt(tmpl, out.Findings)
+
+	return out, err
+}
+
+// loadFixPromptTemplate reads and parses the --fix-prompt-template file.
+// It returns nil when the flag isn't set.
+func loadFixPromptTemplate() (*template.Template, error) {
+	if *fixPromptFile == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(*fixPromptFile)
+	if err != nil {
+		return nil, fmt.Errorf("read fix prompt template: %w", err)
+	}
+
+	return output.ParseFixPromptTemplate(string(data))
+}
+
+// writeOutputFiles renders out to each target file.
+func writeOutputFiles(targets []outputTarget, out *output.Output) error {
+	for _, t := range targets {
+		if err := writeOutputFile(t, out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeOutputFile renders out to a single target file.
+func writeOutputFile(t outputTarget, out *output.Output) error {
+	f, err := os.Create(t.path)
+	if err != nil {
+		return fmt.Errorf("create output %s: %w", t.path, err)
+	}
+
+	// Files are for logs and tools, never terminals: drop escapes even with --color always
+	if err := newFormatter(t.format, f).WithStripANSI().Render(f, out); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("write %s output to %s: %w", t.format, t.path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close output %s: %w", t.path, err)
+	}
+
+	return nil
+}
+
+// writeDiffDump writes the --dump-diff output to path, or stderr for "-".
+func writeDiffDump(reviewCtx *rcontext.ReviewContext, path string) error {
+	if path == "-" {
+		return reviewCtx.WriteDiffDump(os.Stderr)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create diff dump %s: %w", path, err)
+	}
+
+	if err := reviewCtx.WriteDiffDump(f); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("write diff dump %s: %w", path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close diff dump %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// resolvePathBase converts --path-base to a slash-separated directory relative to
+// repoRoot. Relative bases resolve against workDir. The base must be inside the repo.
+func resolvePathBase(repoRoot, workDir, base string) (string, error) {
+	if base == "" {
+		return "", nil
+	}
+
+	return repoRelPath(repoRoot, workDir, base, "--path-base", true)
+}
+
+// repoRelPath converts p, given by flag, to a slash-separated path relative to
+// repoRoot; relative paths resolve against workDir. It fails when p is outside
+// the repo, or when mustExist is set and p doesn't exist.
+func repoRelPath(repoRoot, workDir, p, flag string, mustExist bool) (string, error) {
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(workDir, p)
+	}
+
+	// Resolve symlinks on both sides so e.g. /tmp -> /private/tmp compares equal
+	root, err := filepath.EvalSymlinks(repoRoot)
+	if err != nil {
+		return "", fmt.Errorf("resolve repo root: %w", err)
+	}
+
+	resolved, err := filepath.EvalSymlinks(p)
+	if errors.Is(err, os.ErrNotExist) && !mustExist {
+		resolved, err = evalExistingPrefix(p)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", flag, err)
+	}
+
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s %s is outside the repository %s", flag, p, repoRoot)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// evalExistingPrefix resolves symlinks in the longest existing ancestor of p
+// and appends the rest, for paths that no longer exist (e.g. a deleted directory).
+func evalExistingPrefix(p string) (string, error) {
+	dir, rest := filepath.Clean(p), ""
+
+	for {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+
+		parent := filepath.Dir(dir)
+		if !errors.Is(err, os.ErrNotExist) || parent == dir {
+			return "", err
+		}
+
+		dir, rest = parent, filepath.Join(filepath.Base(dir), rest)
+	}
+}
+
+// resolveFormat picks the output format. --plain and --prompt-only take
+// precedence over --format for CodeRabbit compatibility.
+func resolveFormat() (output.Format, error) {
+	switch {
+	case *plain:
+		return output.FormatPlain, nil
+	case *promptOnly:
+		return output.FormatPromptOnly, nil
+	case *formatName != "":
+		return output.ParseFormat(*formatName)
+	default:
+		return output.FormatJSON, nil
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestParseOutputTargets(t *testing.T) {
+	targets, err := parseOutputTargets([]string{"checkstyle=out/review.xml", "json=review.json"})
+	if err != nil {
+		t.Fatalf("parseOutputTargets() error = %v", err)
+	}
+
+	if len(targets) != 2 {
+		t.Fatalf("got %d targets, want 2", len(targets))
+	}
+	if targets[0].format != output.FormatCheckstyle || targets[0].path != "out/review.xml" {
+		t.Errorf("targets[0] = %+v", targets[0])
+	}
+
+	for _, spec := range []string{"checkstyle", "=review.xml", "json=", "yaml=review.yaml"} {
+		if _, err := parseOutputTargets([]string{spec}); err == nil {
+			t.Errorf("parseOutputTargets(%q) expected error", spec)
+		}
+	}
+}
+
+func TestWriteOutputFiles(t *testing.T) {
+	dir := t.TempDir()
+	targets := []outputTarget{
+		{format: output.FormatCheckstyle, path: filepath.Join(dir, "review.xml")},
+		{format: output.FormatPlain, path: filepath.Join(dir, "review.txt")},
+	}
+
+	out := &output.Output{
+		Findings: []session.Finding{
+			{File: "main.go", Line: 3, Severity: "error", Category: "bug", Description: "nil deref"},
+		},
+	}
+
+	if err := writeOutputFiles(targets, out); err != nil {
+		t.Fatalf("writeOutputFiles() error = %v", err)
+	}
+
+	data, err := os.ReadFile(targets[0].path)
+	if err != nil {
+		t.Fatalf("read checkstyle: %v", err)
+	}
+
+	var report struct{}
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Errorf("checkstyle output is not XML: %v", err)
+	}
+
+	data, err = os.ReadFile(targets[1].path)
+	if err != nil {
+		t.Fatalf("read plain: %v", err)
+	}
+
+	if !strings.Contains(string(data), "nil deref") {
+		t.Errorf("plain output missing finding:\n%s", data)
+	}
+}
+
+func TestBuildOutputCapsOnlyTheReport(t *testing.T) {
+	oldMax := *maxFindings
+	t.Cleanup(func() { *maxFindings = oldMax })
+
+	*maxFindings = 1
+
+	findings := []session.Finding{
+		{File: "a.go", Line: 1, Severity: "suggestion", Category: "style", Description: "rename"},
+		{File: "b.go", Line: 2, Severity: "error", Category: "bug", Description: "nil deref"},
+		{File: "c.go", Line: 3, Severity: "warning", Category: "bug", Description: "unchecked error"},
+	}
+	result := &review.Result{Findings: findings}
+	sess := &session.Session{ID: 7, Findings: findings}
+
+	out, err := buildOutput(result, sess, "")
+	if err != nil {
+		t.Fatalf("buildOutput() error = %v", err)
+	}
+
+	if len(out.Findings) != 1 || out.Findings[0].File != "b.go" || out.OmittedFindings != 2 {
+		t.Errorf("output = %+v, want only the b.go error with 2 omitted", out)
+	}
+
+	// The session and result keep every finding for --format-session and --badge
+	if len(result.Findings) != 3 || result.OmittedFindings != 0 || len(sess.Findings) != 3 {
+		t.Errorf("result = %+v, session findings = %d; want all 3 kept", result, len(sess.Findings))
+	}
+}
+
+func TestWriteOutputFileStripsANSI(t *testing.T) {
+	oldColor := *colorMode
+	t.Cleanup(func() { *colorMode = oldColor })
+
+	*colorMode = colorAlways
+
+	target := outputTarget{format: output.FormatPlain, path: filepath.Join(t.TempDir(), "review.log")}
+	out := &output.Output{
+		Findings: []session.Finding{
+			{File: "main.go", Line: 3, Severity: "error", Category: "bug", Description: "nil deref"},
+		},
+	}
+
+	if err := writeOutputFile(target, out); err != nil {
+		t.Fatalf("writeOutputFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(target.path)
+	if err != nil {
+		t.Fatalf("read plain: %v", err)
+	}
+
+	if strings.Contains(string(data), "\x1b") || !strings.Contains(string(data), "nil deref") {
+		t.Errorf("plain output should be the finding without escape sequences:\n%q", data)
+	}
+}
+
+func TestResolvePathBase(t *testing.T) {
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "services", "api")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		workDir string
+		base    string
+		want    string
+		wantErr bool
+	}{
+		{"unset", repo, "", "", false},
+		{"absolute", repo, sub, "services/api", false},
+		{"relative to workdir", filepath.Join(repo, "services"), "api", "services/api", false},
+		{"repo root", sub, "../..", ".", false},
+		{"outside repo", repo, "..", "", true},
+		{"missing", repo, "nope", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePathBase(repo, tt.workDir, tt.base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolvePathBase() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("resolvePathBase() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoRelPathMissing(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, "services"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repoRelPath(repo, repo, "services/deleted", "--path", false)
+	if err != nil || got != "services/deleted" {
+		t.Errorf("repoRelPath(missing) = %q, %v; want services/deleted", got, err)
+	}
+
+	if _, err := repoRelPath(repo, repo, "services/deleted", "--path", true); err == nil {
+		t.Error("repoRelPath(missing, mustExist) should fail")
+	}
+
+	if _, err := repoRelPath(repo, repo, "../elsewhere", "--path", false); err == nil {
+		t.Error("repoRelPath(outside) should fail")
+	}
+}
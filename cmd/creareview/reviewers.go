@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/output"
+)
+
+// suggestReviewers sets out.SuggestedReviewers to the CODEOWNERS owners of
+// files when --suggest-reviewers is set. A repository without CODEOWNERS
+// gets a warning, not an error.
+func suggestReviewers(out *output.Output, repoRoot string, files []rcontext.FileContent) error {
+	if !*suggestRevs {
+		return nil
+	}
+
+	owners, err := rcontext.LoadCodeOwners(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	if owners == nil {
+		fmt.Fprintln(os.Stderr, "warning: --suggest-reviewers: no CODEOWNERS file found")
+
+		return nil
+	}
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+
+	out.SuggestedReviewers = owners.Reviewers(paths)
+
+	return nil
+}
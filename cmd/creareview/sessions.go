@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/crealfy/crea-review/pkg/batch"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/output"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// sessionSaver persists review sessions. --no-session swaps in discardSessions.
+type sessionSaver interface {
+	Create(sess *session.Session) error
+	Save(sess *session.Session) error
+}
+
+// discardSessions is a sessionSaver that writes nothing, for ephemeral CI runs.
+type discardSessions struct{}
+
+// Create leaves the session without an ID.
+func (discardSessions) Create(*session.Session) error { return nil }
+
+// Save does nothing.
+func (discardSessions) Save(*session.Session) error { return nil }
+
+// failSession records reviewErr on sess and saves it as failed, so
+// --continue reviews its files again. It returns the error for run.
+func failSession(saver sessionSaver, sess *session.Session, reviewErr error) error {
+	sess.Status = session.StatusFailed
+	sess.Error = reviewErr.Error()
+
+	if err := saver.Save(sess); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to save session %d: %v\n", sess.ID, err)
+	}
+
+	return fmt.Errorf("run review: %w", reviewErr)
+}
+
+// newReviewSession returns an in-progress session for the files in
+// reviewCtx, continuing --continue and recording the --tag and invocation.
+// The counts are those of batch.Counts.
+func newReviewSession(reviewCtx *rcontext.ReviewContext, scored, skipped, unsampled, previous int) *session.Session {
+	sess := batch.NewSession(reviewCtx, batch.Counts{
+		Scored: scored, Skipped: skipped, Unsampled: unsampled, Previous: previous,
+	}, *continueFrom)
+	sess.Tags = tags
+	sess.Invocation = newInvocation(reviewCtx.Config)
+
+	return sess
+}
+
+// printContinueHint tells the user how to review the files sess left for
+// the next batch.
+func printContinueHint(sess *session.Session) {
+	if sess.FilesRemaining > 0 && sess.ID > 0 && !*promptOnly {
+		fmt.Fprintf(os.Stderr, "\nRun 'creareview --continue %d' for next batch (%d files remaining)\n",
+			sess.ID, sess.FilesRemaining)
+	}
+}
+
+// validateSessionFlags rejects flags that need stored sessions when --no-session is set.
+func validateSessionFlags() error {
+	if !*noSession {
+		return nil
+	}
+
+	switch {
+	case *continueFrom > 0:
+		return fmt.Errorf("%w: --no-session and --continue are mutually exclusive", rcontext.ErrInvalidConfig)
+	case *listSessions:
+		return fmt.Errorf("%w: --no-session and --list-sessions are mutually exclusive", rcontext.ErrInvalidConfig)
+	case *formatSess > 0:
+		return fmt.Errorf("%w: --no-session and --format-session are mutually exclusive", rcontext.ErrInvalidConfig)
+	case *badge:
+		return fmt.Errorf("%w: --no-session and --badge are mutually exclusive", rcontext.ErrInvalidConfig)
+	case len(tags) > 0:
+		return fmt.Errorf("%w: --no-session and --tag are mutually exclusive", rcontext.ErrInvalidConfig)
+	case *useCache || *noCache || *cacheClear:
+		return fmt.Errorf("%w: the response cache lives in the state dir and can't be used with --no-session", rcontext.ErrInvalidConfig)
+	case *dailyBudget > 0:
+		return fmt.Errorf("%w: --daily-file-budget counts stored sessions and can't be used with --no-session", rcontext.ErrInvalidConfig)
+	case *lintBaseline:
+		return fmt.Errorf("%w: --lint-baseline stores findings in the state dir and can't be used with --no-session", rcontext.ErrInvalidConfig)
+	}
+
+	return nil
+}
+
+// openSessionStore opens the session store for repoRoot, honoring
+// --state-in-repo and --state-dir.
+func openSessionStore(repoRoot string) (*session.Store, error) {
+	if *stateInRepo {
+		*stateDir = session.InRepoStateDir(repoRoot)
+	}
+
+	store, err := session.NewStore(repoRoot, *stateDir)
+	if err != nil {
+		return nil, fmt.Errorf("init session store: %w", err)
+	}
+
+	return store, nil
+}
+
+// runStoreCommand runs the commands that only use the state dir instead of
+// reviewing: --list-sessions, --cache-clear, --format-session and --badge.
+// It reports whether one ran.
+func runStoreCommand(store *session.Store, format output.Format, displayBase string, targets []outputTarget) (bool, error) {
+	switch {
+	case *listSessions:
+		return true, printSessionList(store)
+	case *cacheClear:
+		return true, clearResponseCache(stdout, store)
+	case *formatSess > 0:
+		formatter, err := newStdoutFormatter(format)
+		if err != nil {
+			return true, err
+		}
+
+		return true, renderSession(stdout, store, *formatSess, formatter, displayBase, targets)
+	case *badge:
+		return true, printBadge(stdout, store)
+	default:
+		return false, nil
+	}
+}
+
+// printSessionList writes the stored sessions carrying every --tag, as JSON
+// with --format json.
+func printSessionList(store *session.Store) error {
+	sessions, err := store.List()
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+
+	sessions = session.FilterByTags(sessions, tags)
+
+	if *formatName == string(output.FormatJSON) {
+		return newFormatter(output.FormatJSON, os.Stdout).FormatSessionListJSON(stdout, sessions)
+	}
+
+	return output.FormatSessionList(stdout, sessions)
+}
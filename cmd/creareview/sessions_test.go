@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestValidateSessionFlags(t *testing.T) {
+	t.Cleanup(func() {
+		*noSession, *continueFrom, *listSessions, *formatSess = false, 0, false, 0
+		tags = nil
+	})
+
+	tests := []struct {
+		name      string
+		noSess    bool
+		continueN int
+		list      bool
+		tags      stringList
+		formatN   int
+		wantErr   bool
+	}{
+		{"sessions enabled", false, 3, true, stringList{"security"}, 2, false},
+		{"no session alone", true, 0, false, nil, 0, false},
+		{"no session with continue", true, 3, false, nil, 0, true},
+		{"no session with list", true, 0, true, nil, 0, true},
+		{"no session with tag", true, 0, false, stringList{"security"}, 0, true},
+		{"no session with format-session", true, 0, false, nil, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*noSession, *continueFrom, *listSessions, *formatSess = tt.noSess, tt.continueN, tt.list, tt.formatN
+			tags = tt.tags
+
+			err := validateSessionFlags()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSessionFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, rcontext.ErrInvalidConfig) {
+				t.Errorf("error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
+
+func TestDiscardSessions(t *testing.T) {
+	var saver sessionSaver = discardSessions{}
+
+	sess := &session.Session{FilesReviewed: 2}
+	if err := saver.Create(sess); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if sess.ID != 0 {
+		t.Errorf("ID = %d, want 0 (no session)", sess.ID)
+	}
+
+	if err := saver.Save(sess); err != nil {
+		t.Errorf("Save() error = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+	"github.com/crealfy/crea-review/pkg/review"
+)
+
+// spinnerFrames are drawn in turn, one per stream event.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinner shows activity on a terminal while the agent streams events.
+// It redraws a single line in place, so it only draws when the output is a TTY;
+// piped or redirected stderr gets nothing.
+type spinner struct {
+	mu     sync.Mutex
+	w      io.Writer
+	tty    bool
+	events int
+}
+
+// newSpinner creates a spinner writing to w; tty enables drawing.
+func newSpinner(w io.Writer, tty bool) *spinner {
+	return &spinner{w: w, tty: tty}
+}
+
+// startSpinner attaches a stderr spinner to opts unless --quiet or
+// --prompt-only is set. It returns nil when there is none.
+func startSpinner(opts *review.Options) *spinner {
+	if *quiet || *promptOnly {
+		return nil
+	}
+
+	spin := newSpinner(os.Stderr, isTerminal(os.Stderr) && os.Getenv("TERM") != "dumb")
+	opts.StreamHandler = spin.handle
+
+	return spin
+}
+
+// handle is an agent stream handler that advances the spinner.
+func (s *spinner) handle(agent.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events++
+
+	if !s.tty {
+		return
+	}
+
+	frame := spinnerFrames[s.events%len(spinnerFrames)]
+	fmt.Fprintf(s.w, "\r   %s waiting for the review (%d events)", frame, s.events)
+}
+
+// stop clears the spinner line if anything was drawn. It does nothing on
+// a nil spinner.
+func (s *spinner) stop() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tty && s.events > 0 {
+		fmt.Fprint(s.w, "\r\033[K")
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+)
+
+func TestSpinner(t *testing.T) {
+	t.Run("terminal", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		s := newSpinner(&buf, true)
+		s.handle(agent.Event{})
+		s.handle(agent.Event{})
+		s.stop()
+
+		out := buf.String()
+		if !strings.Contains(out, "(2 events)") {
+			t.Errorf("spinner should show the event count:\n%q", out)
+		}
+		if !strings.HasSuffix(out, "\r\033[K") {
+			t.Errorf("spinner should clear its line when stopped:\n%q", out)
+		}
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		s := newSpinner(&buf, false)
+		s.handle(agent.Event{})
+		s.stop()
+
+		if buf.Len() != 0 {
+			t.Errorf("spinner wrote to a non-terminal: %q", buf.String())
+		}
+	})
+
+	t.Run("no events", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		newSpinner(&buf, true).stop()
+
+		if buf.Len() != 0 {
+			t.Errorf("stop without events should write nothing, got %q", buf.String())
+		}
+	})
+}
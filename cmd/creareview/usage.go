@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `creareview - AI Code Review Tool
+
+Usage: creareview [flags]
+       creareview --one-shot [flags] file...
+       creareview --files-from path [flags]
+
+CodeRabbit-compatible flags:
+  -t string           Review type: all, committed, uncommitted (default "all")
+  --base string       Base ref for comparison: branch, origin/main, tag or SHA
+  --base-commit string Base commit for comparison
+  --head-commit string Head commit for comparison (default: working tree
+                      with --base-commit, HEAD with --base)
+  --pr int            Review GitHub pull request N: its merge base to its head
+                      (token from GITHUB_TOKEN or GH_TOKEN)
+  --pr-remote name    Remote of the GitHub repository for --pr (default "origin")
+  --cwd string        Working directory
+  -c, --config file   Config or instruction file (repeatable); review.yaml
+                      in the repo root is always loaded first
+  --profile name      Apply a named profile from the config (instructions,
+                      categories, weights)
+  --plain             Output plain text format
+  --prompt-only       Output minimal prompt for piping to crea-pipe
+  --no-color          Disable colored output (same as --color never)
+  --color mode        Colorize output: auto (terminals only), always, never (default "auto")
+  --strip-ansi        Remove terminal escape sequences from formatted output
+                      (--output files are always stripped)
+  --format string     Output format: json, plain, prompt-only, checkstyle, jsonl (default "json")
+  --compact-json      Emit single-line JSON without indentation (for pipes)
+  --prompt-header     Prefix --prompt-only output with a session/commit header
+  --finding-template tmpl Render each finding with a text/template instead of
+                      --format, e.g. '{{.Location}} {{.Description}}'
+  --output format=path Also write output in another format to a file (repeatable)
+  --path dir          Only review changes under dir (e.g. services/billing)
+  --path-base dir     Show finding paths relative to dir (must be inside the repo)
+
+creareview specific flags:
+  --backend string    AI backend: claude, codex, auto (default "claude")
+  --backend-order list Backends --backend auto tries, in order (default "claude,codex")
+  --findings-file string Load findings from a JSON file instead of calling the AI
+  --require-findings-format Fail when a substantial AI response has no parseable
+                      FINDING blocks, printing the raw response to stderr
+  --prompt-template file Render the review prompt from a text/template file
+  --print-prompt-template Print the built-in prompt template and exit
+  --fix-prompt-template file Render the implementation prompt (prompt-only output
+                      and implementation_prompt in JSON) from a text/template file
+  --list-backends     List AI backends and whether each is available, then exit
+  --version           Print version, commit and Go version, then exit (--json for JSON)
+  -env KEY=VALUE      Environment variable (repeatable)
+  --with-linters      Include linter output
+  --with-pr-template  Include .github/pull_request_template.md as a reviewer checklist
+  --review-commits    Also review the commit messages in the range (category "commit")
+  --with-editorconfig Pass .editorconfig style settings for changed files to the reviewer
+  --linter string     Linter command to run (requires --with-linters); run by sh
+                      with --env added to its environment
+  --lint-all          Lint entire repo instead of just changed files
+  --lint-baseline     Only include linter findings not present at the base commit
+  --skip-deleted      Exclude deleted files from review
+  --added-only        Review only added lines: score by lines added, drop hunks
+                      without additions and skip files that only delete
+  --include-untracked Also review untracked, non-ignored files as added files
+                      (working-tree reviews only)
+  --skip-tests        Exclude test files from review (still used for test scoring)
+  --redact            Mask secrets in the diff and file contents sent to the model
+  --quiet             Suppress progress messages
+  --estimate          Print estimated tokens and cost without calling the AI
+  --score-only        Print the priority scores (with breakdowns) of the files that
+                      pass the filters as JSON, then exit without reviewing
+  --gate category:count Fail (exit 5) when a category has more findings (repeatable)
+  --fail-on severity  Fail (exit 5) on any finding at or above: error, warning, suggestion
+  --baseline file     Suppress known findings listed in a baseline file
+  --write-baseline file Write this run's finding fingerprints as a baseline
+  --one-shot          Review the given files in full, without a diff or session
+  --files-from path   Also review the tracked files listed in path (- for stdin),
+                      one per line, relative to the repo root; implies --one-shot
+  --suggest-reviewers Append the CODEOWNERS owners of the reviewed files to the report
+  --content mode      Embed changed file content in the prompt: none, diff
+                      (changed regions with line numbers), full (default "none")
+  --content-context int Lines kept around each changed region with --content diff (default 10)
+  --dump-diff file    Write the resolved base/head, file list and diff to file (- for stderr)
+  --timeout duration  Abort the whole run after this long, e.g. 10m (default: no limit)
+  --model string      Model override
+  --env-model list    Models per environment, e.g. ci=claude-opus,local=claude-haiku;
+                      the environment is $CREAREVIEW_ENV, else ci when $CI is set,
+                      else local. --model wins
+  --retries int       Number of retries on transient failures (default 0)
+  --retry-delay int   Base delay between retries in ms, doubled per retry
+                      with full jitter (default 1000)
+  --retry-max-delay int Max delay between retries in ms (default 30000)
+
+File limit and sorting:
+  --max-files int     Max files per review batch (default 15)
+  --always-review glob Always review changed files matching glob, ahead of
+                      --max-files and the filters (repeatable)
+  --min-lines int     Skip files with fewer changed lines (critical paths are always kept)
+  --min-score float   Skip files with a priority score below this (0-100)
+  --score-by-hunks    Size files by hunk count instead of lines changed, so
+                      scattered edits outrank one large block
+  --sample int        Score only a sample of N files when more changed, for very
+                      large diffs; the rest are left for --continue (default 0, off)
+  --sample-strategy string How --sample picks files: stratified (critical paths,
+                      then spread across directories) or largest (default "stratified")
+  --explain           Print why each reviewed file was included, e.g.
+                      "critical-path, high churn (32), no tests"
+  --max-findings int  Keep only the N most severe findings (default 0, unlimited)
+  --collapse-ranges   Merge identical findings on consecutive lines into one range
+  --context int       Show N source lines around each finding in the text report
+  --on-limit string   When over max-files: continue, stop (default "continue")
+  --daily-file-budget int Cap the files reviewed per rolling 24h, counted from the
+                      stored sessions (default 0, no cap)
+  --on-budget string  When over --daily-file-budget: stop, warn (default "stop")
+  --sort string       Sort files: priority, alpha, size, none (default "priority")
+
+Session management:
+  --continue int      Continue from session N
+  --list-sessions     List all sessions
+  --format-session N  Render session N's stored findings in --format, without reviewing again
+  --badge             Print a shields.io endpoint badge JSON for the latest session, then exit
+  --tag string        Label the session (repeatable); with --list-sessions, filter by tag
+  --state-dir string  Override state directory
+  --state-in-repo     Keep session state in <repo>/.creareview (shareable)
+  --no-session        Don't read or write sessions; no session id or --continue hint
+
+Response cache:
+  --cache             Reuse the AI response of an identical earlier review
+                      (same backend, model and prompt)
+  --no-cache          Call the AI even if a response is cached, and cache the new one
+  --cache-ttl dur     How long cached responses are reused (default 24h)
+  --cache-clear       Remove all cached responses, then exit
+
+Examples:
+  # Review uncommitted changes
+  creareview -t uncommitted --plain
+
+  # Include brand-new files that haven't been added to git yet
+  creareview -t uncommitted --include-untracked --plain
+
+  # Compare against main branch
+  creareview --base main --prompt-only | crea-pipe --auto-approve
+
+  # Review a historical range (--base-commit/--base take precedence over -t)
+  creareview --base-commit v1.0.0 --head-commit v1.1.0
+
+  # Include golangci-lint findings in review
+  creareview --base main --with-linters --linter "golangci-lint run --out-format json"
+
+  # Log plain text and keep a checkstyle artifact from the same run
+  creareview --base main --plain --output checkstyle=review.xml
+
+  # CI policy: no security findings, at most 5 style findings
+  creareview --base main --gate security:0 --gate style:5
+
+  # Adopt gates on legacy code: record today's findings, then report only new ones
+  creareview --base main --write-baseline review-baseline.json
+  creareview --base main --baseline review-baseline.json --fail-on error
+
+  # Check the expected cost before reviewing
+  creareview --base main --estimate
+
+  # Continue from previous session
+  creareview --continue 1
+
+  # Quick review of one file, no session
+  creareview --one-shot --plain pkg/auth/login.go
+
+  # Review files picked by another tool
+  git diff --name-only main | creareview --files-from - --plain
+
+  # Re-render findings from a previous run without calling the AI
+  creareview --base main --findings-file findings.json --plain
+
+`)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build information, set at link time with
+// -ldflags "-X main.version=... -X main.commit=... -X main.buildTime=...".
+// Unset values fall back to the module and VCS info Go embeds in the binary.
+var (
+	version   = ""
+	commit    = ""
+	buildTime = ""
+)
+
+// versionInfo is what --version prints.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// currentVersion returns the build information of the running binary.
+func currentVersion() versionInfo {
+	info := versionInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		fillFromBuildInfo(&info, bi)
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+
+	return info
+}
+
+// fillFromBuildInfo sets fields not given at link time from the embedded
+// module version and VCS settings.
+func fillFromBuildInfo(info *versionInfo, bi *debug.BuildInfo) {
+	if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+
+	for _, s := range bi.Settings {
+		switch {
+		case s.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = s.Value
+		case s.Key == "vcs.time" && info.BuildTime == "":
+			info.BuildTime = s.Value
+		}
+	}
+}
+
+// printVersion writes info as one line of text, or as JSON.
+func printVersion(w io.Writer, info versionInfo, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(info)
+	}
+
+	_, err := fmt.Fprintf(w, "creareview %s (commit %s, built %s, %s %s)\n",
+		info.Version, orNone(info.Commit), orNone(info.BuildTime), info.GoVersion, info.Platform)
+
+	return err
+}
+
+// orNone returns s, or "none" when it is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+
+	return s
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestFillFromBuildInfo(t *testing.T) {
+	bi := &debug.BuildInfo{
+		Main: debug.Module{Version: "v1.4.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+		},
+	}
+
+	t.Run("fills unset fields", func(t *testing.T) {
+		var info versionInfo
+		fillFromBuildInfo(&info, bi)
+
+		if info.Version != "v1.4.0" || info.Commit != "abc123" || info.BuildTime != "2026-01-02T03:04:05Z" {
+			t.Errorf("info = %+v", info)
+		}
+	})
+
+	t.Run("ldflags win", func(t *testing.T) {
+		info := versionInfo{Version: "v2.0.0", Commit: "def456"}
+		fillFromBuildInfo(&info, bi)
+
+		if info.Version != "v2.0.0" || info.Commit != "def456" {
+			t.Errorf("info = %+v, want link-time values kept", info)
+		}
+	})
+
+	t.Run("devel build", func(t *testing.T) {
+		var info versionInfo
+		fillFromBuildInfo(&info, &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}})
+
+		if info.Version != "" {
+			t.Errorf("Version = %q, want empty for (devel)", info.Version)
+		}
+	})
+}
+
+func TestPrintVersion(t *testing.T) {
+	info := versionInfo{Version: "v1.4.0", Commit: "abc123", GoVersion: "go1.25.5", Platform: "linux/amd64"}
+
+	var text bytes.Buffer
+	if err := printVersion(&text, info, false); err != nil {
+		t.Fatalf("printVersion() error = %v", err)
+	}
+
+	want := "creareview v1.4.0 (commit abc123, built none, go1.25.5 linux/amd64)\n"
+	if text.String() != want {
+		t.Errorf("printVersion() = %q, want %q", text.String(), want)
+	}
+
+	var js bytes.Buffer
+	if err := printVersion(&js, info, true); err != nil {
+		t.Fatalf("printVersion(json) error = %v", err)
+	}
+
+	var got versionInfo
+	if err := json.Unmarshal(js.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if got != info {
+		t.Errorf("JSON round trip = %+v, want %+v", got, info)
+	}
+
+	if strings.Contains(js.String(), "build_time") {
+		t.Errorf("JSON has empty build_time:\n%s", js.String())
+	}
+}
+
+func TestValidateFlagsJSONRequiresVersion(t *testing.T) {
+	t.Cleanup(func() { *versionJSON = false })
+
+	if err := validateFlags(); err != nil {
+		t.Fatalf("validateFlags() with defaults error = %v", err)
+	}
+
+	*versionJSON = true
+
+	if err := validateFlags(); !errors.Is(err, rcontext.ErrInvalidConfig) {
+		t.Errorf("validateFlags() with --json alone error = %v, want ErrInvalidConfig", err)
+	}
+}
@@ -2,6 +2,9 @@ module github.com/crealfy/crea-review
 
 go 1.25.5
 
-require github.com/crealfy/crea-pipe v0.0.0-20260217185151-b50b04afdba0
+require (
+	github.com/crealfy/crea-pipe v0.0.0-20260217185151-b50b04afdba0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require github.com/coder/websocket v1.8.14 // indirect
@@ -0,0 +1,102 @@
+// Package testutil provides helpers shared by the tests of several packages.
+package testutil
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// GitRepo creates an empty git repository in a temporary directory and
+// returns its symlink-resolved path. The test is skipped when git is
+// unavailable.
+func GitRepo(tb testing.TB) string {
+	tb.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		tb.Skip("git not available")
+	}
+
+	dir, err := filepath.EvalSymlinks(tb.TempDir())
+	if err != nil {
+		tb.Fatalf("resolve temp dir: %v", err)
+	}
+
+	Git(tb, dir, "init", "-q")
+
+	return dir
+}
+
+// Git runs a git command in dir as the "test" user and returns its trimmed
+// output. It fails the test on error.
+func Git(tb testing.TB, dir string, args ...string) string {
+	tb.Helper()
+
+	return git(tb, dir, "test", args...)
+}
+
+// WriteFiles writes files into dir, keyed by slash-separated path, creating
+// directories as needed.
+func WriteFiles(tb testing.TB, dir string, files map[string]string) {
+	tb.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatalf("create dir for %s: %v", name, err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			tb.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+// Commit writes files into dir and commits everything in the working tree.
+func Commit(tb testing.TB, dir, msg string, files map[string]string) {
+	tb.Helper()
+
+	CommitAs(tb, dir, "test", msg, files)
+}
+
+// CommitAs is Commit with author as the commit's author.
+func CommitAs(tb testing.TB, dir, author, msg string, files map[string]string) {
+	tb.Helper()
+
+	WriteFiles(tb, dir, files)
+	git(tb, dir, author, "add", "-A")
+	git(tb, dir, author, "commit", "-q", "-m", msg)
+}
+
+// CommitFile writes name with its own name as content and commits only
+// that file, for tests that just need some history.
+func CommitFile(tb testing.TB, dir, name string) {
+	tb.Helper()
+
+	WriteFiles(tb, dir, map[string]string{name: name + "\n"})
+	Git(tb, dir, "add", name)
+	Git(tb, dir, "commit", "-q", "-m", name)
+}
+
+// git runs a git command in dir with author as the commit author.
+func git(tb testing.TB, dir, author string, args ...string) string {
+	tb.Helper()
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+author, "GIT_AUTHOR_EMAIL="+author+"@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+
+	out, err := cmd.Output()
+	if err != nil {
+		tb.Fatalf("git %v: %v\n%s", args, err, stderr.String())
+	}
+
+	return strings.TrimSpace(string(out))
+}
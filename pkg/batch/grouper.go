@@ -2,9 +2,11 @@
 package batch
 
 import (
-	"path/filepath"
+	"path"
+	"slices"
 	"strings"
 
+	rcontext "github.com/crealfy/crea-review/pkg/context"
 	"github.com/crealfy/crea-review/pkg/priority"
 )
 
@@ -35,6 +37,8 @@ type Options struct {
 	GroupByPackage bool
 
 	// PairTests keeps source and test files together.
+	// With both PairTests and GroupByPackage off, Group returns sequential
+	// batches of MaxFilesPerBatch in input order.
 	PairTests bool
 }
 
@@ -70,6 +74,12 @@ func (g *Grouper) Group(scores []priority.Score) []Batch {
 		files = files[:g.opts.MaxTotalFiles]
 	}
 
+	// Normalize separators so test pairing and package grouping work for Windows paths
+	files = slices.Clone(files)
+	for i := range files {
+		files[i].Path = rcontext.NormalizePath(files[i].Path)
+	}
+
 	// First, pair tests with their source files
 	pairs := make(map[string][]priority.Score)
 	used := make(map[string]bool)
@@ -103,7 +113,7 @@ func (g *Grouper) Group(scores []priority.Score) []Batch {
 				continue
 			}
 
-			pkg := filepath.Dir(score.Path)
+			pkg := path.Dir(score.Path)
 			packages[pkg] = append(packages[pkg], score)
 			used[score.Path] = true
 		}
@@ -152,7 +162,7 @@ func (g *Grouper) Group(scores []priority.Score) []Batch {
 
 				splitBatch := Batch{
 					ID:     batchID,
-					Reason: "test-pair (split " + filepath.Base(sourcePath) + ")",
+					Reason: "test-pair (split " + path.Base(sourcePath) + ")",
 				}
 
 				for _, f := range pairFiles[i:end] {
@@ -211,15 +221,18 @@ func (g *Grouper) Group(scores []priority.Score) []Batch {
 		batchID++
 	}
 
-	// Sort batches by total score descending
-	sortBatches(batches)
+	// Sort batches by total score descending. Without any grouping the
+	// batches are plain chunks of the input and keep its (priority) order.
+	if g.opts.PairTests || g.opts.GroupByPackage {
+		sortBatches(batches)
+	}
 
 	return batches
 }
 
 // isTestFile checks if a file is a test file.
-func isTestFile(path string) bool {
-	base := filepath.Base(path)
+func isTestFile(file string) bool {
+	base := path.Base(file)
 
 	// Go tests
 	if strings.HasSuffix(base, "_test.go") {
@@ -253,41 +266,41 @@ func isTestFile(path string) bool {
 
 // getSourcePath returns the source file path for a test file.
 func getSourcePath(testPath string) string {
-	dir := filepath.Dir(testPath)
-	base := filepath.Base(testPath)
-	ext := filepath.Ext(base)
+	dir := path.Dir(testPath)
+	base := path.Base(testPath)
+	ext := path.Ext(base)
 
 	// Go: handler_test.go -> handler.go
 	if strings.HasSuffix(base, "_test.go") {
-		return filepath.Join(dir, strings.TrimSuffix(base, "_test.go")+".go")
+		return path.Join(dir, strings.TrimSuffix(base, "_test.go")+".go")
 	}
 
 	// JavaScript/TypeScript: auth.test.js -> auth.js
 	if strings.Contains(base, ".test.") {
-		return filepath.Join(dir, strings.Replace(base, ".test.", ".", 1))
+		return path.Join(dir, strings.Replace(base, ".test.", ".", 1))
 	}
 	if strings.Contains(base, ".spec.") {
-		return filepath.Join(dir, strings.Replace(base, ".spec.", ".", 1))
+		return path.Join(dir, strings.Replace(base, ".spec.", ".", 1))
 	}
 
 	// Python: test_auth.py -> auth.py or auth_test.py -> auth.py
 	if strings.HasPrefix(base, "test_") {
-		return filepath.Join(dir, strings.TrimPrefix(base, "test_"))
+		return path.Join(dir, strings.TrimPrefix(base, "test_"))
 	}
 	if strings.HasSuffix(base, "_test.py") {
-		return filepath.Join(dir, strings.TrimSuffix(base, "_test.py")+".py")
+		return path.Join(dir, strings.TrimSuffix(base, "_test.py")+".py")
 	}
 
 	// Java/Kotlin: AuthTest.java -> Auth.java
 	if strings.HasSuffix(base, "Test.java") {
-		return filepath.Join(dir, strings.TrimSuffix(base, "Test.java")+".java")
+		return path.Join(dir, strings.TrimSuffix(base, "Test.java")+".java")
 	}
 	if strings.HasSuffix(base, "Test.kt") {
-		return filepath.Join(dir, strings.TrimSuffix(base, "Test.kt")+".kt")
+		return path.Join(dir, strings.TrimSuffix(base, "Test.kt")+".kt")
 	}
 
 	// Fallback: remove test from name
-	return filepath.Join(dir, strings.TrimSuffix(base, ext)+ext)
+	return path.Join(dir, strings.TrimSuffix(base, ext)+ext)
 }
 
 // sortBatches sorts batches by total score descending.
@@ -1,6 +1,7 @@
 package batch
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/crealfy/crea-review/pkg/priority"
@@ -89,6 +90,49 @@ func TestGrouperTestPairing(t *testing.T) {
 	}
 }
 
+func TestGrouperTestPairingWindowsPaths(t *testing.T) {
+	grouper := NewGrouper(DefaultOptions())
+	scores := []priority.Score{
+		{Path: `pkg\auth\handler.go`, Total: 80},
+		{Path: "pkg/auth/handler_test.go", Total: 30},
+	}
+
+	batches := grouper.Group(scores)
+	if len(batches) != 1 {
+		t.Fatalf("got %d batches, want 1 test-pair batch: %+v", len(batches), batches)
+	}
+
+	if batches[0].Reason != "test-pair" || len(batches[0].Files) != 2 || batches[0].Files[0] != "pkg/auth/handler.go" {
+		t.Errorf("batch = %+v, want pkg/auth/handler.go paired with its test", batches[0])
+	}
+}
+
+func TestGrouperTestPairingSameBasename(t *testing.T) {
+	grouper := NewGrouper(DefaultOptions())
+	scores := []priority.Score{
+		{Path: "pkg/a/handler.go", Total: 80},
+		{Path: "pkg/b/handler.go", Total: 70},
+		{Path: "pkg/a/handler_test.go", Total: 30},
+	}
+
+	pairs := 0
+
+	for _, batch := range grouper.Group(scores) {
+		if batch.Reason != "test-pair" {
+			continue
+		}
+
+		pairs++
+		if len(batch.Files) != 2 || batch.Files[0] != "pkg/a/handler.go" || batch.Files[1] != "pkg/a/handler_test.go" {
+			t.Errorf("test-pair batch = %v, want pkg/a/handler.go with its test only", batch.Files)
+		}
+	}
+
+	if pairs != 1 {
+		t.Errorf("got %d test-pair batches, want 1", pairs)
+	}
+}
+
 func TestGrouperPackageGrouping(t *testing.T) {
 	grouper := NewGrouper(DefaultOptions())
 	scores := []priority.Score{
@@ -136,6 +180,41 @@ func TestGrouperMaxFilesPerBatch(t *testing.T) {
 	}
 }
 
+func TestGrouperNoGrouping(t *testing.T) {
+	opts := Options{MaxFilesPerBatch: 2}
+
+	grouper := NewGrouper(opts)
+	scores := []priority.Score{
+		{Path: "pkg/a/handler.go", Total: 50},
+		{Path: "pkg/b/util.go", Total: 45},
+		{Path: "pkg/a/handler_test.go", Total: 40},
+		{Path: "pkg/b/a.go", Total: 10},
+		{Path: "pkg/c/big.go", Total: 5},
+	}
+
+	batches := grouper.Group(scores)
+
+	want := [][]string{
+		{"pkg/a/handler.go", "pkg/b/util.go"},
+		{"pkg/a/handler_test.go", "pkg/b/a.go"},
+		{"pkg/c/big.go"},
+	}
+
+	if len(batches) != len(want) {
+		t.Fatalf("len(batches) = %d, want %d", len(batches), len(want))
+	}
+
+	for i, batch := range batches {
+		if batch.ID != i+1 || batch.Reason != "ungrouped" {
+			t.Errorf("batches[%d] = ID %d reason %q, want ID %d ungrouped", i, batch.ID, batch.Reason, i+1)
+		}
+
+		if strings.Join(batch.Files, ",") != strings.Join(want[i], ",") {
+			t.Errorf("batches[%d].Files = %v, want %v", i, batch.Files, want[i])
+		}
+	}
+}
+
 func TestGrouperMaxTotalFiles(t *testing.T) {
 	opts := DefaultOptions()
 	opts.MaxTotalFiles = 3
@@ -197,6 +276,10 @@ func TestGetSourcePath(t *testing.T) {
 		{"auth_test.py", "auth.py"},
 		{"AuthTest.java", "Auth.java"},
 		{"AuthTest.kt", "Auth.kt"},
+		// Same basename in different packages stays in its own directory
+		{"pkg/a/handler_test.go", "pkg/a/handler.go"},
+		{"pkg/b/handler_test.go", "pkg/b/handler.go"},
+		{"svc/b/test_models.py", "svc/b/models.py"},
 	}
 
 	for _, tt := range tests {
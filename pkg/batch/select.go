@@ -0,0 +1,76 @@
+package batch
+
+import (
+	"path"
+	"strings"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/priority"
+)
+
+// Limit applies the max-files cut. Files matching always are kept
+// regardless of rank and count against the budget first; the remaining slots
+// go to the highest-ranked other files. Order is preserved.
+func Limit(scores []priority.Score, maxFiles int, always []string) []priority.Score {
+	if maxFiles <= 0 || len(scores) <= maxFiles {
+		return scores
+	}
+
+	budget := maxFiles
+	for _, s := range scores {
+		if MatchesAny(s.Path, always) {
+			budget--
+		}
+	}
+
+	kept := make([]priority.Score, 0, maxFiles)
+
+	for _, s := range scores {
+		switch {
+		case MatchesAny(s.Path, always):
+			kept = append(kept, s)
+		case budget > 0:
+			kept = append(kept, s)
+			budget--
+		}
+	}
+
+	return kept
+}
+
+// Files keeps the files that have a score in scores, in the order of files.
+func Files(files []rcontext.FileContent, scores []priority.Score) []rcontext.FileContent {
+	keep := make(map[string]bool, len(scores))
+	for _, s := range scores {
+		keep[s.Path] = true
+	}
+
+	var result []rcontext.FileContent
+
+	for _, f := range files {
+		if keep[f.Path] {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+// MatchesAny reports whether file matches one of the glob patterns.
+// Patterns without a slash also match the file's base name, so "*.sql"
+// matches "db/migrations/001.sql".
+func MatchesAny(file string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, file); ok {
+			return true
+		}
+
+		if !strings.Contains(p, "/") {
+			if ok, _ := path.Match(p, path.Base(file)); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,175 @@
+package batch
+
+import (
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/priority"
+)
+
+func TestLimit(t *testing.T) {
+	scores := []priority.Score{
+		{Path: "pkg/api/handler.go", Total: 80},
+		{Path: "pkg/util/strings.go", Total: 50},
+		{Path: "auth/session.go", Total: 20},
+		{Path: "db/migrations/001.sql", Total: 10},
+	}
+
+	tests := []struct {
+		name      string
+		maxFiles  int
+		always    []string
+		wantPaths []string
+	}{
+		{
+			name:      "no limit",
+			maxFiles:  0,
+			always:    []string{"auth/session.go"},
+			wantPaths: []string{"pkg/api/handler.go", "pkg/util/strings.go", "auth/session.go", "db/migrations/001.sql"},
+		},
+		{
+			name:      "top files without allowlist",
+			maxFiles:  2,
+			wantPaths: []string{"pkg/api/handler.go", "pkg/util/strings.go"},
+		},
+		{
+			name:      "always-review survives max-files 1",
+			maxFiles:  1,
+			always:    []string{"auth/session.go"},
+			wantPaths: []string{"auth/session.go"},
+		},
+		{
+			name:      "always-review counts against the budget first",
+			maxFiles:  2,
+			always:    []string{"auth/*.go"},
+			wantPaths: []string{"pkg/api/handler.go", "auth/session.go"},
+		},
+		{
+			name:      "base name pattern may exceed the budget",
+			maxFiles:  1,
+			always:    []string{"*.sql", "auth/session.go"},
+			wantPaths: []string{"auth/session.go", "db/migrations/001.sql"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Limit(scores, tt.maxFiles, tt.always)
+
+			if len(got) != len(tt.wantPaths) {
+				t.Fatalf("got %d scores, want %d", len(got), len(tt.wantPaths))
+			}
+
+			for i, want := range tt.wantPaths {
+				if got[i].Path != want {
+					t.Errorf("got[%d].Path = %q, want %q", i, got[i].Path, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  []rcontext.FileContent
+		scores []priority.Score
+		want   int
+	}{
+		{
+			name: "all files matched",
+			files: []rcontext.FileContent{
+				{Path: "a.go"},
+				{Path: "b.go"},
+				{Path: "c.go"},
+			},
+			scores: []priority.Score{
+				{Path: "a.go", Total: 90},
+				{Path: "b.go", Total: 80},
+				{Path: "c.go", Total: 70},
+			},
+			want: 3,
+		},
+		{
+			name: "some files matched",
+			files: []rcontext.FileContent{
+				{Path: "a.go"},
+				{Path: "b.go"},
+				{Path: "c.go"},
+				{Path: "d.go"},
+			},
+			scores: []priority.Score{
+				{Path: "a.go", Total: 90},
+				{Path: "c.go", Total: 70},
+			},
+			want: 2,
+		},
+		{
+			name: "no files matched",
+			files: []rcontext.FileContent{
+				{Path: "a.go"},
+				{Path: "b.go"},
+			},
+			scores: []priority.Score{
+				{Path: "x.go", Total: 90},
+			},
+			want: 0,
+		},
+		{
+			name:   "empty files",
+			files:  []rcontext.FileContent{},
+			scores: []priority.Score{{Path: "a.go", Total: 90}},
+			want:   0,
+		},
+		{
+			name: "empty scores",
+			files: []rcontext.FileContent{
+				{Path: "a.go"},
+			},
+			scores: []priority.Score{},
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Files(tt.files, tt.scores)
+			if len(got) != tt.want {
+				t.Errorf("Files() = %d files, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFilesPaths(t *testing.T) {
+	files := []rcontext.FileContent{
+		{Path: "a.go"},
+		{Path: "b.go"},
+		{Path: "c.go"},
+	}
+	scores := []priority.Score{
+		{Path: "a.go", Total: 90},
+		{Path: "c.go", Total: 70},
+	}
+
+	got := Files(files, scores)
+	if len(got) != 2 {
+		t.Fatalf("Files() = %d files, want 2", len(got))
+	}
+
+	// Verify the correct files were returned
+	paths := make(map[string]bool)
+	for _, f := range got {
+		paths[f.Path] = true
+	}
+
+	if !paths["a.go"] {
+		t.Error("missing a.go in result")
+	}
+	if !paths["c.go"] {
+		t.Error("missing c.go in result")
+	}
+	if paths["b.go"] {
+		t.Error("b.go should not be in result")
+	}
+}
@@ -0,0 +1,47 @@
+package batch
+
+import (
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// Counts records how the files of a change were split up before a batch
+// was reviewed.
+type Counts struct {
+	// Scored is the number of files left after scoring and filtering,
+	// before the max-files cut.
+	Scored int
+
+	// Skipped is the number of files dropped before scoring finished: by
+	// --path, the score filters or sampling.
+	Skipped int
+
+	// Unsampled is the part of Skipped that sampling left out. Those files
+	// are still to be reviewed with --continue.
+	Unsampled int
+
+	// Previous is the number of files reviewed by the sessions being
+	// continued.
+	Previous int
+}
+
+// NewSession returns an in-progress session for the files in reviewCtx,
+// continuing session continuedFrom (0 for none). The files scored but cut by
+// the max-files limit, and the unsampled ones, remain for --continue.
+func NewSession(reviewCtx *rcontext.ReviewContext, counts Counts, continuedFrom int) *session.Session {
+	sess := &session.Session{
+		BaseCommit:       reviewCtx.BaseCommit,
+		HeadCommit:       reviewCtx.HeadCommit,
+		TotalFilesInDiff: counts.Scored + counts.Skipped + counts.Previous,
+		FilesReviewed:    len(reviewCtx.ChangedFiles),
+		FilesRemaining:   counts.Scored - len(reviewCtx.ChangedFiles) + counts.Unsampled,
+		Status:           session.StatusInProgress,
+		ContinuedFrom:    continuedFrom,
+	}
+
+	for _, f := range reviewCtx.ChangedFiles {
+		sess.Files = append(sess.Files, f.Path)
+	}
+
+	return sess
+}
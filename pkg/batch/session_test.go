@@ -0,0 +1,34 @@
+package batch
+
+import (
+	"slices"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestNewSession(t *testing.T) {
+	reviewCtx := &rcontext.ReviewContext{
+		BaseCommit:   "abc123",
+		HeadCommit:   "def456",
+		ChangedFiles: []rcontext.FileContent{{Path: "a.go"}, {Path: "b.go"}},
+	}
+
+	// 5 files scored, 2 of them selected; 3 more dropped before scoring, 1
+	// of those by sampling; 4 reviewed by the earlier sessions
+	sess := NewSession(reviewCtx, Counts{Scored: 5, Skipped: 3, Unsampled: 1, Previous: 4}, 7)
+
+	if sess.TotalFilesInDiff != 12 || sess.FilesReviewed != 2 || sess.FilesRemaining != 4 {
+		t.Errorf("total, reviewed, remaining = %d, %d, %d; want 12, 2, 4",
+			sess.TotalFilesInDiff, sess.FilesReviewed, sess.FilesRemaining)
+	}
+
+	if sess.Status != session.StatusInProgress || sess.ContinuedFrom != 7 {
+		t.Errorf("Status = %q, ContinuedFrom = %d; want in progress, 7", sess.Status, sess.ContinuedFrom)
+	}
+
+	if sess.BaseCommit != "abc123" || sess.HeadCommit != "def456" || !slices.Equal(sess.Files, []string{"a.go", "b.go"}) {
+		t.Errorf("session = %+v, want the commits and files of reviewCtx", sess)
+	}
+}
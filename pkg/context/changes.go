@@ -0,0 +1,76 @@
+package context
+
+import (
+	"context"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// gatherFileContents collects metadata about changed files (no content - Claude reads files itself).
+func gatherFileContents(_ context.Context, _ string, diffFiles []git.DiffFile, opts GatherOptions) ([]FileContent, ReviewStats) {
+	var files []FileContent
+	stats := ReviewStats{
+		TotalFiles: len(diffFiles),
+	}
+
+	// Build exclusion set for already-reviewed files
+	excludeSet := make(map[string]bool)
+	for _, f := range opts.ExcludeFiles {
+		excludeSet[NormalizePath(f)] = true
+	}
+
+	for _, df := range diffFiles {
+		// Skip excluded files (already reviewed in previous session)
+		if excludeSet[NormalizePath(df.Path)] {
+			stats.SkippedFiles++
+
+			continue
+		}
+
+		// Skip deleted files when requested (nothing left to critique)
+		if opts.SkipDeleted && df.Status == git.FileDeleted {
+			stats.SkippedFiles++
+
+			continue
+		}
+
+		// Skip binary files
+		if df.IsBinary {
+			stats.BinaryFiles++
+
+			continue
+		}
+
+		// Skip files that only remove lines when reviewing additions
+		if opts.AddedOnly && df.LinesAdded == 0 {
+			stats.SkippedFiles++
+
+			continue
+		}
+
+		// Check max files limit
+		if opts.MaxFiles > 0 && len(files) >= opts.MaxFiles {
+			stats.SkippedFiles++
+
+			continue
+		}
+
+		stats.TotalLinesAdded += df.LinesAdded
+		stats.TotalLinesDeleted += df.LinesDeleted
+
+		// Only collect metadata - Claude reads files itself
+		fc := FileContent{
+			Path:         NormalizePath(df.Path),
+			OldPath:      NormalizePath(df.OldPath),
+			Status:       string(df.Status),
+			LinesAdded:   df.LinesAdded,
+			LinesDeleted: df.LinesDeleted,
+			Language:     detectLanguage(df.Path),
+		}
+
+		files = append(files, fc)
+		stats.ReviewedFiles++
+	}
+
+	return files, stats
+}
@@ -0,0 +1,172 @@
+package context
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultCodeOwnersPaths are where GitHub looks for a CODEOWNERS file,
+// relative to the repo root, in lookup order.
+var DefaultCodeOwnersPaths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// CodeOwners maps paths to their owners, as a CODEOWNERS file does.
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+// codeOwnersRule is one CODEOWNERS line: a path pattern and its owners.
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// LoadCodeOwners reads the first of DefaultCodeOwnersPaths that exists in
+// repoPath. It returns nil when the repository has no CODEOWNERS file.
+func LoadCodeOwners(repoPath string) (*CodeOwners, error) {
+	for _, p := range DefaultCodeOwnersPaths {
+		f, err := os.Open(filepath.Join(repoPath, filepath.FromSlash(p)))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read CODEOWNERS: %w", err)
+		}
+
+		defer f.Close()
+
+		owners, err := parseCodeOwners(f)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", p, err)
+		}
+
+		return owners, nil
+	}
+
+	return nil, nil
+}
+
+// parseCodeOwners parses CODEOWNERS lines: a gitignore-style pattern
+// followed by owners (@user, @org/team or an email). Blank lines and #
+// comments are skipped; a pattern without owners clears ownership.
+func parseCodeOwners(r io.Reader) (*CodeOwners, error) {
+	co := &CodeOwners{}
+	sc := bufio.NewScanner(r)
+
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), " #")
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		co.rules = append(co.rules, codeOwnersRule{
+			pattern: regexp.MustCompile(codeOwnersPattern(fields[0])),
+			owners:  fields[1:],
+		})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return co, nil
+}
+
+// Owners returns the owners of path, from the last matching rule.
+func (co *CodeOwners) Owners(path string) []string {
+	path = NormalizePath(path)
+
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		if co.rules[i].pattern.MatchString(path) {
+			return co.rules[i].owners
+		}
+	}
+
+	return nil
+}
+
+// Reviewers returns the owners of files without duplicates, in order of
+// first appearance.
+func (co *CodeOwners) Reviewers(files []string) []string {
+	var (
+		reviewers []string
+		seen      = make(map[string]bool)
+	)
+
+	for _, f := range files {
+		for _, owner := range co.Owners(f) {
+			if !seen[owner] {
+				seen[owner] = true
+				reviewers = append(reviewers, owner)
+			}
+		}
+	}
+
+	return reviewers
+}
+
+// codeOwnersPattern translates a CODEOWNERS pattern into a regular
+// expression over slash-separated, repo-relative paths. A pattern without a
+// slash (other than a trailing one) matches at any depth. A pattern ending
+// in "/" or in a literal name also matches everything under it, while a
+// trailing wildcard stays within one level: "docs/*" matches docs/a.md but
+// not docs/guides/b.md.
+func codeOwnersPattern(pattern string) string {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	subtree := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+
+	if last := pattern[strings.LastIndex(pattern, "/")+1:]; !strings.ContainsAny(last, "*?") {
+		subtree = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("^")
+
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				// "**/" also matches no directory at all
+				if i+2 < len(pattern) && pattern[i+2] == '/' {
+					sb.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					sb.WriteString(".*")
+					i++
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if subtree {
+		sb.WriteString("(?:/.*)?")
+	}
+
+	sb.WriteString("$")
+
+	return sb.String()
+}
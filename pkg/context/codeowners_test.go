@@ -0,0 +1,101 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCodeOwnersPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*", "cmd/main.go", true},
+		{"*.js", "web/app.js", true},
+		{"*.js", "web/app.ts", false},
+		{"docs/", "docs/usage.md", true},
+		{"docs/", "pkg/docs/notes.md", true},
+		{"/docs/", "pkg/docs/notes.md", false},
+		{"apps/api", "apps/api/server.go", true},
+		{"apps/api", "lib/apps/api/server.go", false},
+		{"/build/logs/", "build/logs/a/b.log", true},
+		{"docs/*", "docs/usage.md", true},
+		{"docs/*", "docs/guides/setup.md", false},
+		{"apps/*/", "apps/api/server.go", true},
+		{"**", "a/b/c.go", true},
+		{"**/logs", "deep/nested/logs/x.log", true},
+		{"**/logs", "logs/x.log", true},
+		{"src/**/test", "src/a/b/test/x_test.go", true},
+		{"Makefile", "tools/Makefile", true},
+		{"file?.txt", "file1.txt", true},
+	}
+
+	for _, tt := range tests {
+		re := regexp.MustCompile(codeOwnersPattern(tt.pattern))
+		if got := re.MatchString(tt.path); got != tt.match {
+			t.Errorf("pattern %q on %q = %v, want %v", tt.pattern, tt.path, got, tt.match)
+		}
+	}
+}
+
+func TestCodeOwnersReviewers(t *testing.T) {
+	co, err := parseCodeOwners(strings.NewReader(`# Default owners
+*                   @alice
+
+# Security owns auth, also reviewed by bob
+/pkg/auth/          @crealfy/security-team @bob
+*.md                docs@example.com # inline comment
+/pkg/auth/README.md
+`))
+	if err != nil {
+		t.Fatalf("parseCodeOwners() error = %v", err)
+	}
+
+	tests := []struct {
+		files []string
+		want  []string
+	}{
+		{[]string{"main.go"}, []string{"@alice"}},
+		{[]string{"pkg/auth/login.go", "main.go", "pkg/auth/token.go"}, []string{"@crealfy/security-team", "@bob", "@alice"}},
+		{[]string{"docs/usage.md"}, []string{"docs@example.com"}},
+		{[]string{"pkg/auth/README.md"}, nil},
+		{nil, nil},
+	}
+
+	for _, tt := range tests {
+		if got := co.Reviewers(tt.files); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Reviewers(%v) = %v, want %v", tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestLoadCodeOwners(t *testing.T) {
+	dir := t.TempDir()
+
+	co, err := LoadCodeOwners(dir)
+	if err != nil || co != nil {
+		t.Fatalf("LoadCodeOwners() without a file = %v, %v; want nil, nil", co, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	writeTestFile(t, dir, "CODEOWNERS", "* @root-owner\n")
+	writeTestFile(t, dir, ".github/CODEOWNERS", "* @github-owner\n")
+
+	co, err = LoadCodeOwners(dir)
+	if err != nil {
+		t.Fatalf("LoadCodeOwners() error = %v", err)
+	}
+
+	// .github/CODEOWNERS takes precedence, as on GitHub
+	if got := co.Owners("main.go"); !reflect.DeepEqual(got, []string{"@github-owner"}) {
+		t.Errorf("Owners() = %v, want [@github-owner]", got)
+	}
+}
@@ -0,0 +1,66 @@
+package context
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// MaxReviewCommits caps how many commit messages are included for review.
+const MaxReviewCommits = 100
+
+// Commit is a commit message in the reviewed range.
+type Commit struct {
+	// Hash is the abbreviated commit hash.
+	Hash string
+
+	// Subject is the first line of the message.
+	Subject string
+
+	// Body is the rest of the message, without trailing blank lines.
+	Body string
+}
+
+// logCommits returns the messages of the commits in base..head, newest first,
+// up to MaxReviewCommits. An empty head means HEAD; a base that isn't a
+// commit (the empty tree of an initial commit) includes all of HEAD's history.
+func logCommits(ctx context.Context, repoPath, base, head string) ([]Commit, error) {
+	if head == "" {
+		head = "HEAD"
+	}
+
+	inRange := false
+	if base != "" {
+		_, err := revParse(ctx, repoPath, base)
+		inRange = err == nil
+	}
+
+	var (
+		log []git.Commit
+		err error
+	)
+
+	if inRange {
+		log, err = git.CommitsBetween(ctx, repoPath, base, head)
+	} else {
+		log, err = git.Log(ctx, repoPath, git.LogOptions{MaxCount: MaxReviewCommits})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("log commits: %w", err)
+	}
+
+	commits := make([]Commit, 0, min(len(log), MaxReviewCommits))
+
+	for _, c := range log[:min(len(log), MaxReviewCommits)] {
+		commits = append(commits, Commit{
+			Hash:    c.ShortHash,
+			Subject: c.Subject,
+			Body:    strings.TrimRight(c.Body, "\n"),
+		})
+	}
+
+	return commits, nil
+}
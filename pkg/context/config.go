@@ -0,0 +1,243 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidConfig indicates a config file or option that can't be used.
+var ErrInvalidConfig = errors.New("invalid config")
+
+// DefaultConfigFile is the team config file loaded from the repo root when present.
+const DefaultConfigFile = "review.yaml"
+
+// Config is team review policy, usually versioned in the repo as review.yaml.
+type Config struct {
+	// Instructions are default review instructions passed to the model.
+	Instructions string `yaml:"instructions"`
+
+	// PathInstructions are instructions added only when the change touches
+	// files under their path.
+	PathInstructions []PathInstruction `yaml:"path_instructions"`
+
+	// CriticalPaths are extra regex patterns marking critical files.
+	CriticalPaths []string `yaml:"critical_paths"`
+
+	// Categories are extra finding categories beyond the built-in ones.
+	Categories []string `yaml:"categories"`
+
+	// Severities maps a category to the severity used when the model omits one.
+	Severities map[string]string `yaml:"severities"`
+
+	// DocURLs maps a category to a documentation URL attached to its findings.
+	DocURLs map[string]string `yaml:"doc_urls"`
+
+	// Weights overrides individual priority scoring weights.
+	Weights WeightsConfig `yaml:"weights"`
+
+	// RedactPatterns are extra regex patterns masked by --redact,
+	// on top of DefaultRedactPatterns.
+	RedactPatterns []string `yaml:"redact_patterns"`
+
+	// PRTemplate is the pull request template used by --with-pr-template,
+	// relative to the repo root. Empty means GitHub's default locations.
+	PRTemplate string `yaml:"pr_template"`
+
+	// Models maps an environment (see ModelEnvironment), such as "ci" or
+	// "local", to the model used there unless --model is given.
+	Models map[string]string `yaml:"models"`
+
+	// Profiles are named bundles of instructions, categories and weights,
+	// selected with --profile.
+	Profiles map[string]Profile `yaml:"profiles"`
+
+	// Focus limits reported findings to these categories. It is set by
+	// ApplyProfile rather than read from YAML.
+	Focus []string `yaml:"-"`
+}
+
+// WeightsConfig holds optional scoring weight overrides.
+// Nil fields keep the scorer's defaults.
+type WeightsConfig struct {
+	LinesChanged *float64 `yaml:"lines_changed"`
+	Criticality  *float64 `yaml:"criticality"`
+	Churn        *float64 `yaml:"churn"`
+	TestCoverage *float64 `yaml:"test_coverage"`
+	Recency      *float64 `yaml:"recency"`
+	Authors      *float64 `yaml:"authors"`
+}
+
+// LoadConfig loads review.yaml from the repo root (if present) followed by
+// the given files, merging them in order. YAML files are parsed as Config;
+// any other file (e.g. CLAUDE.md) is appended to Instructions.
+// Relative paths are resolved against repoPath.
+// Returns an empty Config when there is nothing to load.
+func LoadConfig(repoPath string, files []string) (*Config, error) {
+	paths := files
+
+	defaultPath := filepath.Join(repoPath, DefaultConfigFile)
+	if _, err := os.Stat(defaultPath); err == nil {
+		paths = append([]string{defaultPath}, files...)
+	}
+
+	cfg := &Config{}
+
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(repoPath, path)
+		}
+
+		if err := cfg.mergeFile(path); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return cfg, nil
+}
+
+// mergeFile merges a single config or instruction file into cfg.
+func (c *Config) mergeFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".yaml" && ext != ".yml" {
+		c.appendInstructions(string(data))
+
+		return nil
+	}
+
+	var file Config
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	c.merge(&file)
+
+	return nil
+}
+
+// merge applies other on top of c: lists are appended, maps and weights are overridden.
+func (c *Config) merge(other *Config) {
+	c.appendInstructions(other.Instructions)
+	c.PathInstructions = append(c.PathInstructions, other.PathInstructions...)
+	c.CriticalPaths = append(c.CriticalPaths, other.CriticalPaths...)
+	c.Categories = append(c.Categories, other.Categories...)
+	c.RedactPatterns = append(c.RedactPatterns, other.RedactPatterns...)
+
+	if other.PRTemplate != "" {
+		c.PRTemplate = other.PRTemplate
+	}
+
+	if len(other.Profiles) > 0 {
+		if c.Profiles == nil {
+			c.Profiles = make(map[string]Profile)
+		}
+
+		maps.Copy(c.Profiles, other.Profiles)
+	}
+
+	if len(other.Severities) > 0 {
+		if c.Severities == nil {
+			c.Severities = make(map[string]string)
+		}
+
+		maps.Copy(c.Severities, other.Severities)
+	}
+
+	if len(other.Models) > 0 {
+		if c.Models == nil {
+			c.Models = make(map[string]string)
+		}
+
+		maps.Copy(c.Models, other.Models)
+	}
+
+	if len(other.DocURLs) > 0 {
+		if c.DocURLs == nil {
+			c.DocURLs = make(map[string]string)
+		}
+
+		maps.Copy(c.DocURLs, other.DocURLs)
+	}
+
+	mergeWeight(&c.Weights.LinesChanged, other.Weights.LinesChanged)
+	mergeWeight(&c.Weights.Criticality, other.Weights.Criticality)
+	mergeWeight(&c.Weights.Churn, other.Weights.Churn)
+	mergeWeight(&c.Weights.TestCoverage, other.Weights.TestCoverage)
+	mergeWeight(&c.Weights.Recency, other.Weights.Recency)
+	mergeWeight(&c.Weights.Authors, other.Weights.Authors)
+}
+
+// mergeWeight overrides dst when src is set.
+func mergeWeight(dst **float64, src *float64) {
+	if src != nil {
+		*dst = src
+	}
+}
+
+// appendInstructions adds text to Instructions, separated by a blank line.
+func (c *Config) appendInstructions(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	if c.Instructions != "" {
+		c.Instructions += "\n\n"
+	}
+
+	c.Instructions += text
+}
+
+// Validate checks that patterns compile, severities are known, doc URLs are
+// absolute and path instructions are complete.
+func (c *Config) Validate() error {
+	var errs []error
+
+	for _, pattern := range c.CriticalPaths {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("invalid critical path pattern %q: %w", pattern, err))
+		}
+	}
+
+	for _, pattern := range c.RedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("invalid redact pattern %q: %w", pattern, err))
+		}
+	}
+
+	for category, severity := range c.Severities {
+		switch severity {
+		case "error", "warning", "suggestion":
+		default:
+			errs = append(errs, fmt.Errorf("invalid severity %q for category %q", severity, category))
+		}
+	}
+
+	if err := validatePathInstructions(c.PathInstructions); err != nil {
+		errs = append(errs, err)
+	}
+
+	for category, raw := range c.DocURLs {
+		if u, err := url.Parse(raw); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid doc URL %q for category %q: want an absolute URL", raw, category))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,140 @@
+package context
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+
+	return path
+}
+
+func TestLoadConfigNone(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if cfg == nil || cfg.Instructions != "" || len(cfg.CriticalPaths) != 0 {
+		t.Errorf("LoadConfig() = %+v, want empty config", cfg)
+	}
+}
+
+func TestLoadConfigDefaultFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, DefaultConfigFile, `
+instructions: Check for missing error handling.
+critical_paths:
+  - (?i)/ledger/
+categories:
+  - accessibility
+severities:
+  accessibility: warning
+doc_urls:
+  security: https://wiki.example.com/secure-coding
+weights:
+  churn: 0.5
+`)
+
+	cfg, err := LoadConfig(dir, nil)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if cfg.Instructions != "Check for missing error handling." {
+		t.Errorf("Instructions = %q", cfg.Instructions)
+	}
+	if len(cfg.CriticalPaths) != 1 || cfg.CriticalPaths[0] != "(?i)/ledger/" {
+		t.Errorf("CriticalPaths = %v", cfg.CriticalPaths)
+	}
+	if len(cfg.Categories) != 1 || cfg.Categories[0] != "accessibility" {
+		t.Errorf("Categories = %v", cfg.Categories)
+	}
+	if cfg.Severities["accessibility"] != "warning" {
+		t.Errorf("Severities = %v", cfg.Severities)
+	}
+	if cfg.DocURLs["security"] != "https://wiki.example.com/secure-coding" {
+		t.Errorf("DocURLs = %v", cfg.DocURLs)
+	}
+	if cfg.Weights.Churn == nil || *cfg.Weights.Churn != 0.5 {
+		t.Errorf("Weights.Churn = %v, want 0.5", cfg.Weights.Churn)
+	}
+	if cfg.Weights.Recency != nil {
+		t.Errorf("Weights.Recency = %v, want nil", *cfg.Weights.Recency)
+	}
+}
+
+func TestLoadConfigMergeOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, DefaultConfigFile, `
+instructions: Team rules.
+critical_paths: ["/ledger/"]
+weights:
+  churn: 0.5
+  recency: 0.1
+`)
+	override := writeTestFile(t, dir, "local.yml", `
+critical_paths: ["/vault/"]
+weights:
+  churn: 0.2
+`)
+	writeTestFile(t, dir, "CLAUDE.md", "Prefer table-driven tests.\n")
+
+	cfg, err := LoadConfig(dir, []string{override, "CLAUDE.md"})
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if len(cfg.CriticalPaths) != 2 {
+		t.Errorf("CriticalPaths = %v, want both files appended", cfg.CriticalPaths)
+	}
+	if *cfg.Weights.Churn != 0.2 {
+		t.Errorf("Weights.Churn = %v, want later file to win", *cfg.Weights.Churn)
+	}
+	if *cfg.Weights.Recency != 0.1 {
+		t.Errorf("Weights.Recency = %v, want 0.1 kept", *cfg.Weights.Recency)
+	}
+	if !strings.Contains(cfg.Instructions, "Team rules.") || !strings.Contains(cfg.Instructions, "table-driven") {
+		t.Errorf("Instructions = %q, want both sources", cfg.Instructions)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid yaml", "critical_paths: [unclosed"},
+		{"invalid pattern", "critical_paths: ['(']"},
+		{"invalid severity", "severities:\n  security: fatal"},
+		{"invalid redact pattern", "redact_patterns: ['[']"},
+		{"relative doc URL", "doc_urls:\n  security: docs/security.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFile(t, dir, DefaultConfigFile, tt.content)
+
+			if _, err := LoadConfig(dir, nil); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("LoadConfig() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := LoadConfig(t.TempDir(), []string{"missing.yaml"}); err == nil {
+			t.Error("expected error for missing config file")
+		}
+	})
+}
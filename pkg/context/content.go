@@ -0,0 +1,236 @@
+package context
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// Default byte caps for embedded file content.
+const (
+	DefaultMaxLineBytes = 2000
+	DefaultMaxFileBytes = 256 * 1024
+)
+
+// maxReadWorkers caps concurrent file reads so large diffs don't exhaust file descriptors.
+const maxReadWorkers = 16
+
+// readContents fills Content, Truncated, LinesTotal and InvalidUTF8 for each file using a
+// bounded worker pool. Results are written in place, so order is preserved.
+// Deleted, binary and submodule entries are skipped; a file that fails to read
+// is left without content and reported as a warning. Returns ctx.Err() if the
+// context is cancelled before all reads finish.
+func readContents(ctx context.Context, repoPath string, files []FileContent, opts GatherOptions) error {
+	workers := opts.ReadConcurrency
+	if workers <= 0 {
+		workers = min(runtime.NumCPU(), maxReadWorkers)
+	}
+	workers = min(workers, len(files))
+
+	indexes := make(chan int)
+	errs := make([]error, len(files))
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				f := &files[i]
+
+				text, err := readFileCapped(repoPath, f.Path, opts)
+				if err != nil {
+					errs[i] = err
+
+					continue
+				}
+
+				text.apply(f)
+			}
+		}()
+	}
+
+	var cancelled error
+
+feed:
+	for i := range files {
+		if !hasReadableContent(files[i]) {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			cancelled = err
+
+			break
+		}
+
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			cancelled = ctx.Err()
+
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if cancelled != nil {
+		return fmt.Errorf("read file contents: %w", cancelled)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// hasReadableContent reports whether a changed file has working-tree content worth reading.
+func hasReadableContent(f FileContent) bool {
+	return f.Status != string(git.FileDeleted) && !f.IsBinary && !f.IsSubmodule
+}
+
+// fileText is a file's content as embedded in the prompt.
+type fileText struct {
+	// content is the (possibly truncated) file content, always valid UTF-8.
+	content string
+
+	// truncated reports whether content was cut.
+	truncated bool
+
+	// lines is the file's total line count before truncation.
+	lines int
+
+	// invalidUTF8 reports whether invalid UTF-8 was replaced with U+FFFD.
+	invalidUTF8 bool
+}
+
+// apply copies the text into f.
+func (t fileText) apply(f *FileContent) {
+	f.Content = t.content
+	f.Truncated = t.truncated
+	f.LinesTotal = t.lines
+	f.InvalidUTF8 = t.invalidUTF8
+}
+
+// readFileContent reads a file relative to the repo root, truncating it to
+// maxLines unless noTruncate is set or maxLines is not positive. Invalid UTF-8
+// (e.g. a latin-1 file) is replaced with U+FFFD so the prompt stays valid.
+func readFileContent(repoPath, path string, maxLines int, noTruncate bool) (fileText, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, path))
+	if err != nil {
+		return fileText{}, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	text := fileText{invalidUTF8: !utf8.Valid(data)}
+	if text.invalidUTF8 {
+		data = bytes.ToValidUTF8(data, []byte(string(utf8.RuneError)))
+	}
+
+	content := string(data)
+	lines := strings.Split(content, "\n")
+
+	text.lines = len(lines)
+	if strings.HasSuffix(content, "\n") {
+		text.lines--
+	}
+
+	if noTruncate || maxLines <= 0 || text.lines <= maxLines {
+		text.content = content
+
+		return text, nil
+	}
+
+	text.content = strings.Join(lines[:maxLines], "\n") +
+		fmt.Sprintf("\n... (truncated, %d more lines)", text.lines-maxLines)
+	text.truncated = true
+
+	return text, nil
+}
+
+// readFileCapped reads a file like readFileContent, then applies the
+// MaxLineBytes and MaxFileBytes caps from opts.
+func readFileCapped(repoPath, path string, opts GatherOptions) (fileText, error) {
+	text, err := readFileContent(repoPath, path, opts.MaxFileLines, opts.NoTruncate)
+	if err != nil {
+		return fileText{}, err
+	}
+
+	var capped bool
+
+	text.content, capped = capContent(text.content, opts.MaxLineBytes, opts.MaxFileBytes)
+	text.truncated = text.truncated || capped
+
+	return text, nil
+}
+
+// capContent cuts lines longer than maxLineBytes and the whole content at
+// maxBytes, ending each cut with a marker. Cuts never split a UTF-8 rune.
+// A limit of 0 or less disables that cap. Reports whether anything was cut.
+func capContent(content string, maxLineBytes, maxBytes int) (string, bool) {
+	capped := false
+
+	if maxLineBytes > 0 && len(content) > maxLineBytes {
+		lines := strings.Split(content, "\n")
+		for i, line := range lines {
+			if len(line) <= maxLineBytes {
+				continue
+			}
+
+			cut := runeBoundary(line, maxLineBytes)
+			lines[i] = line[:cut] + fmt.Sprintf(" ... (line truncated, %d more bytes)", len(line)-cut)
+			capped = true
+		}
+
+		if capped {
+			content = strings.Join(lines, "\n")
+		}
+	}
+
+	if maxBytes > 0 && len(content) > maxBytes {
+		cut := runeBoundary(content, maxBytes)
+		if nl := strings.LastIndexByte(content[:cut], '\n'); nl > 0 {
+			cut = nl
+		}
+
+		content = content[:cut] + fmt.Sprintf("\n... (truncated, %d more bytes)", len(content)-cut)
+		capped = true
+	}
+
+	return content, capped
+}
+
+// runeBoundary returns the largest index <= n that doesn't split a UTF-8 rune in s.
+func runeBoundary(s string, n int) int {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return n
+}
+
+// countInvalidUTF8 returns how many files had invalid UTF-8 replaced.
+func countInvalidUTF8(files []FileContent) int {
+	n := 0
+
+	for _, f := range files {
+		if f.InvalidUTF8 {
+			n++
+		}
+	}
+
+	return n
+}
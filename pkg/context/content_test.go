@@ -0,0 +1,328 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// writeContentFixture writes n small files into dir and returns matching FileContent entries.
+func writeContentFixture(tb testing.TB, dir string, n int) []FileContent {
+	tb.Helper()
+
+	files := make([]FileContent, n)
+	for i := range n {
+		name := fmt.Sprintf("file%03d.go", i)
+		content := strings.Repeat(fmt.Sprintf("// line of %s\n", name), 50)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			tb.Fatalf("failed to write %s: %v", name, err)
+		}
+		files[i] = FileContent{Path: name, Status: string(git.FileModified)}
+	}
+
+	return files
+}
+
+func TestReadContents(t *testing.T) {
+	t.Run("preserves order", func(t *testing.T) {
+		dir := t.TempDir()
+		files := writeContentFixture(t, dir, 40)
+
+		opts := GatherOptions{MaxFileLines: 10, ReadConcurrency: 4}
+		if err := readContents(context.Background(), dir, files, opts); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, f := range files {
+			if !strings.HasPrefix(f.Content, "// line of "+f.Path) {
+				t.Errorf("%s: content = %q, want its own lines", f.Path, f.Content[:min(len(f.Content), 30)])
+			}
+			if !f.Truncated {
+				t.Errorf("%s: expected truncated=true", f.Path)
+			}
+			if f.LinesTotal != 50 {
+				t.Errorf("%s: LinesTotal = %d, want 50", f.Path, f.LinesTotal)
+			}
+		}
+	})
+
+	t.Run("skips unreadable entries", func(t *testing.T) {
+		dir := t.TempDir()
+		files := []FileContent{
+			{Path: "gone.go", Status: string(git.FileDeleted)},
+			{Path: "image.png", Status: string(git.FileModified), IsBinary: true},
+			{Path: "vendor/lib", Status: string(git.FileModified), IsSubmodule: true},
+		}
+
+		if err := readContents(context.Background(), dir, files, GatherOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, f := range files {
+			if f.Content != "" {
+				t.Errorf("%s: content = %q, want empty", f.Path, f.Content)
+			}
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		dir := t.TempDir()
+		files := writeContentFixture(t, dir, 5)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := readContents(ctx, dir, files, GatherOptions{ReadConcurrency: 1})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestReadFileCapped(t *testing.T) {
+	t.Run("one-line 1MB file", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "app.min.js"), []byte(strings.Repeat("x", 1<<20)), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+
+		text, err := readFileCapped(dir, "app.min.js", DefaultGatherOptions())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, truncated, total := text.content, text.truncated, text.lines
+		if !truncated {
+			t.Error("expected truncated=true")
+		}
+		if total != 1 {
+			t.Errorf("total = %d, want 1", total)
+		}
+		if len(got) > DefaultMaxLineBytes+100 {
+			t.Errorf("len(content) = %d, want about %d", len(got), DefaultMaxLineBytes)
+		}
+		if !strings.HasSuffix(got, fmt.Sprintf("(line truncated, %d more bytes)", 1<<20-DefaultMaxLineBytes)) {
+			t.Errorf("content ends with %q, want line truncation marker", got[len(got)-60:])
+		}
+	})
+
+	t.Run("no caps", func(t *testing.T) {
+		dir := t.TempDir()
+		content := strings.Repeat("y", 5000)
+		if err := os.WriteFile(filepath.Join(dir, "big.txt"), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+
+		text, err := readFileCapped(dir, "big.txt", GatherOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, truncated := text.content, text.truncated
+		if truncated || got != content {
+			t.Errorf("truncated = %v, len = %d; want untouched content", truncated, len(got))
+		}
+	})
+}
+
+func TestCapContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		maxLineBytes int
+		maxBytes     int
+		want         string
+		wantCapped   bool
+	}{
+		{"under caps", "short\nlines", 10, 100, "short\nlines", false},
+		{"long line", "abcdefgh\nok", 4, 0, "abcd ... (line truncated, 4 more bytes)\nok", true},
+		{"keeps runes whole", "héllo", 2, 0, "h ... (line truncated, 5 more bytes)", true},
+		{"total cap at line boundary", "aaaa\nbbbb\ncccc", 0, 12, "aaaa\nbbbb\n... (truncated, 5 more bytes)", true},
+		{"disabled", strings.Repeat("z", 50), 0, 0, strings.Repeat("z", 50), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, capped := capContent(tt.content, tt.maxLineBytes, tt.maxBytes)
+
+			if got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+			if capped != tt.wantCapped {
+				t.Errorf("capped = %v, want %v", capped, tt.wantCapped)
+			}
+		})
+	}
+}
+
+func BenchmarkReadContents(b *testing.B) {
+	dir := b.TempDir()
+	files := writeContentFixture(b, dir, 200)
+	opts := DefaultGatherOptions()
+
+	b.ResetTimer()
+	for range b.N {
+		if err := readContents(context.Background(), dir, files, opts); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestReadFileContent(t *testing.T) {
+	t.Run("file not found", func(t *testing.T) {
+		_, err := readFileContent("/nonexistent", "file.go", 100, false)
+		if err == nil {
+			t.Error("expected error for nonexistent file")
+		}
+	})
+
+	t.Run("success - no truncation", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		content := "line1\nline2\nline3"
+		filePath := filepath.Join(tmpDir, "test.go")
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		text, err := readFileContent(tmpDir, "test.go", 10, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, truncated, totalLines := text.content, text.truncated, text.lines
+		if got != content {
+			t.Errorf("content = %q, want %q", got, content)
+		}
+		if truncated {
+			t.Error("expected truncated=false")
+		}
+		if totalLines != 3 {
+			t.Errorf("totalLines = %d, want 3", totalLines)
+		}
+	})
+
+	t.Run("success - with truncation", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		lines := make([]string, 10)
+		for i := range 10 {
+			lines[i] = "line1"
+		}
+		content := strings.Join(lines, "\n")
+		filePath := filepath.Join(tmpDir, "test.go")
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		text, err := readFileContent(tmpDir, "test.go", 5, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, truncated, totalLines := text.content, text.truncated, text.lines
+		if !truncated {
+			t.Error("expected truncated=true")
+		}
+		if totalLines != 10 {
+			t.Errorf("totalLines = %d, want 10", totalLines)
+		}
+		if !strings.Contains(got, "truncated") {
+			t.Error("expected truncation message in content")
+		}
+		// Check that we got approximately 5 lines plus the truncation message
+		gotLines := strings.Split(got, "\n")
+		if len(gotLines) > 7 { // 5 lines + 2 for truncation message
+			t.Errorf("got %d lines, expected ~6-7", len(gotLines))
+		}
+	})
+
+	t.Run("noTruncate option", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		lines := make([]string, 10)
+		for i := range 10 {
+			lines[i] = "line1"
+		}
+		content := strings.Join(lines, "\n")
+		filePath := filepath.Join(tmpDir, "test.go")
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		text, err := readFileContent(tmpDir, "test.go", 5, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, truncated, totalLines := text.content, text.truncated, text.lines
+		if truncated {
+			t.Error("expected truncated=false with noTruncate=true")
+		}
+		if totalLines != 10 {
+			t.Errorf("totalLines = %d, want 10", totalLines)
+		}
+		if got != content {
+			t.Error("content should not be truncated with noTruncate=true")
+		}
+	})
+
+	t.Run("maxLines zero or negative - no truncation", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		content := "line1\nline2\nline3\n"
+		filePath := filepath.Join(tmpDir, "test.go")
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		// Test with 0
+		text, err := readFileContent(tmpDir, "test.go", 0, false)
+		if err != nil {
+			t.Fatalf("unexpected error with maxLines=0: %v", err)
+		}
+		got, truncated := text.content, text.truncated
+		if truncated {
+			t.Error("expected truncated=false with maxLines=0")
+		}
+		if got != content {
+			t.Error("content should not be truncated with maxLines=0")
+		}
+	})
+
+	t.Run("latin-1 content is sanitized", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		// "café = 5€" in latin-1: é is 0xE9, and 0x80 is undefined
+		if err := os.WriteFile(filepath.Join(tmpDir, "menu.txt"), []byte("caf\xe9 = 5\x80\n"), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		text, err := readFileContent(tmpDir, "menu.txt", 10, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !text.invalidUTF8 {
+			t.Error("expected invalidUTF8=true")
+		}
+		if !utf8.ValidString(text.content) {
+			t.Errorf("content %q is not valid UTF-8", text.content)
+		}
+		if want := "caf\uFFFD = 5\uFFFD\n"; text.content != want {
+			t.Errorf("content = %q, want %q", text.content, want)
+		}
+	})
+
+	t.Run("valid UTF-8 is untouched", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		content := "café = 5€\n"
+		if err := os.WriteFile(filepath.Join(tmpDir, "menu.txt"), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		text, err := readFileContent(tmpDir, "menu.txt", 10, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if text.invalidUTF8 || text.content != content {
+			t.Errorf("got (%q, invalidUTF8=%v), want (%q, false)", text.content, text.invalidUTF8, content)
+		}
+	})
+}
@@ -5,8 +5,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/crealfy/crea-pipe/pkg/git"
 )
@@ -25,9 +23,16 @@ type ReviewContext struct {
 	// BaseBranch is the base branch name (if comparing branches).
 	BaseBranch string
 
+	// SameCommit is set when base and head resolve to the same commit,
+	// in which case nothing is diffed.
+	SameCommit bool
+
 	// Diff is the raw unified diff.
 	Diff string
 
+	// DiffFiles is the structured file list from git, before any exclusions.
+	DiffFiles []git.DiffFile
+
 	// ChangedFiles contains the changed files with their contents.
 	ChangedFiles []FileContent
 
@@ -37,6 +42,18 @@ type ReviewContext struct {
 	// LinterOutput contains optional linter findings.
 	LinterOutput []LinterFinding
 
+	// Config is the merged team config (review.yaml and ConfigFiles).
+	Config *Config
+
+	// PRTemplate is the team's pull request template, used as a reviewer checklist.
+	PRTemplate string
+
+	// Commits are the commit messages in the range, when IncludeCommits is set.
+	Commits []Commit
+
+	// PrimaryLanguage is the language with the most changed lines (see RankLanguages).
+	PrimaryLanguage string
+
 	// Stats contains review statistics.
 	Stats ReviewStats
 }
@@ -64,6 +81,9 @@ type FileContent struct {
 	// LinesDeleted is the number of lines deleted in the diff.
 	LinesDeleted int
 
+	// Hunks is the number of separate hunks in the diff.
+	Hunks int
+
 	// Status is the file status (added, modified, deleted, renamed).
 	Status string
 
@@ -75,6 +95,33 @@ type FileContent struct {
 
 	// RelatedReason explains why this file is related (for RelatedFiles).
 	RelatedReason string
+
+	// IsSubmodule indicates a submodule (gitlink) pointer change rather than a file.
+	IsSubmodule bool
+
+	// Note is a short annotation for the reviewer (e.g. the old → new submodule commit).
+	Note string
+
+	// Scoped indicates Content holds only the changed regions, with line
+	// numbers, rather than the whole file.
+	Scoped bool
+
+	// InvalidUTF8 indicates Content had invalid UTF-8 replaced with U+FFFD.
+	InvalidUTF8 bool
+
+	// EditorConfig holds the file's .editorconfig style settings (see
+	// EditorConfigKeys), when gathered with IncludeEditorConfig.
+	EditorConfig map[string]string
+}
+
+// IsRename reports whether the file was renamed (possibly with edits).
+func (f FileContent) IsRename() bool {
+	return f.Status == string(git.FileRenamed)
+}
+
+// IsPureRename reports whether the file was renamed without content changes.
+func (f FileContent) IsPureRename() bool {
+	return f.IsRename() && f.LinesAdded == 0 && f.LinesDeleted == 0
 }
 
 // LinterFinding represents a linter finding.
@@ -120,6 +167,10 @@ type ReviewStats struct {
 
 	// BinaryFiles is the number of binary files skipped.
 	BinaryFiles int
+
+	// InvalidUTF8Files is the number of files whose embedded content had
+	// invalid UTF-8 replaced.
+	InvalidUTF8Files int
 }
 
 // GatherOptions configures context gathering.
@@ -139,15 +190,83 @@ type GatherOptions struct {
 	// IncludeLinters runs linters and includes output.
 	IncludeLinters bool
 
+	// IncludePRTemplate adds the pull request template to the context as a reviewer checklist.
+	IncludePRTemplate bool
+
+	// IncludeCommits adds the commit messages in the range so they are reviewed too.
+	IncludeCommits bool
+
+	// IncludeEditorConfig adds each changed file's .editorconfig style settings.
+	IncludeEditorConfig bool
+
 	// LinterCommand is the linter command to run (e.g., "golangci-lint run --out-format json").
 	LinterCommand string
 
 	// LintAll runs linter on entire repo, not just changed files.
 	LintAll bool
 
+	// LintBaselineDir, when set, keeps only linter findings that aren't
+	// present at the base commit. Base findings are cached there per commit.
+	LintBaselineDir string
+
+	// LinterEnv holds extra environment variables for the linter command
+	// (see LinterOptions.Env).
+	LinterEnv map[string]string
+
+	// MaxFileLines is the maximum number of lines to include per file.
+	MaxFileLines int
+
+	// NoTruncate disables line-count truncation. MaxLineBytes and
+	// MaxFileBytes still apply.
+	NoTruncate bool
+
+	// MaxLineBytes caps each embedded line, so a minified file on one huge
+	// line can't flood the prompt (0 = unlimited).
+	MaxLineBytes int
+
+	// MaxFileBytes caps the embedded content of each file (0 = unlimited).
+	MaxFileBytes int
+
+	// Content selects how much of each changed file is read into
+	// FileContent.Content. Empty means ContentNone.
+	Content ContentMode
+
+	// ContentContext is the number of lines kept around each changed region
+	// with ContentDiff.
+	ContentContext int
+
+	// ReadConcurrency bounds parallel file reads when Content is set.
+	// Zero means the number of CPUs, capped at 16.
+	ReadConcurrency int
+
+	// IncludeRelated includes files related to the changes.
+	IncludeRelated bool
+
+	// RelatedDepth is how many commits of history to scan for related files.
+	RelatedDepth int
+
+	// ConfigFiles are additional config or instruction files (e.g., CLAUDE.md, review.yaml),
+	// merged after the repo's review.yaml. See LoadConfig.
+	ConfigFiles []string
+
+	// Profile is the config profile to apply after loading, if any.
+	Profile string
+
 	// MaxFiles limits the number of files to gather.
 	MaxFiles int
 
+	// SkipDeleted drops deleted files from the review.
+	// They still count toward ReviewStats.TotalFiles but not toward MaxFiles.
+	SkipDeleted bool
+
+	// AddedOnly reviews only added lines: files without any are skipped and
+	// hunks that add nothing are dropped from the diff.
+	AddedOnly bool
+
+	// IncludeUntracked adds untracked, non-ignored files as added files
+	// when the head is the working tree.
+	IncludeUntracked bool
+
 	// ExcludeFiles is a list of file paths to exclude from gathering.
 	// Used when continuing from a previous session to skip already-reviewed files.
 	ExcludeFiles []string
@@ -156,16 +275,21 @@ type GatherOptions struct {
 // DefaultGatherOptions returns default gather options.
 func DefaultGatherOptions() GatherOptions {
 	return GatherOptions{
-		HeadCommit: "HEAD",
-		ReviewType: "all",
-		MaxFiles:   50,
+		HeadCommit:     "HEAD",
+		ReviewType:     "all",
+		MaxFileLines:   500,
+		MaxLineBytes:   DefaultMaxLineBytes,
+		MaxFileBytes:   DefaultMaxFileBytes,
+		IncludeRelated: true,
+		RelatedDepth:   5,
+		MaxFiles:       50,
 	}
 }
 
 // Gather collects all context needed for a code review.
 func Gather(ctx context.Context, repoPath string, opts GatherOptions) (*ReviewContext, error) {
 	// Resolve repo root
-	root, err := git.RepoRoot(ctx, repoPath)
+	root, err := RepoRoot(ctx, repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("resolve repo root: %w", err)
 	}
@@ -174,17 +298,42 @@ func Gather(ctx context.Context, repoPath string, opts GatherOptions) (*ReviewCo
 		RepoPath: root,
 	}
 
+	// Load team config before anything else so errors surface early
+	rc.Config, err = LoadConfig(root, opts.ConfigFiles)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	if err := rc.Config.ApplyProfile(opts.Profile); err != nil {
+		return nil, err
+	}
+
 	// Determine base and head commits
+	if err := verifyRefs(ctx, root, opts); err != nil {
+		return nil, err
+	}
+
 	if err := resolveCommits(ctx, rc, opts); err != nil {
 		return nil, fmt.Errorf("resolve commits: %w", err)
 	}
 
+	// A fresh repo or first commit has no parent to diff against
+	if err := resolveInitialCommit(ctx, rc); err != nil {
+		return nil, fmt.Errorf("resolve commits: %w", err)
+	}
+
+	// Identical base and head is almost always a typo; say so instead of diffing
+	if rc.HeadCommit != "" && sameCommit(ctx, root, rc.BaseCommit, rc.HeadCommit) {
+		rc.SameCommit = true
+
+		return rc, nil
+	}
+
 	// Get raw diff
 	diff, err := git.Diff(ctx, root, rc.BaseCommit, rc.HeadCommit)
 	if err != nil {
 		return nil, fmt.Errorf("get diff: %w", err)
 	}
-	rc.Diff = diff
 
 	// Get structured file list
 	diffFiles, err := git.DiffFiles(ctx, root, rc.BaseCommit, rc.HeadCommit)
@@ -192,207 +341,85 @@ func Gather(ctx context.Context, repoPath string, opts GatherOptions) (*ReviewCo
 		return nil, fmt.Errorf("get diff files: %w", err)
 	}
 
-	// Gather file contents
-	rc.ChangedFiles, rc.Stats = gatherFileContents(ctx, root, diffFiles, opts)
-
-	// Skip related files gathering - Claude reads files itself
-
-	// Run linters if requested
-	if opts.IncludeLinters {
-		findings, err := runLinters(ctx, root, rc.ChangedFiles, opts)
+	// Untracked files only exist in the working tree
+	if opts.IncludeUntracked && rc.HeadCommit == "" {
+		untrackedDiff, untracked, err := untrackedChanges(ctx, root)
 		if err != nil {
-			// Non-fatal
-			fmt.Fprintf(os.Stderr, "warning: failed to run linters: %v\n", err)
-		} else {
-			rc.LinterOutput = findings
+			return nil, err
 		}
-	}
 
-	return rc, nil
-}
-
-// resolveCommits determines the base and head commits based on options.
-func resolveCommits(ctx context.Context, rc *ReviewContext, opts GatherOptions) error {
-	// If base commit is specified, use it
-	if opts.BaseCommit != "" {
-		rc.BaseCommit = opts.BaseCommit
-		rc.HeadCommit = opts.HeadCommit
-
-		return nil
-	}
-
-	// If base branch is specified, find merge base
-	if opts.BaseBranch != "" {
-		rc.BaseBranch = opts.BaseBranch
-		head, err := git.HEAD(ctx, rc.RepoPath)
-		if err != nil {
-			return fmt.Errorf("get HEAD: %w", err)
-		}
-		rc.HeadCommit = head
-
-		// For branch comparison, we'll use the branch name directly
-		// The Diff function handles this
-		rc.BaseCommit = opts.BaseBranch
-
-		return nil
+		diff += untrackedDiff
+		diffFiles = append(diffFiles, untracked...)
 	}
 
-	// Handle review types
-	switch opts.ReviewType {
-	case "uncommitted":
-		// Compare against HEAD for uncommitted changes
-		rc.BaseCommit = "HEAD"
-		rc.HeadCommit = "" // Empty means working directory
-	case "committed":
-		// Compare HEAD against its parent
-		rc.BaseCommit = "HEAD~1"
-		rc.HeadCommit = "HEAD"
-	default: // "all"
-		// Compare against HEAD (shows all uncommitted changes)
-		rc.BaseCommit = "HEAD"
-		rc.HeadCommit = ""
+	if opts.AddedOnly {
+		diff = filterAddedHunks(diff)
 	}
 
-	return nil
-}
-
-// gatherFileContents collects metadata about changed files (no content - Claude reads files itself).
-func gatherFileContents(_ context.Context, _ string, diffFiles []git.DiffFile, opts GatherOptions) ([]FileContent, ReviewStats) {
-	var files []FileContent
-	stats := ReviewStats{
-		TotalFiles: len(diffFiles),
-	}
+	rc.Diff = diff
+	rc.DiffFiles = diffFiles
 
-	// Build exclusion set for already-reviewed files
-	excludeSet := make(map[string]bool)
-	for _, f := range opts.ExcludeFiles {
-		excludeSet[f] = true
-	}
+	// Gather file contents
+	rc.ChangedFiles, rc.Stats = gatherFileContents(ctx, root, diffFiles, opts)
+	rc.PrimaryLanguage = primaryLanguage(rc.ChangedFiles)
 
-	for _, df := range diffFiles {
-		// Skip excluded files (already reviewed in previous session)
-		if excludeSet[df.Path] {
-			stats.SkippedFiles++
+	// Submodule bumps have no textual diff; annotate them so they aren't mistaken for empty files
+	annotateSubmodules(rc.ChangedFiles, diff)
+	annotateHunks(rc.ChangedFiles, diff)
 
-			continue
+	if opts.Content == ContentFull || opts.Content == ContentDiff {
+		if err := readChangedContents(ctx, root, rc.HeadCommit, rc.ChangedFiles, diff, opts); err != nil {
+			return nil, err
 		}
 
-		// Skip binary files
-		if df.IsBinary {
-			stats.BinaryFiles++
-
-			continue
+		rc.Stats.InvalidUTF8Files = countInvalidUTF8(rc.ChangedFiles)
+		if n := rc.Stats.InvalidUTF8Files; n > 0 {
+			fmt.Fprintf(os.Stderr, "warning: %d file(s) are not valid UTF-8; invalid bytes were replaced in the prompt\n", n)
 		}
+	}
 
-		// Check max files limit
-		if opts.MaxFiles > 0 && len(files) >= opts.MaxFiles {
-			stats.SkippedFiles++
+	// Skip related files gathering - Claude reads files itself
 
-			continue
+	if opts.IncludePRTemplate {
+		rc.PRTemplate, err = loadPRTemplate(root, rc.Config.PRTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
 		}
 
-		stats.TotalLinesAdded += df.LinesAdded
-		stats.TotalLinesDeleted += df.LinesDeleted
-
-		// Only collect metadata - Claude reads files itself
-		fc := FileContent{
-			Path:         df.Path,
-			OldPath:      df.OldPath,
-			Status:       string(df.Status),
-			LinesAdded:   df.LinesAdded,
-			LinesDeleted: df.LinesDeleted,
-			Language:     detectLanguage(df.Path),
+		if rc.PRTemplate == "" {
+			fmt.Fprintf(os.Stderr, "warning: no pull request template found\n")
 		}
-
-		files = append(files, fc)
-		stats.ReviewedFiles++
 	}
 
-	return files, stats
-}
-
-
-// detectLanguage detects the programming language from file extension.
-func detectLanguage(path string) string {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".go":
-		return "go"
-	case ".py":
-		return "python"
-	case ".js":
-		return "javascript"
-	case ".ts":
-		return "typescript"
-	case ".jsx":
-		return "jsx"
-	case ".tsx":
-		return "tsx"
-	case ".rs":
-		return "rust"
-	case ".java":
-		return "java"
-	case ".kt", ".kts":
-		return "kotlin"
-	case ".c":
-		return "c"
-	case ".cpp", ".cc", ".cxx":
-		return "cpp"
-	case ".h", ".hpp":
-		return "c-header"
-	case ".cs":
-		return "csharp"
-	case ".rb":
-		return "ruby"
-	case ".php":
-		return "php"
-	case ".swift":
-		return "swift"
-	case ".sh", ".bash":
-		return "shell"
-	case ".sql":
-		return "sql"
-	case ".html", ".htm":
-		return "html"
-	case ".css":
-		return "css"
-	case ".scss", ".sass":
-		return "scss"
-	case ".json":
-		return "json"
-	case ".yaml", ".yml":
-		return "yaml"
-	case ".xml":
-		return "xml"
-	case ".md", ".markdown":
-		return "markdown"
-	case ".proto":
-		return "protobuf"
-	default:
-		return "text"
+	if opts.IncludeEditorConfig {
+		annotateEditorConfig(root, rc.ChangedFiles)
 	}
-}
 
-
-// runLinters runs the configured linter on changed files.
-func runLinters(ctx context.Context, repoPath string, files []FileContent, opts GatherOptions) ([]LinterFinding, error) {
-	if opts.LinterCommand == "" {
-		return nil, nil // No linter configured
+	if opts.IncludeCommits {
+		rc.Commits, err = logCommits(ctx, root, rc.BaseCommit, rc.HeadCommit)
+		if err != nil {
+			return nil, fmt.Errorf("log commits: %w", err)
+		}
 	}
 
-	// Extract file paths (skip deleted files)
-	var filePaths []string
+	// Run linters if requested
+	if opts.IncludeLinters {
+		findings, err := runLinters(ctx, root, rc.ChangedFiles, opts)
+		if err != nil {
+			// Non-fatal
+			fmt.Fprintf(os.Stderr, "warning: failed to run linters: %v\n", err)
+		} else {
+			if opts.LintBaselineDir != "" {
+				if fresh, err := filterLintBaseline(ctx, rc, findings, opts); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: lint baseline: %v; keeping all linter findings\n", err)
+				} else {
+					findings = fresh
+				}
+			}
 
-	for _, f := range files {
-		if f.Status != "deleted" {
-			filePaths = append(filePaths, f.Path)
+			rc.LinterOutput = findings
 		}
 	}
 
-	return RunLinter(ctx, LinterOptions{
-		Command:  opts.LinterCommand,
-		RepoPath: repoPath,
-		Files:    filePaths,
-		All:      opts.LintAll,
-	})
+	return rc, nil
 }
@@ -2,9 +2,6 @@ package context
 
 import (
 	"context"
-	"os"
-	"path/filepath"
-	"strings"
 	"testing"
 
 	"github.com/crealfy/crea-pipe/pkg/git"
@@ -33,163 +30,6 @@ func TestDefaultGatherOptions(t *testing.T) {
 	}
 }
 
-func TestDetectLanguage(t *testing.T) {
-	tests := []struct {
-		path     string
-		expected string
-	}{
-		{"main.go", "go"},
-		{"script.py", "python"},
-		{"app.js", "javascript"},
-		{"component.tsx", "tsx"},
-		{"handler.ts", "typescript"},
-		{"lib.rs", "rust"},
-		{"Main.java", "java"},
-		{"app.kt", "kotlin"},
-		{"main.c", "c"},
-		{"util.cpp", "cpp"},
-		{"header.h", "c-header"},
-		{"Program.cs", "csharp"},
-		{"script.rb", "ruby"},
-		{"index.php", "php"},
-		{"App.swift", "swift"},
-		{"script.sh", "shell"},
-		{"query.sql", "sql"},
-		{"index.html", "html"},
-		{"style.css", "css"},
-		{"style.scss", "scss"},
-		{"config.json", "json"},
-		{"config.yaml", "yaml"},
-		{"config.yml", "yaml"},
-		{"doc.xml", "xml"},
-		{"README.md", "markdown"},
-		{"schema.proto", "protobuf"},
-		{"unknown.xyz", "text"},
-		{"Makefile", "text"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.path, func(t *testing.T) {
-			got := detectLanguage(tt.path)
-			if got != tt.expected {
-				t.Errorf("detectLanguage(%q) = %q, want %q", tt.path, got, tt.expected)
-			}
-		})
-	}
-}
-
-func TestReadFileContent(t *testing.T) {
-	t.Run("file not found", func(t *testing.T) {
-		_, _, _, err := readFileContent("/nonexistent", "file.go", 100, false)
-		if err == nil {
-			t.Error("expected error for nonexistent file")
-		}
-	})
-
-	t.Run("success - no truncation", func(t *testing.T) {
-		tmpDir := t.TempDir()
-		content := "line1\nline2\nline3"
-		filePath := filepath.Join(tmpDir, "test.go")
-		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
-			t.Fatalf("failed to write test file: %v", err)
-		}
-
-		got, truncated, totalLines, err := readFileContent(tmpDir, "test.go", 10, false)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if got != content {
-			t.Errorf("content = %q, want %q", got, content)
-		}
-		if truncated {
-			t.Error("expected truncated=false")
-		}
-		if totalLines != 3 {
-			t.Errorf("totalLines = %d, want 3", totalLines)
-		}
-	})
-
-	t.Run("success - with truncation", func(t *testing.T) {
-		tmpDir := t.TempDir()
-		lines := make([]string, 10)
-		for i := range 10 {
-			lines[i] = "line1"
-		}
-		content := strings.Join(lines, "\n")
-		filePath := filepath.Join(tmpDir, "test.go")
-		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
-			t.Fatalf("failed to write test file: %v", err)
-		}
-
-		got, truncated, totalLines, err := readFileContent(tmpDir, "test.go", 5, false)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if !truncated {
-			t.Error("expected truncated=true")
-		}
-		if totalLines != 10 {
-			t.Errorf("totalLines = %d, want 10", totalLines)
-		}
-		if !strings.Contains(got, "truncated") {
-			t.Error("expected truncation message in content")
-		}
-		// Check that we got approximately 5 lines plus the truncation message
-		gotLines := strings.Split(got, "\n")
-		if len(gotLines) > 7 { // 5 lines + 2 for truncation message
-			t.Errorf("got %d lines, expected ~6-7", len(gotLines))
-		}
-	})
-
-	t.Run("noTruncate option", func(t *testing.T) {
-		tmpDir := t.TempDir()
-		lines := make([]string, 10)
-		for i := range 10 {
-			lines[i] = "line1"
-		}
-		content := strings.Join(lines, "\n")
-		filePath := filepath.Join(tmpDir, "test.go")
-		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
-			t.Fatalf("failed to write test file: %v", err)
-		}
-
-		got, truncated, totalLines, err := readFileContent(tmpDir, "test.go", 5, true)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if truncated {
-			t.Error("expected truncated=false with noTruncate=true")
-		}
-		if totalLines != 10 {
-			t.Errorf("totalLines = %d, want 10", totalLines)
-		}
-		if got != content {
-			t.Error("content should not be truncated with noTruncate=true")
-		}
-	})
-
-	t.Run("maxLines zero or negative - no truncation", func(t *testing.T) {
-		tmpDir := t.TempDir()
-		content := "line1\nline2\nline3\n"
-		filePath := filepath.Join(tmpDir, "test.go")
-		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
-			t.Fatalf("failed to write test file: %v", err)
-		}
-
-		// Test with 0
-		got, truncated, _, err := readFileContent(tmpDir, "test.go", 0, false)
-		if err != nil {
-			t.Fatalf("unexpected error with maxLines=0: %v", err)
-		}
-		if truncated {
-			t.Error("expected truncated=false with maxLines=0")
-		}
-		if got != content {
-			t.Error("content should not be truncated with maxLines=0")
-		}
-	})
-}
-
 func TestReviewContextTypes(t *testing.T) {
 	// Test that types can be instantiated correctly
 	rc := &ReviewContext{
@@ -367,6 +207,17 @@ func TestResolveCommits(t *testing.T) {
 			wantBranch: "",
 			wantError:  false,
 		},
+		{
+			name: "base branch with explicit head",
+			opts: GatherOptions{
+				BaseBranch: "main",
+				HeadCommit: "def456",
+			},
+			wantBase:   "main",
+			wantHead:   "def456",
+			wantBranch: "main",
+			wantError:  false,
+		},
 		{
 			name: "base branch specified - requires git repo",
 			opts: GatherOptions{
@@ -511,4 +362,65 @@ func TestGatherFileContents(t *testing.T) {
 			t.Errorf("files[2].Status = %q, want 'deleted'", files[2].Status)
 		}
 	})
+	t.Run("rename with edits vs pure rename", func(t *testing.T) {
+		diffFiles := []git.DiffFile{
+			{Path: "pkg/new.go", OldPath: "pkg/old.go", LinesAdded: 4, LinesDeleted: 2, Status: git.FileRenamed},
+			{Path: "pkg/moved.go", OldPath: "lib/moved.go", Status: git.FileRenamed},
+		}
+
+		files, _ := gatherFileContents(context.Background(), "/tmp", diffFiles, GatherOptions{})
+
+		if len(files) != 2 {
+			t.Fatalf("got %d files, want 2", len(files))
+		}
+		if !files[0].IsRename() || files[0].IsPureRename() || files[0].OldPath != "pkg/old.go" {
+			t.Errorf("files[0] = %+v, want rename with edits from pkg/old.go", files[0])
+		}
+		if !files[1].IsPureRename() {
+			t.Errorf("files[1] = %+v, want pure rename", files[1])
+		}
+	})
+
+	t.Run("windows paths are normalized", func(t *testing.T) {
+		diffFiles := []git.DiffFile{
+			{Path: `pkg\auth\handler.go`, OldPath: `pkg\handler.go`, LinesAdded: 1, Status: git.FileRenamed},
+			{Path: `pkg\done.go`, LinesAdded: 1, Status: git.FileModified},
+		}
+
+		opts := GatherOptions{ExcludeFiles: []string{"pkg/done.go"}}
+		files, _ := gatherFileContents(context.Background(), "/tmp", diffFiles, opts)
+
+		if len(files) != 1 {
+			t.Fatalf("got %d files, want 1 (excluded file matched across separators)", len(files))
+		}
+		if files[0].Path != "pkg/auth/handler.go" || files[0].OldPath != "pkg/handler.go" {
+			t.Errorf("paths = %q, %q, want forward slashes", files[0].Path, files[0].OldPath)
+		}
+	})
+
+	t.Run("skip deleted files", func(t *testing.T) {
+		diffFiles := []git.DiffFile{
+			{Path: "deleted.go", IsBinary: false, LinesAdded: 0, LinesDeleted: 10, Status: git.FileDeleted},
+			{Path: "a.go", IsBinary: false, LinesAdded: 5, LinesDeleted: 0, Status: git.FileModified},
+			{Path: "b.go", IsBinary: false, LinesAdded: 5, LinesDeleted: 0, Status: git.FileModified},
+		}
+
+		opts := GatherOptions{SkipDeleted: true, MaxFiles: 2}
+		files, stats := gatherFileContents(context.Background(), "/tmp", diffFiles, opts)
+
+		if len(files) != 2 {
+			t.Fatalf("got %d files, want 2 (deleted file must not consume budget)", len(files))
+		}
+		for _, f := range files {
+			if f.Status == "deleted" {
+				t.Errorf("deleted file %q should be skipped", f.Path)
+			}
+		}
+		if stats.TotalFiles != 3 {
+			t.Errorf("TotalFiles = %d, want 3", stats.TotalFiles)
+		}
+		if stats.SkippedFiles != 1 {
+			t.Errorf("SkippedFiles = %d, want 1", stats.SkippedFiles)
+		}
+	})
 }
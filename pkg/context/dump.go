@@ -0,0 +1,47 @@
+package context
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDiffDump writes the resolved base/head, the structured file list and
+// the raw diff, for debugging which files a review picked up.
+func (rc *ReviewContext) WriteDiffDump(w io.Writer) error {
+	var sb strings.Builder
+
+	head := rc.HeadCommit
+	if head == "" {
+		head = "working tree"
+	}
+
+	sb.WriteString(fmt.Sprintf("# base: %s\n", rc.BaseCommit))
+	sb.WriteString(fmt.Sprintf("# head: %s\n", head))
+	sb.WriteString(fmt.Sprintf("# files: %d\n", len(rc.DiffFiles)))
+
+	for _, df := range rc.DiffFiles {
+		sb.WriteString(fmt.Sprintf("%s\t+%d\t-%d\t%s", df.Status, df.LinesAdded, df.LinesDeleted, df.Path))
+
+		if df.OldPath != "" && df.OldPath != df.Path {
+			sb.WriteString(fmt.Sprintf(" (from %s)", df.OldPath))
+		}
+
+		if df.IsBinary {
+			sb.WriteString(" [binary]")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("# diff\n")
+	sb.WriteString(rc.Diff)
+
+	if rc.Diff != "" && !strings.HasSuffix(rc.Diff, "\n") {
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
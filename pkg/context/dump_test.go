@@ -0,0 +1,38 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+func TestWriteDiffDump(t *testing.T) {
+	rc := &ReviewContext{
+		BaseCommit: "abc123",
+		Diff:       "diff --git a/new.go b/new.go\n+package main",
+		DiffFiles: []git.DiffFile{
+			{Path: "new.go", Status: git.FileAdded, LinesAdded: 1},
+			{Path: "pkg/b.go", OldPath: "pkg/a.go", Status: git.FileRenamed},
+			{Path: "logo.png", Status: git.FileModified, IsBinary: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := rc.WriteDiffDump(&buf); err != nil {
+		t.Fatalf("WriteDiffDump() error = %v", err)
+	}
+
+	want := "# base: abc123\n" +
+		"# head: working tree\n" +
+		"# files: 3\n" +
+		"added\t+1\t-0\tnew.go\n" +
+		"renamed\t+0\t-0\tpkg/b.go (from pkg/a.go)\n" +
+		"modified\t+0\t-0\tlogo.png [binary]\n" +
+		"# diff\n" +
+		"diff --git a/new.go b/new.go\n+package main\n"
+
+	if buf.String() != want {
+		t.Errorf("WriteDiffDump() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
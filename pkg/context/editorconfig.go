@@ -0,0 +1,227 @@
+package context
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// EditorConfigKeys are the .editorconfig properties passed to the reviewer.
+var EditorConfigKeys = []string{
+	"indent_style",
+	"indent_size",
+	"tab_width",
+	"max_line_length",
+	"trim_trailing_whitespace",
+	"insert_final_newline",
+}
+
+// editorConfig is a parsed .editorconfig file.
+type editorConfig struct {
+	root     bool
+	sections []editorConfigSection
+}
+
+// editorConfigSection is one [glob] section of an .editorconfig file.
+type editorConfigSection struct {
+	pattern *regexp.Regexp
+	props   map[string]string
+}
+
+// annotateEditorConfig sets EditorConfig on each file from the .editorconfig
+// files between it and the repo root.
+func annotateEditorConfig(root string, files []FileContent) {
+	cache := make(map[string]*editorConfig)
+
+	for i := range files {
+		files[i].EditorConfig = editorConfigFor(root, files[i].Path, cache)
+	}
+}
+
+// editorConfigFor resolves the EditorConfigKeys properties for path (relative
+// to root). Closer .editorconfig files and later sections win; the search
+// stops at the repo root or a file with root = true. Returns nil when no
+// property applies.
+func editorConfigFor(root, file string, cache map[string]*editorConfig) map[string]string {
+	// Collect configs from the file's directory up to the root, nearest first
+	var configs []*editorConfig
+
+	var dirs []string
+
+	for dir := path.Dir(file); ; dir = path.Dir(dir) {
+		ec := loadEditorConfig(root, dir, cache)
+		if ec != nil {
+			configs = append(configs, ec)
+			dirs = append(dirs, dir)
+
+			if ec.root {
+				break
+			}
+		}
+
+		if dir == "." || dir == "/" {
+			break
+		}
+	}
+
+	props := make(map[string]string)
+
+	// Apply farthest first so nearer files override
+	for i := len(configs) - 1; i >= 0; i-- {
+		rel := file
+		if dirs[i] != "." {
+			rel = strings.TrimPrefix(file, dirs[i]+"/")
+		}
+
+		for _, s := range configs[i].sections {
+			if !s.pattern.MatchString(rel) {
+				continue
+			}
+
+			for k, v := range s.props {
+				if v == "unset" {
+					delete(props, k)
+				} else {
+					props[k] = v
+				}
+			}
+		}
+	}
+
+	if len(props) == 0 {
+		return nil
+	}
+
+	return props
+}
+
+// loadEditorConfig reads and caches dir/.editorconfig; nil when absent.
+func loadEditorConfig(root, dir string, cache map[string]*editorConfig) *editorConfig {
+	if ec, ok := cache[dir]; ok {
+		return ec
+	}
+
+	var ec *editorConfig
+
+	if data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(dir), ".editorconfig")); err == nil {
+		ec = parseEditorConfig(string(data))
+	}
+
+	cache[dir] = ec
+
+	return ec
+}
+
+// parseEditorConfig parses .editorconfig text, keeping only EditorConfigKeys.
+// Sections with invalid globs are skipped.
+func parseEditorConfig(text string) *editorConfig {
+	ec := &editorConfig{}
+
+	var current *editorConfigSection
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = nil
+
+			if re, err := regexp.Compile(editorConfigGlob(line[1 : len(line)-1])); err == nil {
+				ec.sections = append(ec.sections, editorConfigSection{pattern: re, props: make(map[string]string)})
+				current = &ec.sections[len(ec.sections)-1]
+			}
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		switch {
+		case current == nil && len(ec.sections) == 0 && key == "root":
+			ec.root = value == "true"
+		case current != nil && slices.Contains(EditorConfigKeys, key):
+			current.props[key] = value
+		}
+	}
+
+	return ec
+}
+
+// editorConfigGlob converts an EditorConfig section glob to an anchored
+// regular expression over slash-separated paths relative to the
+// .editorconfig's directory. Globs without a slash match at any depth.
+func editorConfigGlob(glob string) string {
+	var sb strings.Builder
+
+	sb.WriteString("^")
+
+	switch {
+	case strings.HasPrefix(glob, "/"):
+		glob = glob[1:]
+	case !strings.Contains(glob, "/"):
+		sb.WriteString("(?:.*/)?")
+	}
+
+	braces := 0
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+
+		switch {
+		case c == '*' && i+1 < len(glob) && glob[i+1] == '*':
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+
+				continue
+			}
+
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			sb.WriteString("[" + class + "]")
+			i += end
+		case c == '{':
+			braces++
+
+			sb.WriteString("(?:")
+		case c == '}' && braces > 0:
+			braces--
+
+			sb.WriteString(")")
+		case c == ',' && braces > 0:
+			sb.WriteString("|")
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+
+	return sb.String()
+}
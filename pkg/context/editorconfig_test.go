@@ -0,0 +1,97 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestEditorConfigGlob(t *testing.T) {
+	tests := []struct {
+		glob  string
+		path  string
+		match bool
+	}{
+		{"*", "main.go", true},
+		{"*", "pkg/main.go", true},
+		{"*.go", "pkg/review/prompt.go", true},
+		{"*.go", "main.py", false},
+		{"*.{js,ts}", "web/app.ts", true},
+		{"*.{js,ts}", "web/app.tsx", false},
+		{"Makefile", "build/Makefile", true},
+		{"/Makefile", "build/Makefile", false},
+		{"docs/*.md", "docs/usage.md", true},
+		{"docs/*.md", "docs/commands/usage.md", false},
+		{"docs/**.md", "docs/commands/usage.md", true},
+		{"file?.txt", "file1.txt", true},
+		{"[!a]*.txt", "abc.txt", false},
+	}
+
+	for _, tt := range tests {
+		re := regexp.MustCompile(editorConfigGlob(tt.glob))
+		if got := re.MatchString(tt.path); got != tt.match {
+			t.Errorf("glob %q on %q = %v, want %v", tt.glob, tt.path, got, tt.match)
+		}
+	}
+}
+
+func TestEditorConfigFor(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "web", "vendor"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	writeTestFile(t, dir, ".editorconfig", `root = true
+
+[*]
+indent_style = space
+indent_size = 4
+charset = utf-8
+
+[*.go]
+indent_style = tab
+indent_size = unset
+
+[Makefile]
+indent_style = tab
+`)
+	writeTestFile(t, dir, "web/.editorconfig", `[*.ts]
+indent_size = 2
+max_line_length = 100
+`)
+	writeTestFile(t, dir, "web/vendor/.editorconfig", `root = true
+
+[*.ts]
+max_line_length = off
+`)
+
+	tests := []struct {
+		file string
+		want map[string]string
+	}{
+		{"main.go", map[string]string{"indent_style": "tab"}},
+		{"pkg/review/prompt.go", map[string]string{"indent_style": "tab"}},
+		{"README.md", map[string]string{"indent_style": "space", "indent_size": "4"}},
+		{"web/app.ts", map[string]string{"indent_style": "space", "indent_size": "2", "max_line_length": "100"}},
+		{"web/vendor/lib.ts", map[string]string{"max_line_length": "off"}},
+		{"web/vendor/lib.css", nil},
+	}
+
+	cache := make(map[string]*editorConfig)
+	for _, tt := range tests {
+		if got := editorConfigFor(dir, tt.file, cache); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("editorConfigFor(%q) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestAnnotateEditorConfigNone(t *testing.T) {
+	files := []FileContent{{Path: "main.go"}}
+	annotateEditorConfig(t.TempDir(), files)
+
+	if files[0].EditorConfig != nil {
+		t.Errorf("EditorConfig = %v, want nil without .editorconfig", files[0].EditorConfig)
+	}
+}
@@ -0,0 +1,81 @@
+package context
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// StatusWholeFile marks a file reviewed in full rather than as a diff.
+const StatusWholeFile = "whole file"
+
+// GatherFiles builds a review context for whole files, without a diff.
+// Paths are relative to repoPath or absolute, and must be inside the repository.
+// Content is always embedded, truncated per opts.MaxFileLines, opts.NoTruncate and the byte caps.
+func GatherFiles(ctx context.Context, repoPath string, paths []string, opts GatherOptions) (*ReviewContext, error) {
+	root, err := RepoRoot(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve repo root: %w", err)
+	}
+
+	rc := &ReviewContext{
+		RepoPath: root,
+	}
+
+	rc.Config, err = LoadConfig(root, opts.ConfigFiles)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	if err := rc.Config.ApplyProfile(opts.Profile); err != nil {
+		return nil, err
+	}
+
+	for _, p := range paths {
+		rel, err := repoRelative(root, p)
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := readFileCapped(root, rel, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		f := FileContent{
+			Path:     NormalizePath(rel),
+			Language: detectLanguage(rel),
+			Status:   StatusWholeFile,
+		}
+		text.apply(&f)
+		rc.ChangedFiles = append(rc.ChangedFiles, f)
+	}
+
+	rc.Stats = ReviewStats{
+		TotalFiles:       len(rc.ChangedFiles),
+		ReviewedFiles:    len(rc.ChangedFiles),
+		InvalidUTF8Files: countInvalidUTF8(rc.ChangedFiles),
+	}
+	rc.PrimaryLanguage = primaryLanguage(rc.ChangedFiles)
+
+	if opts.IncludeEditorConfig {
+		annotateEditorConfig(root, rc.ChangedFiles)
+	}
+
+	return rc, nil
+}
+
+// repoRelative returns p relative to root, rejecting paths outside the repository.
+func repoRelative(root, p string) (string, error) {
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(root, p)
+	}
+
+	rel, err := filepath.Rel(root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the repository %s", p, root)
+	}
+
+	return rel, nil
+}
@@ -0,0 +1,46 @@
+package context
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+)
+
+func TestGatherFiles(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeTestFile(t, dir, "pkg/util.go", "package pkg\n\nfunc A() {}\n")
+
+	rc, err := GatherFiles(context.Background(), dir, []string{"pkg/util.go"}, DefaultGatherOptions())
+	if err != nil {
+		t.Fatalf("GatherFiles() error = %v", err)
+	}
+
+	if len(rc.ChangedFiles) != 1 {
+		t.Fatalf("got %d files, want 1", len(rc.ChangedFiles))
+	}
+
+	f := rc.ChangedFiles[0]
+	if f.Path != "pkg/util.go" || f.Language != "go" || f.Status != StatusWholeFile {
+		t.Errorf("file = %+v, want pkg/util.go as a whole go file", f)
+	}
+	if f.LinesTotal != 3 || f.Content == "" {
+		t.Errorf("LinesTotal = %d, content %q, want 3 lines of content", f.LinesTotal, f.Content)
+	}
+	if rc.Diff != "" {
+		t.Errorf("Diff = %q, want none", rc.Diff)
+	}
+
+	if _, err := GatherFiles(context.Background(), dir, []string{"../outside.go"}, DefaultGatherOptions()); err == nil {
+		t.Error("expected error for a path outside the repository")
+	}
+
+	if _, err := GatherFiles(context.Background(), dir, []string{"missing.go"}, DefaultGatherOptions()); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
@@ -0,0 +1,99 @@
+package context
+
+import "strings"
+
+// parseHunkCounts counts the "@@" hunk headers of each file in a unified diff, keyed by path.
+func parseHunkCounts(diff string) map[string]int {
+	counts := make(map[string]int)
+
+	var path string
+
+	for line := range strings.SplitSeq(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			path = diffHeaderPath(line)
+		case strings.HasPrefix(line, "@@ ") && path != "":
+			counts[path]++
+		}
+	}
+
+	return counts
+}
+
+// annotateHunks sets Hunks on each changed file from the diff.
+func annotateHunks(files []FileContent, diff string) {
+	counts := parseHunkCounts(diff)
+
+	for i := range files {
+		files[i].Hunks = counts[files[i].Path]
+	}
+}
+
+// filterAddedHunks drops the hunks of a unified diff that add no lines, and
+// the file sections left without hunks (pure deletions, renames, mode and
+// binary changes). Hunks that add lines are kept whole, removed lines included.
+func filterAddedHunks(diff string) string {
+	var (
+		out     strings.Builder
+		header  []string
+		hunk    []string
+		kept    bool
+		hasPlus bool
+	)
+
+	flushHunk := func() {
+		if hasPlus {
+			if !kept {
+				out.WriteString(strings.Join(header, "\n") + "\n")
+				kept = true
+			}
+
+			out.WriteString(strings.Join(hunk, "\n") + "\n")
+		}
+
+		hunk, hasPlus = nil, false
+	}
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flushHunk()
+
+			header, kept = []string{line}, false
+		case strings.HasPrefix(line, "@@ "):
+			flushHunk()
+
+			hunk = []string{line}
+		case hunk != nil:
+			hunk = append(hunk, line)
+			hasPlus = hasPlus || strings.HasPrefix(line, "+")
+		default:
+			header = append(header, line)
+		}
+	}
+
+	flushHunk()
+
+	return out.String()
+}
+
+// FilterDiff keeps the "diff --git" file sections of a unified diff whose
+// path (the b/ side, so renames count where they land) satisfies keep.
+func FilterDiff(diff string, keep func(path string) bool) string {
+	var (
+		out  strings.Builder
+		kept bool
+	)
+
+	for line := range strings.SplitAfterSeq(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			kept = keep(diffHeaderPath(strings.TrimSuffix(line, "\n")))
+		}
+
+		if kept {
+			out.WriteString(line)
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,152 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHunkCounts(t *testing.T) {
+	diff := `diff --git a/one.go b/one.go
+index 1111111..2222222 100644
+--- a/one.go
++++ b/one.go
+@@ -1,3 +1,4 @@
+ package one
++// added
+diff --git a/many.go b/many.go
+index 3333333..4444444 100644
+--- a/many.go
++++ b/many.go
+@@ -1,2 +1,2 @@ func a() {
+-old
++new
+@@ -10,2 +10,2 @@ func b() {
+-old
++new
+@@ -20,2 +20,2 @@ func c() {
+ context @@ not a header
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+`
+
+	counts := parseHunkCounts(diff)
+
+	want := map[string]int{"one.go": 1, "many.go": 3}
+	for path, n := range want {
+		if counts[path] != n {
+			t.Errorf("counts[%s] = %d, want %d", path, counts[path], n)
+		}
+	}
+
+	if _, ok := counts["logo.png"]; ok {
+		t.Errorf("binary file should have no hunks, got %d", counts["logo.png"])
+	}
+
+	files := []FileContent{{Path: "many.go"}, {Path: "logo.png"}}
+	annotateHunks(files, diff)
+
+	if files[0].Hunks != 3 || files[1].Hunks != 0 {
+		t.Errorf("annotateHunks() = %d, %d; want 3, 0", files[0].Hunks, files[1].Hunks)
+	}
+}
+
+func TestFilterAddedHunks(t *testing.T) {
+	diff := `diff --git a/mixed.go b/mixed.go
+index 1111111..2222222 100644
+--- a/mixed.go
++++ b/mixed.go
+@@ -1,3 +1,2 @@ func a() {
+ keep
+-removed only
+ keep
+@@ -10,2 +9,2 @@ func b() {
+-old
++new
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+index 3333333..0000000
+--- a/gone.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package gone
+-func x() {}
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000..4444444
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1 @@
++package new
+`
+
+	want := `diff --git a/mixed.go b/mixed.go
+index 1111111..2222222 100644
+--- a/mixed.go
++++ b/mixed.go
+@@ -10,2 +9,2 @@ func b() {
+-old
++new
+diff --git a/new.go b/new.go
+new file mode 100644
+index 0000000..4444444
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1 @@
++package new
+`
+
+	if got := filterAddedHunks(diff); got != want {
+		t.Errorf("filterAddedHunks() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := filterAddedHunks(""); got != "" {
+		t.Errorf("filterAddedHunks(\"\") = %q, want empty", got)
+	}
+}
+
+func TestFilterDiff(t *testing.T) {
+	diff := `diff --git a/api/handler.go b/api/handler.go
+index 1111111..2222222 100644
+--- a/api/handler.go
++++ b/api/handler.go
+@@ -1 +1 @@
+-old
++new
+diff --git a/web/app.js b/web/app.js
+index 3333333..4444444 100644
+--- a/web/app.js
++++ b/web/app.js
+@@ -1 +1 @@
+-a
++b
+diff --git a/web/old.go b/api/moved.go
+similarity index 100%
+rename from web/old.go
+rename to api/moved.go
+`
+
+	want := `diff --git a/api/handler.go b/api/handler.go
+index 1111111..2222222 100644
+--- a/api/handler.go
++++ b/api/handler.go
+@@ -1 +1 @@
+-old
++new
+diff --git a/web/old.go b/api/moved.go
+similarity index 100%
+rename from web/old.go
+rename to api/moved.go
+`
+
+	inAPI := func(path string) bool { return strings.HasPrefix(path, "api/") }
+
+	if got := FilterDiff(diff, inAPI); got != want {
+		t.Errorf("FilterDiff() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := FilterDiff(diff, func(string) bool { return true }); got != diff {
+		t.Errorf("FilterDiff(keep all) changed the diff:\n%s", got)
+	}
+}
@@ -0,0 +1,111 @@
+package context
+
+import (
+	"cmp"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// RankLanguages tallies the files' languages weighted by lines changed and
+// returns them from most to least changed, ties alphabetically. Files without
+// line counts (e.g. whole-file reviews) weigh their total lines instead.
+// Unrecognized files ("text") are not counted.
+func RankLanguages(files []FileContent) []string {
+	weights := make(map[string]int)
+
+	for _, f := range files {
+		if f.Language == "" || f.Language == "text" || f.IsBinary {
+			continue
+		}
+
+		weight := f.LinesAdded + f.LinesDeleted
+		if weight == 0 {
+			weight = f.LinesTotal
+		}
+
+		weights[f.Language] += max(weight, 1)
+	}
+
+	languages := slices.Collect(maps.Keys(weights))
+	slices.SortFunc(languages, func(a, b string) int {
+		if c := cmp.Compare(weights[b], weights[a]); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a, b)
+	})
+
+	return languages
+}
+
+// primaryLanguage returns the most changed language in files, or "" if none is recognized.
+func primaryLanguage(files []FileContent) string {
+	if languages := RankLanguages(files); len(languages) > 0 {
+		return languages[0]
+	}
+
+	return ""
+}
+
+// detectLanguage detects the programming language from file extension.
+func detectLanguage(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".go":
+		return "go"
+	case ".py":
+		return "python"
+	case ".js":
+		return "javascript"
+	case ".ts":
+		return "typescript"
+	case ".jsx":
+		return "jsx"
+	case ".tsx":
+		return "tsx"
+	case ".rs":
+		return "rust"
+	case ".java":
+		return "java"
+	case ".kt", ".kts":
+		return "kotlin"
+	case ".c":
+		return "c"
+	case ".cpp", ".cc", ".cxx":
+		return "cpp"
+	case ".h", ".hpp":
+		return "c-header"
+	case ".cs":
+		return "csharp"
+	case ".rb":
+		return "ruby"
+	case ".php":
+		return "php"
+	case ".swift":
+		return "swift"
+	case ".sh", ".bash":
+		return "shell"
+	case ".sql":
+		return "sql"
+	case ".html", ".htm":
+		return "html"
+	case ".css":
+		return "css"
+	case ".scss", ".sass":
+		return "scss"
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".xml":
+		return "xml"
+	case ".md", ".markdown":
+		return "markdown"
+	case ".proto":
+		return "protobuf"
+	default:
+		return "text"
+	}
+}
@@ -0,0 +1,112 @@
+package context
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRankLanguages(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []FileContent
+		want  []string
+	}{
+		{
+			name: "weighted by lines changed",
+			files: []FileContent{
+				{Path: "a.go", Language: "go", LinesAdded: 10},
+				{Path: "b.go", Language: "go", LinesAdded: 5, LinesDeleted: 5},
+				{Path: "app.ts", Language: "typescript", LinesAdded: 50},
+				{Path: "README", Language: "text", LinesAdded: 500},
+			},
+			want: []string{"typescript", "go"},
+		},
+		{
+			name: "ties sort alphabetically",
+			files: []FileContent{
+				{Path: "x.py", Language: "python", LinesAdded: 3},
+				{Path: "y.rb", Language: "ruby", LinesDeleted: 3},
+			},
+			want: []string{"python", "ruby"},
+		},
+		{
+			name: "whole files use total lines",
+			files: []FileContent{
+				{Path: "big.rs", Language: "rust", LinesTotal: 200},
+				{Path: "small.go", Language: "go", LinesTotal: 20},
+			},
+			want: []string{"rust", "go"},
+		},
+		{
+			name: "binary and unknown files ignored",
+			files: []FileContent{
+				{Path: "logo.png", Language: "text", IsBinary: true},
+				{Path: "notes", Language: "text", LinesAdded: 9},
+			},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RankLanguages(tt.files)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("RankLanguages() = %v, want %v", got, tt.want)
+			}
+
+			wantPrimary := ""
+			if len(tt.want) > 0 {
+				wantPrimary = tt.want[0]
+			}
+
+			if primary := primaryLanguage(tt.files); primary != wantPrimary {
+				t.Errorf("primaryLanguage() = %q, want %q", primary, wantPrimary)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"main.go", "go"},
+		{"script.py", "python"},
+		{"app.js", "javascript"},
+		{"component.tsx", "tsx"},
+		{"handler.ts", "typescript"},
+		{"lib.rs", "rust"},
+		{"Main.java", "java"},
+		{"app.kt", "kotlin"},
+		{"main.c", "c"},
+		{"util.cpp", "cpp"},
+		{"header.h", "c-header"},
+		{"Program.cs", "csharp"},
+		{"script.rb", "ruby"},
+		{"index.php", "php"},
+		{"App.swift", "swift"},
+		{"script.sh", "shell"},
+		{"query.sql", "sql"},
+		{"index.html", "html"},
+		{"style.css", "css"},
+		{"style.scss", "scss"},
+		{"config.json", "json"},
+		{"config.yaml", "yaml"},
+		{"config.yml", "yaml"},
+		{"doc.xml", "xml"},
+		{"README.md", "markdown"},
+		{"schema.proto", "protobuf"},
+		{"unknown.xyz", "text"},
+		{"Makefile", "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := detectLanguage(tt.path)
+			if got != tt.expected {
+				t.Errorf("detectLanguage(%q) = %q, want %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
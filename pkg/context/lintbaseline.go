@@ -0,0 +1,217 @@
+package context
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// lintKey identifies a linter finding independently of its line, so
+// findings that only moved aren't reported as new.
+type lintKey struct {
+	tool, file, rule, message string
+}
+
+// newLinterFindings returns the findings in current that aren't in
+// baseline. Findings are matched on tool, file, rule and message; each
+// baseline finding absorbs one matching current finding, so a second copy of
+// an existing issue is still new. renames maps new paths to their old paths
+// in baseline.
+func newLinterFindings(current, baseline []LinterFinding, renames map[string]string) []LinterFinding {
+	known := make(map[lintKey]int, len(baseline))
+	for _, f := range baseline {
+		known[lintKey{f.Tool, NormalizePath(f.File), f.RuleID, f.Message}]++
+	}
+
+	var fresh []LinterFinding
+
+	for _, f := range current {
+		file := NormalizePath(f.File)
+		if old, ok := renames[file]; ok {
+			file = old
+		}
+
+		key := lintKey{f.Tool, file, f.RuleID, f.Message}
+		if known[key] > 0 {
+			known[key]--
+
+			continue
+		}
+
+		fresh = append(fresh, f)
+	}
+
+	return fresh
+}
+
+// filterLintBaseline drops linter findings already present at the base
+// commit. The base commit's findings are read from opts.LintBaselineDir, or
+// computed by linting a temporary worktree of the base commit and stored
+// there for the next run.
+func filterLintBaseline(ctx context.Context, rc *ReviewContext, findings []LinterFinding, opts GatherOptions) ([]LinterFinding, error) {
+	base, err := revParse(ctx, rc.RepoPath, rc.BaseCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	files := baseFiles(rc.ChangedFiles)
+	path := filepath.Join(opts.LintBaselineDir, lintBaselineKey(base, files, opts)+".json")
+
+	baseline, err := loadLintBaseline(path)
+	if errors.Is(err, os.ErrNotExist) {
+		baseline, err = lintAtCommit(ctx, rc.RepoPath, base, files, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		err = saveLintBaseline(path, baseline)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	renames := make(map[string]string)
+
+	for _, f := range rc.ChangedFiles {
+		if f.IsRename() {
+			renames[f.Path] = f.OldPath
+		}
+	}
+
+	return newLinterFindings(relLinterFindings(rc.RepoPath, findings), baseline, renames), nil
+}
+
+// lintBaselineKey names the cached baseline for base. Besides the commit it
+// hashes the linter command, LintAll and the linted files, since each of
+// them changes the findings.
+func lintBaselineKey(base string, files []FileContent, opts GatherOptions) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%t\x00", opts.LinterCommand, opts.LintAll)
+
+	if !opts.LintAll {
+		paths := make([]string, len(files))
+		for i, f := range files {
+			paths[i] = f.Path
+		}
+
+		slices.Sort(paths)
+
+		for _, p := range paths {
+			fmt.Fprintf(h, "%s\x00", p)
+		}
+	}
+
+	return base + "-" + hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// baseFiles maps the changed files to their paths at the base commit,
+// dropping added files.
+func baseFiles(files []FileContent) []FileContent {
+	var old []FileContent
+
+	for _, f := range files {
+		switch {
+		case f.Status == "added":
+		case f.IsRename():
+			old = append(old, FileContent{Path: f.OldPath, Status: f.Status})
+		default:
+			old = append(old, f)
+		}
+	}
+
+	return old
+}
+
+// lintAtCommit runs the linter on a temporary worktree checked out at commit.
+// Without LintAll it lints files, the changed files as they were at commit.
+func lintAtCommit(ctx context.Context, repoPath, commit string, files []FileContent, opts GatherOptions) ([]LinterFinding, error) {
+	// Nothing to lint when every changed file is new
+	if len(files) == 0 && !opts.LintAll {
+		return nil, nil
+	}
+
+	var findings []LinterFinding
+
+	err := withWorktree(ctx, repoPath, commit, func(dir string) error {
+		found, err := runLinters(ctx, dir, files, opts)
+		if err != nil {
+			return fmt.Errorf("lint base commit: %w", err)
+		}
+
+		findings = relLinterFindings(dir, found)
+
+		return nil
+	})
+
+	return findings, err
+}
+
+// relLinterFindings rewrites absolute finding paths under root as
+// slash-separated paths relative to root.
+func relLinterFindings(root string, findings []LinterFinding) []LinterFinding {
+	out := make([]LinterFinding, len(findings))
+
+	for i, f := range findings {
+		if filepath.IsAbs(f.File) {
+			if rel, err := filepath.Rel(root, f.File); err == nil {
+				f.File = rel
+			}
+		}
+
+		f.File = NormalizePath(f.File)
+		out[i] = f
+	}
+
+	return out
+}
+
+// loadLintBaseline reads stored baseline findings.
+func loadLintBaseline(path string) ([]LinterFinding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []LinterFinding
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return nil, fmt.Errorf("parse lint baseline %s: %w", path, err)
+	}
+
+	return findings, nil
+}
+
+// saveLintBaseline stores baseline findings, replacing the file atomically.
+func saveLintBaseline(path string, findings []LinterFinding) error {
+	if findings == nil {
+		findings = []LinterFinding{}
+	}
+
+	data, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal lint baseline: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create lint baseline dir: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write lint baseline: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("write lint baseline: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package context
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+)
+
+func TestNewLinterFindings(t *testing.T) {
+	baseline := []LinterFinding{
+		{Tool: "vet", File: "a.go", Line: 10, Message: "unused x", RuleID: "unused"},
+		{Tool: "vet", File: "a.go", Line: 20, Message: "shadowed err", RuleID: "shadow"},
+		{Tool: "vet", File: "old.go", Line: 3, Message: "empty branch"},
+	}
+
+	current := []LinterFinding{
+		// Moved by an edit above it: still the same finding
+		{Tool: "vet", File: "a.go", Line: 14, Message: "unused x", RuleID: "unused"},
+		// A second copy of an existing issue is new
+		{Tool: "vet", File: "a.go", Line: 30, Message: "unused x", RuleID: "unused"},
+		// Same message from another rule is new
+		{Tool: "vet", File: "a.go", Line: 20, Message: "shadowed err", RuleID: "govet"},
+		// Followed through a rename
+		{Tool: "vet", File: "new.go", Line: 3, Message: "empty branch"},
+		{Tool: "vet", File: "b.go", Line: 1, Message: "unused y", RuleID: "unused"},
+	}
+
+	got := newLinterFindings(current, baseline, map[string]string{"new.go": "old.go"})
+
+	want := []int{30, 20, 1}
+	if len(got) != len(want) {
+		t.Fatalf("got %d new findings, want %d: %+v", len(got), len(want), got)
+	}
+
+	for i, line := range want {
+		if got[i].Line != line {
+			t.Errorf("got[%d] = %+v, want line %d", i, got[i], line)
+		}
+	}
+
+	if fresh := newLinterFindings(current, nil, nil); len(fresh) != len(current) {
+		t.Errorf("without a baseline got %d findings, want all %d", len(fresh), len(current))
+	}
+}
+
+func TestFilterLintBaseline(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	writeTestFile(t, dir, "a.go", "package a\n// TODO: old\n")
+	testutil.Git(t, dir, "add", "a.go")
+	testutil.Git(t, dir, "commit", "-q", "-m", "a")
+
+	// The working tree adds a second TODO above the existing one
+	writeTestFile(t, dir, "a.go", "package a\n// TODO: new\n// TODO: old\n")
+
+	script := writeTestFile(t, t.TempDir(), "lint.sh",
+		`awk 'BEGIN { printf "[" } /TODO/ { if (n++) printf ","; printf "{\"Tool\":\"todo\",\"File\":\"%s\",\"Line\":%d,\"Message\":\"%s\"}", FILENAME, FNR, $0 } END { print "]" }' "$@"`)
+
+	opts := GatherOptions{LinterCommand: "sh " + script, LintBaselineDir: filepath.Join(t.TempDir(), "lint-baselines")}
+	rc := &ReviewContext{
+		RepoPath:     dir,
+		BaseCommit:   "HEAD",
+		ChangedFiles: []FileContent{{Path: "a.go", Status: "modified"}},
+	}
+
+	current, err := runLinters(context.Background(), dir, rc.ChangedFiles, opts)
+	if err != nil {
+		t.Fatalf("runLinters() error = %v", err)
+	}
+
+	got, err := filterLintBaseline(context.Background(), rc, current, opts)
+	if err != nil {
+		t.Fatalf("filterLintBaseline() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0].Message != "// TODO: new" {
+		t.Fatalf("got %+v, want only the new TODO", got)
+	}
+
+	if out := testutil.Git(t, dir, "worktree", "list", "--porcelain"); strings.Count(out, "worktree ") != 1 {
+		t.Errorf("temporary worktree left behind: %s", out)
+	}
+
+	// The base findings are cached per commit and reused
+	cached, err := filepath.Glob(filepath.Join(opts.LintBaselineDir, "*.json"))
+	if err != nil || len(cached) != 1 {
+		t.Fatalf("cached baselines = %v (err %v), want one", cached, err)
+	}
+
+	if err := os.WriteFile(cached[0], []byte("[]"), 0o644); err != nil {
+		t.Fatalf("write baseline: %v", err)
+	}
+
+	got, err = filterLintBaseline(context.Background(), rc, current, opts)
+	if err != nil {
+		t.Fatalf("filterLintBaseline() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Errorf("got %d findings with an empty cached baseline, want 2", len(got))
+	}
+}
+
+func TestLintBaselineKey(t *testing.T) {
+	files := []FileContent{{Path: "b.go"}, {Path: "a.go"}}
+	opts := GatherOptions{LinterCommand: "golangci-lint run"}
+	key := lintBaselineKey("abc123", files, opts)
+
+	if !strings.HasPrefix(key, "abc123-") {
+		t.Errorf("lintBaselineKey() = %q, want the commit as prefix", key)
+	}
+
+	reordered := []FileContent{{Path: "a.go"}, {Path: "b.go"}}
+	if got := lintBaselineKey("abc123", reordered, opts); got != key {
+		t.Errorf("file order changed the key: %q != %q", got, key)
+	}
+
+	changed := map[string]string{
+		"commit":   lintBaselineKey("def456", files, opts),
+		"command":  lintBaselineKey("abc123", files, GatherOptions{LinterCommand: "eslint"}),
+		"files":    lintBaselineKey("abc123", files[:1], opts),
+		"lint all": lintBaselineKey("abc123", files, GatherOptions{LinterCommand: opts.LinterCommand, LintAll: true}),
+	}
+
+	for name, got := range changed {
+		if got == key {
+			t.Errorf("changing the %s kept key %q", name, key)
+		}
+	}
+
+	// With LintAll the file list doesn't matter
+	all := GatherOptions{LinterCommand: opts.LinterCommand, LintAll: true}
+	if lintBaselineKey("abc123", files, all) != lintBaselineKey("abc123", nil, all) {
+		t.Error("LintAll key depends on the changed files")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -24,6 +25,11 @@ type LinterOptions struct {
 
 	// All runs the linter on the entire repo, ignoring Files.
 	All bool
+
+	// Env holds extra environment variables (e.g. from --env). They are
+	// added to the linter's environment, so the shell expands $VAR in
+	// Command from them first, then from the process environment.
+	Env map[string]string
 }
 
 // RunLinter executes a user-provided linter command.
@@ -39,9 +45,15 @@ func RunLinter(ctx context.Context, opts LinterOptions) ([]LinterFinding, error)
 		cmdStr = cmdStr + " " + strings.Join(opts.Files, " ")
 	}
 
-	// Execute via shell for proper arg parsing
+	// Execute via shell for proper arg parsing. The shell also expands
+	// variables, so Env must be in cmd.Env; later entries win over os.Environ.
 	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
 	cmd.Dir = opts.RepoPath
+	cmd.Env = os.Environ()
+
+	for k, v := range opts.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -104,3 +116,27 @@ func parseOutput(stdout, stderr []byte) []LinterFinding {
 		Level:   "info",
 	}}
 }
+
+// runLinters runs the configured linter on changed files.
+func runLinters(ctx context.Context, repoPath string, files []FileContent, opts GatherOptions) ([]LinterFinding, error) {
+	if opts.LinterCommand == "" {
+		return nil, nil // No linter configured
+	}
+
+	// Extract file paths (skip deleted files)
+	var filePaths []string
+
+	for _, f := range files {
+		if f.Status != "deleted" {
+			filePaths = append(filePaths, f.Path)
+		}
+	}
+
+	return RunLinter(ctx, LinterOptions{
+		Command:  opts.LinterCommand,
+		RepoPath: repoPath,
+		Files:    filePaths,
+		All:      opts.LintAll,
+		Env:      opts.LinterEnv,
+	})
+}
@@ -32,6 +32,56 @@ func TestRunLinter_SuccessfulCommand(t *testing.T) {
 	}
 }
 
+func TestRunLinter_ExpandsEnv(t *testing.T) {
+	t.Setenv("CREAREVIEW_TEST_LEVEL", "warning")
+	t.Setenv("CREAREVIEW_TEST_MSG", "from the process env")
+
+	// --env wins over the process environment when the shell expands
+	findings, err := RunLinter(context.Background(), LinterOptions{
+		Command:  `echo "[{\"file\":\"a.go\",\"line\":1,\"message\":\"${CREAREVIEW_TEST_MSG}\",\"level\":\"$CREAREVIEW_TEST_LEVEL\"}]"`,
+		RepoPath: ".",
+		Env:      map[string]string{"CREAREVIEW_TEST_MSG": "from --env"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(findings) != 1 || findings[0].Message != "from --env" || findings[0].Level != "warning" {
+		t.Errorf("findings = %+v, want message from --env and level from the process env", findings)
+	}
+}
+
+func TestRunLinter_KeepsShellSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"positional parameter", `set -- a.go; echo "$1"`, "a.go"},
+		{"default value", `echo "${CREAREVIEW_TEST_UNSET:-fallback}"`, "fallback"},
+		{"exit status", `true; echo "status=$?"`, "status=0"},
+		{"single quotes", `echo '$HOME'`, "$HOME"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := RunLinter(context.Background(), LinterOptions{
+				Command:  tt.command,
+				RepoPath: ".",
+				All:      true,
+				Env:      map[string]string{"CREAREVIEW_TEST_MSG": "unused"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(findings) != 1 || findings[0].Message != tt.want {
+				t.Errorf("findings = %+v, want raw output %q", findings, tt.want)
+			}
+		})
+	}
+}
+
 func TestRunLinter_WithFiles(t *testing.T) {
 	// The command should have files appended
 	// We use echo to verify the files are passed
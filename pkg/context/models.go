@@ -0,0 +1,81 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModelEnvVar names the environment variable that selects the model
+// environment explicitly, overriding the CI detection of ModelEnvironment.
+const ModelEnvVar = "CREAREVIEW_ENV"
+
+// Model environments picked by ModelEnvironment when ModelEnvVar is unset.
+const (
+	// ModelEnvCI is the environment when the CI variable is set.
+	ModelEnvCI = "ci"
+
+	// ModelEnvLocal is the environment otherwise.
+	ModelEnvLocal = "local"
+)
+
+// ModelEnvironment returns the environment that selects a model from
+// Config.Models or --env-model: ModelEnvVar when set, else ModelEnvCI when
+// CI is set to anything but "false" or "0", else ModelEnvLocal. The result
+// is lowercase.
+func ModelEnvironment(getenv func(string) string) string {
+	if env := strings.TrimSpace(getenv(ModelEnvVar)); env != "" {
+		return strings.ToLower(env)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(getenv("CI"))) {
+	case "", "false", "0":
+		return ModelEnvLocal
+	default:
+		return ModelEnvCI
+	}
+}
+
+// ParseEnvModels parses an --env-model list such as
+// "CI=claude-opus,local=claude-haiku" into lowercase environments mapped to
+// models.
+func ParseEnvModels(spec string) (map[string]string, error) {
+	models := make(map[string]string)
+
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		env, model, ok := strings.Cut(entry, "=")
+		env, model = strings.TrimSpace(env), strings.TrimSpace(model)
+
+		if !ok || env == "" || model == "" {
+			return nil, fmt.Errorf("invalid env model %q: want environment=model", entry)
+		}
+
+		models[strings.ToLower(env)] = model
+	}
+
+	return models, nil
+}
+
+// ResolveModel picks the model for environment. An explicit override
+// (--model) wins, then flagModels (--env-model), then cfgModels (the
+// config's models). Environment keys match case-insensitively. Empty means
+// the backend's default model.
+func ResolveModel(override string, flagModels, cfgModels map[string]string, environment string) string {
+	if override != "" {
+		return override
+	}
+
+	for _, models := range []map[string]string{flagModels, cfgModels} {
+		for env, model := range models {
+			if strings.EqualFold(env, environment) && model != "" {
+				return model
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,102 @@
+package context
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModelEnvironment(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"nothing set", nil, ModelEnvLocal},
+		{"CI set", map[string]string{"CI": "true"}, ModelEnvCI},
+		{"CI false", map[string]string{"CI": "false"}, ModelEnvLocal},
+		{"CI zero", map[string]string{"CI": "0"}, ModelEnvLocal},
+		{"explicit wins over CI", map[string]string{"CI": "1", ModelEnvVar: "Staging"}, "staging"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+
+			if got := ModelEnvironment(getenv); got != tt.want {
+				t.Errorf("ModelEnvironment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEnvModels(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"", map[string]string{}, false},
+		{"CI=claude-opus, local=claude-haiku", map[string]string{"ci": "claude-opus", "local": "claude-haiku"}, false},
+		{"ci", nil, true},
+		{"ci=", nil, true},
+		{"=opus", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEnvModels(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEnvModels(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+
+			continue
+		}
+
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseEnvModels(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestResolveModel(t *testing.T) {
+	flagModels := map[string]string{"ci": "flag-ci"}
+	cfgModels := map[string]string{"CI": "cfg-ci", "local": "cfg-local"}
+
+	tests := []struct {
+		name        string
+		override    string
+		flagModels  map[string]string
+		cfgModels   map[string]string
+		environment string
+		want        string
+	}{
+		{"--model wins", "opus", flagModels, cfgModels, "ci", "opus"},
+		{"--env-model over config", "", flagModels, cfgModels, "ci", "flag-ci"},
+		{"config when flag has no entry", "", flagModels, cfgModels, "local", "cfg-local"},
+		{"config keys match case-insensitively", "", nil, cfgModels, "ci", "cfg-ci"},
+		{"unknown environment uses backend default", "", flagModels, cfgModels, "staging", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveModel(tt.override, tt.flagModels, tt.cfgModels, tt.environment)
+			if got != tt.want {
+				t.Errorf("ResolveModel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigModels(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, DefaultConfigFile, "models:\n  ci: claude-opus\n  local: claude-haiku\n")
+	writeTestFile(t, dir, "ci.yaml", "models:\n  ci: claude-sonnet\n")
+
+	cfg, err := LoadConfig(dir, []string{"ci.yaml"})
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := map[string]string{"ci": "claude-sonnet", "local": "claude-haiku"}
+	if !reflect.DeepEqual(cfg.Models, want) {
+		t.Errorf("Models = %v, want %v", cfg.Models, want)
+	}
+}
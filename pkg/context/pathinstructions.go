@@ -0,0 +1,53 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// PathInstruction is review guidance for the part of the code base matching
+// a path pattern, such as "check for destructive operations" for
+// migrations/.
+type PathInstruction struct {
+	// Path is a CODEOWNERS-style pattern: "migrations/" matches that
+	// directory at any depth, "db/migrations/" only at the repo root, and
+	// "*.sql" any SQL file.
+	Path string `yaml:"path"`
+
+	// Instructions are added to the prompt when a reviewed file matches Path.
+	Instructions string `yaml:"instructions"`
+}
+
+// MatchingPathInstructions returns the rules matching at least one of
+// files, in config order.
+func MatchingPathInstructions(rules []PathInstruction, files []string) []PathInstruction {
+	var matched []PathInstruction
+
+	for _, rule := range rules {
+		re := regexp.MustCompile(codeOwnersPattern(rule.Path))
+
+		for _, f := range files {
+			if re.MatchString(NormalizePath(f)) {
+				matched = append(matched, rule)
+
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+// validatePathInstructions checks that every rule has a path and instructions.
+func validatePathInstructions(rules []PathInstruction) error {
+	var errs []error
+
+	for i, rule := range rules {
+		if rule.Path == "" || rule.Instructions == "" {
+			errs = append(errs, fmt.Errorf("path_instructions[%d]: want both path and instructions", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
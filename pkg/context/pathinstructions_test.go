@@ -0,0 +1,59 @@
+package context
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMatchingPathInstructions(t *testing.T) {
+	rules := []PathInstruction{
+		{Path: "migrations/", Instructions: "Check for destructive operations."},
+		{Path: "/api/", Instructions: "Check backwards compatibility."},
+		{Path: "*.sql", Instructions: "Check for missing indexes."},
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{"nested migration", []string{"db/migrations/001.sql"}, []string{"migrations/", "*.sql"}},
+		{"anchored pattern only at root", []string{"internal/api/handler.go"}, nil},
+		{"root api", []string{"api/v1.go", "README.md"}, []string{"/api/"}},
+		{"no files", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, rule := range MatchingPathInstructions(rules, tt.files) {
+				got = append(got, rule.Path)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matched %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigPathInstructions(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, DefaultConfigFile, "path_instructions:\n  - path: migrations/\n    instructions: Check for destructive operations.\n")
+	writeTestFile(t, dir, "extra.yaml", "path_instructions:\n  - path: \"*.proto\"\n    instructions: Keep field numbers stable.\n")
+	writeTestFile(t, dir, "bad.yaml", "path_instructions:\n  - path: migrations/\n")
+
+	cfg, err := LoadConfig(dir, []string{"extra.yaml"})
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if len(cfg.PathInstructions) != 2 || cfg.PathInstructions[1].Path != "*.proto" {
+		t.Errorf("PathInstructions = %+v, want migrations/ then *.proto", cfg.PathInstructions)
+	}
+
+	if _, err := LoadConfig(dir, []string{"bad.yaml"}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("LoadConfig() with a rule missing instructions error = %v, want ErrInvalidConfig", err)
+	}
+}
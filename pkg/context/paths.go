@@ -0,0 +1,9 @@
+package context
+
+import "strings"
+
+// NormalizePath converts backslash separators to forward slashes so paths from
+// git, AI findings and the filesystem compare equal on every platform.
+func NormalizePath(path string) string {
+	return strings.ReplaceAll(path, `\`, "/")
+}
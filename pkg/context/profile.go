@@ -0,0 +1,46 @@
+package context
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Profile bundles the settings for one review intent, such as a security or
+// performance review, selected with --profile.
+type Profile struct {
+	// Instructions are appended to the config's instructions.
+	Instructions string `yaml:"instructions"`
+
+	// Categories limits reported findings to these categories.
+	// Empty keeps every category.
+	Categories []string `yaml:"categories"`
+
+	// Weights overrides priority scoring weights on top of the config's.
+	Weights WeightsConfig `yaml:"weights"`
+}
+
+// ApplyProfile merges the named profile into c: its instructions are
+// appended, its weights override the config's, and its categories become
+// the Focus. An empty name is a no-op.
+func (c *Config) ApplyProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		available := "none defined"
+		if len(c.Profiles) > 0 {
+			available = strings.Join(slices.Sorted(maps.Keys(c.Profiles)), ", ")
+		}
+
+		return fmt.Errorf("%w: unknown profile %q (available: %s)", ErrInvalidConfig, name, available)
+	}
+
+	c.merge(&Config{Instructions: p.Instructions, Weights: p.Weights})
+	c.Focus = slices.Clone(p.Categories)
+
+	return nil
+}
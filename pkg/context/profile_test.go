@@ -0,0 +1,69 @@
+package context
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestApplyProfile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, DefaultConfigFile, `
+instructions: Follow the team style guide.
+weights:
+  churn: 0.5
+  recency: 0.2
+profiles:
+  security:
+    instructions: Focus on authentication and input validation.
+    categories: [security, bug]
+    weights:
+      criticality: 0.9
+      churn: 0.1
+  performance:
+    instructions: Look for allocations in hot paths.
+`)
+
+	cfg, err := LoadConfig(dir, nil)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if err := cfg.ApplyProfile("security"); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+
+	want := "Follow the team style guide.\n\nFocus on authentication and input validation."
+	if cfg.Instructions != want {
+		t.Errorf("Instructions = %q, want %q", cfg.Instructions, want)
+	}
+	if !slices.Equal(cfg.Focus, []string{"security", "bug"}) {
+		t.Errorf("Focus = %v, want [security bug]", cfg.Focus)
+	}
+	if cfg.Weights.Criticality == nil || *cfg.Weights.Criticality != 0.9 {
+		t.Errorf("Weights.Criticality = %v, want 0.9", cfg.Weights.Criticality)
+	}
+	if cfg.Weights.Churn == nil || *cfg.Weights.Churn != 0.1 {
+		t.Errorf("Weights.Churn = %v, want profile override 0.1", cfg.Weights.Churn)
+	}
+	if cfg.Weights.Recency == nil || *cfg.Weights.Recency != 0.2 {
+		t.Errorf("Weights.Recency = %v, want config value 0.2", cfg.Weights.Recency)
+	}
+}
+
+func TestApplyProfileErrors(t *testing.T) {
+	cfg := &Config{Profiles: map[string]Profile{"security": {}, "performance": {}}}
+
+	err := cfg.ApplyProfile("secuirty")
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("ApplyProfile() error = %v, want ErrInvalidConfig", err)
+	}
+	if !strings.Contains(err.Error(), "performance, security") {
+		t.Errorf("error = %q, want available profiles listed", err)
+	}
+
+	if err := (&Config{}).ApplyProfile(""); err != nil {
+		t.Errorf("ApplyProfile(\"\") error = %v, want nil", err)
+	}
+}
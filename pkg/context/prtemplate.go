@@ -0,0 +1,53 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPRTemplatePaths are where GitHub looks for a pull request template,
+// relative to the repo root, in lookup order.
+var DefaultPRTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+}
+
+// loadPRTemplate reads the team's pull request template. A configured path
+// (relative to repoPath) must exist; otherwise the first of
+// DefaultPRTemplatePaths that exists is used. Returns an empty string when
+// no default template is found.
+func loadPRTemplate(repoPath, configured string) (string, error) {
+	if configured != "" {
+		if !filepath.IsAbs(configured) {
+			configured = filepath.Join(repoPath, configured)
+		}
+
+		data, err := os.ReadFile(configured)
+		if err != nil {
+			return "", fmt.Errorf("read pr template: %w", err)
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	for _, p := range DefaultPRTemplatePaths {
+		data, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(p)))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("read pr template: %w", err)
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	return "", nil
+}
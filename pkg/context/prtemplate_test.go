@@ -0,0 +1,51 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPRTemplate(t *testing.T) {
+	t.Run("default location", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, ".github"), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		writeTestFile(t, dir, ".github/pull_request_template.md", "- [ ] Tests added\n")
+
+		got, err := loadPRTemplate(dir, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "- [ ] Tests added" {
+			t.Errorf("template = %q, want %q", got, "- [ ] Tests added")
+		}
+	})
+
+	t.Run("configured path", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, dir, "checklist.md", "- [ ] Security reviewed")
+
+		got, err := loadPRTemplate(dir, "checklist.md")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "- [ ] Security reviewed" {
+			t.Errorf("template = %q, want %q", got, "- [ ] Security reviewed")
+		}
+	})
+
+	t.Run("missing configured path", func(t *testing.T) {
+		if _, err := loadPRTemplate(t.TempDir(), "missing.md"); err == nil {
+			t.Error("expected error for missing configured template")
+		}
+	})
+
+	t.Run("none found", func(t *testing.T) {
+		got, err := loadPRTemplate(t.TempDir(), "")
+		if err != nil || got != "" {
+			t.Errorf("loadPRTemplate() = %q, %v, want empty", got, err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package context
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RedactedText replaces each redacted match.
+const RedactedText = "***REDACTED***"
+
+// DefaultRedactPatterns match common secrets: private key blocks, well-known
+// token formats, and key/password/secret assignments.
+var DefaultRedactPatterns = []string{
+	`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`,
+	`(?i)\b(?:password|passwd|pwd|secret|api[_-]?key|access[_-]?token|auth[_-]?token)\s*[:=]\s*["']?[^\s"']{4,}["']?`,
+	`\bAKIA[0-9A-Z]{16}\b`,
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	`\bsk-[A-Za-z0-9_-]{20,}\b`,
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`,
+}
+
+// Redactor masks secrets in text using a set of regex patterns.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor compiles DefaultRedactPatterns followed by extra patterns.
+func NewRedactor(extra []string) (*Redactor, error) {
+	r := &Redactor{}
+
+	for _, pattern := range append(append([]string{}, DefaultRedactPatterns...), extra...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid redact pattern %q: %w", ErrInvalidConfig, pattern, err)
+		}
+
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// Redact replaces every match in s with RedactedText.
+// Returns the redacted text and the number of replacements.
+func (r *Redactor) Redact(s string) (string, int) {
+	count := 0
+
+	for _, re := range r.patterns {
+		s = re.ReplaceAllStringFunc(s, func(string) string {
+			count++
+
+			return RedactedText
+		})
+	}
+
+	return s, count
+}
+
+// Redact masks secrets in the diff and in embedded file contents, the parts
+// of the context that are sent to the model verbatim.
+// Returns the total number of replacements.
+func (rc *ReviewContext) Redact(r *Redactor) int {
+	var total, n int
+
+	rc.Diff, n = r.Redact(rc.Diff)
+	total += n
+
+	for _, files := range [][]FileContent{rc.ChangedFiles, rc.RelatedFiles} {
+		for i := range files {
+			files[i].Content, n = r.Redact(files[i].Content)
+			total += n
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,28 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// ErrGitNotFound indicates the git executable isn't on PATH.
+var ErrGitNotFound = errors.New("git executable not found in PATH; install git or add it to PATH")
+
+// RepoRoot returns the root of the repository containing path. It returns
+// ErrGitNotFound when git isn't installed, so callers don't misreport a
+// missing binary as git.ErrNotARepository.
+func RepoRoot(ctx context.Context, path string) (string, error) {
+	root, err := git.RepoRoot(ctx, path)
+	if err == nil {
+		return root, nil
+	}
+
+	if _, lookErr := exec.LookPath("git"); lookErr != nil {
+		return "", ErrGitNotFound
+	}
+
+	return "", err
+}
@@ -0,0 +1,47 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+	"github.com/crealfy/crea-review/internal/testutil"
+)
+
+func TestRepoRoot(t *testing.T) {
+	t.Run("git missing", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		_, err := RepoRoot(context.Background(), t.TempDir())
+		if !errors.Is(err, ErrGitNotFound) {
+			t.Errorf("error = %v, want ErrGitNotFound", err)
+		}
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not available")
+		}
+
+		dir := t.TempDir()
+
+		_, err := RepoRoot(context.Background(), dir)
+		if !errors.Is(err, git.ErrNotARepository) {
+			t.Errorf("error = %v, want git.ErrNotARepository", err)
+		}
+	})
+
+	t.Run("repository", func(t *testing.T) {
+		dir := testutil.GitRepo(t)
+
+		root, err := RepoRoot(context.Background(), dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if root == "" {
+			t.Error("root is empty")
+		}
+	})
+}
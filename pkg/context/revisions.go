@@ -0,0 +1,202 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// ErrNoCommits indicates a repository without any commits to diff against.
+var ErrNoCommits = errors.New("repository has no commits")
+
+// ErrUnknownRef indicates a base or head ref that doesn't resolve to a commit.
+var ErrUnknownRef = errors.New("unknown ref")
+
+// revParse resolves rev to a commit ID.
+func revParse(ctx context.Context, repoPath, rev string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", ErrUnknownRef, rev, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// sameCommit reports whether base and head resolve to the same commit.
+// Refs that don't resolve are never the same; the diff reports those.
+func sameCommit(ctx context.Context, repoPath, base, head string) bool {
+	baseID, err := revParse(ctx, repoPath, base)
+	if err != nil {
+		return false
+	}
+
+	headID, err := revParse(ctx, repoPath, head)
+	if err != nil {
+		return false
+	}
+
+	return baseID == headID
+}
+
+// verifyRefs checks that the user-supplied base and head refs resolve to commits.
+// Any ref git understands is accepted: branches, remote-tracking branches
+// such as origin/main, tags, SHAs and expressions like HEAD~3.
+func verifyRefs(ctx context.Context, repoPath string, opts GatherOptions) error {
+	refs := []struct{ flag, ref string }{
+		{"--base", opts.BaseBranch},
+		{"--base-commit", opts.BaseCommit},
+	}
+
+	// HeadCommit only applies with an explicit base (see resolveCommits)
+	if opts.BaseBranch != "" || opts.BaseCommit != "" {
+		refs = append(refs, struct{ flag, ref string }{"--head-commit", opts.HeadCommit})
+	}
+
+	for _, r := range refs {
+		if r.ref == "" {
+			continue
+		}
+
+		if _, err := revParse(ctx, repoPath, r.ref); err == nil {
+			continue
+		}
+
+		return fmt.Errorf("%w: %w %q for %s%s", ErrInvalidConfig, ErrUnknownRef, r.ref, r.flag,
+			fetchHint(ctx, repoPath, r.ref))
+	}
+
+	return nil
+}
+
+// fetchHint suggests a fetch when ref names a branch of a configured remote,
+// e.g. upstream/main. Refs are only resolved locally, so a remote-tracking
+// branch must have been fetched first. Returns "" for other refs.
+func fetchHint(ctx context.Context, repoPath, ref string) string {
+	remotes, err := git.Remotes(ctx, repoPath)
+	if err != nil {
+		return ""
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(ref, "refs/"), "remotes/")
+
+	var remote string
+
+	// Remote names may contain "/", so prefer the longest match
+	for _, r := range remotes {
+		if strings.HasPrefix(name, r+"/") && len(r) > len(remote) {
+			remote = r
+		}
+	}
+
+	branch := strings.TrimPrefix(name, remote+"/")
+	if remote == "" || branch == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" (not fetched yet? run \"git fetch %s %s\")", remote, branch)
+}
+
+// emptyTree returns the ID of the empty tree in the repository's hash format,
+// used as the base when there is no commit to compare against.
+func emptyTree(ctx context.Context, repoPath string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "hash-object", "-t", "tree", "--stdin")
+	cmd.Stdin = strings.NewReader("")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: hash empty tree: %w", ErrNoCommits, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// resolveCommits determines the base and head commits based on options.
+// Precedence: BaseCommit, then BaseBranch, then ReviewType. HeadCommit applies
+// to the first two; an empty HeadCommit means the working tree with BaseCommit
+// and HEAD with BaseBranch.
+func resolveCommits(ctx context.Context, rc *ReviewContext, opts GatherOptions) error {
+	// If base commit is specified, use it
+	if opts.BaseCommit != "" {
+		rc.BaseCommit = opts.BaseCommit
+		rc.HeadCommit = opts.HeadCommit
+
+		return nil
+	}
+
+	// If base branch is specified, find merge base
+	if opts.BaseBranch != "" {
+		rc.BaseBranch = opts.BaseBranch
+
+		head := opts.HeadCommit
+		if head == "" {
+			var err error
+
+			head, err = git.HEAD(ctx, rc.RepoPath)
+			if err != nil {
+				return fmt.Errorf("get HEAD: %w", err)
+			}
+		}
+		rc.HeadCommit = head
+
+		// For branch comparison, we'll use the branch name directly
+		// The Diff function handles this
+		rc.BaseCommit = opts.BaseBranch
+
+		return nil
+	}
+
+	// Handle review types
+	switch opts.ReviewType {
+	case "uncommitted":
+		// Compare against HEAD for uncommitted changes
+		rc.BaseCommit = "HEAD"
+		rc.HeadCommit = "" // Empty means working directory
+	case "committed":
+		// Compare HEAD against its parent
+		rc.BaseCommit = "HEAD~1"
+		rc.HeadCommit = "HEAD"
+	default: // "all"
+		// Compare against HEAD (shows all uncommitted changes)
+		rc.BaseCommit = "HEAD"
+		rc.HeadCommit = ""
+	}
+
+	return nil
+}
+
+// resolveInitialCommit swaps a base that doesn't exist yet for the empty tree:
+// HEAD in a repo with no commits, or HEAD~1 when HEAD is the first commit.
+// This makes staged files in a fresh repo and the first commit reviewable.
+func resolveInitialCommit(ctx context.Context, rc *ReviewContext) error {
+	switch rc.BaseCommit {
+	case "HEAD":
+		if _, err := git.HEAD(ctx, rc.RepoPath); err == nil {
+			return nil
+		}
+	case "HEAD~1":
+		if _, err := git.HEAD(ctx, rc.RepoPath); err != nil {
+			return fmt.Errorf("%w: make a commit or review staged files with -t uncommitted", ErrNoCommits)
+		}
+
+		if _, err := revParse(ctx, rc.RepoPath, "HEAD~1"); err == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	tree, err := emptyTree(ctx, rc.RepoPath)
+	if err != nil {
+		return err
+	}
+
+	rc.BaseCommit = tree
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package context
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+)
+
+// emptyTreeSHA1 is the empty tree ID in SHA-1 repositories.
+const emptyTreeSHA1 = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+func TestResolveInitialCommit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no commits diffs against empty tree", func(t *testing.T) {
+		rc := &ReviewContext{RepoPath: testutil.GitRepo(t), BaseCommit: "HEAD"}
+
+		if err := resolveInitialCommit(ctx, rc); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rc.BaseCommit != emptyTreeSHA1 {
+			t.Errorf("BaseCommit = %q, want empty tree", rc.BaseCommit)
+		}
+	})
+
+	t.Run("committed review without commits", func(t *testing.T) {
+		rc := &ReviewContext{RepoPath: testutil.GitRepo(t), BaseCommit: "HEAD~1", HeadCommit: "HEAD"}
+
+		if err := resolveInitialCommit(ctx, rc); !errors.Is(err, ErrNoCommits) {
+			t.Errorf("error = %v, want ErrNoCommits", err)
+		}
+	})
+
+	t.Run("first commit diffs against empty tree", func(t *testing.T) {
+		dir := testutil.GitRepo(t)
+		testutil.CommitFile(t, dir, "main.go")
+
+		rc := &ReviewContext{RepoPath: dir, BaseCommit: "HEAD~1", HeadCommit: "HEAD"}
+		if err := resolveInitialCommit(ctx, rc); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rc.BaseCommit != emptyTreeSHA1 {
+			t.Errorf("BaseCommit = %q, want empty tree", rc.BaseCommit)
+		}
+	})
+
+	t.Run("existing parent is kept", func(t *testing.T) {
+		dir := testutil.GitRepo(t)
+		testutil.CommitFile(t, dir, "a.go")
+		testutil.CommitFile(t, dir, "b.go")
+
+		for _, base := range []string{"HEAD", "HEAD~1", "main"} {
+			rc := &ReviewContext{RepoPath: dir, BaseCommit: base}
+			if err := resolveInitialCommit(ctx, rc); err != nil {
+				t.Fatalf("%s: unexpected error: %v", base, err)
+			}
+			if rc.BaseCommit != base {
+				t.Errorf("BaseCommit = %q, want %q", rc.BaseCommit, base)
+			}
+		}
+	})
+}
+
+func TestGatherNoCommits(t *testing.T) {
+	dir := testutil.GitRepo(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("failed to write main.go: %v", err)
+	}
+	testutil.Git(t, dir, "add", "main.go")
+
+	rc, err := Gather(context.Background(), dir, DefaultGatherOptions())
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	if len(rc.ChangedFiles) != 1 || rc.ChangedFiles[0].Path != "main.go" {
+		t.Errorf("ChangedFiles = %+v, want main.go", rc.ChangedFiles)
+	}
+}
+
+func TestGatherBaseRefs(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	testutil.CommitFile(t, dir, "a.go")
+	testutil.Git(t, dir, "tag", "-a", "v1.0.0", "-m", "release")
+	testutil.Git(t, dir, "update-ref", "refs/remotes/origin/main", "HEAD")
+	testutil.CommitFile(t, dir, "b.go")
+
+	tests := []struct {
+		name string
+		opts GatherOptions
+	}{
+		{"remote-tracking branch", GatherOptions{BaseBranch: "origin/main"}},
+		{"annotated tag", GatherOptions{BaseBranch: "v1.0.0"}},
+		{"tag as base commit", GatherOptions{BaseCommit: "v1.0.0", HeadCommit: "HEAD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := Gather(context.Background(), dir, tt.opts)
+			if err != nil {
+				t.Fatalf("Gather() error = %v", err)
+			}
+
+			if len(rc.ChangedFiles) != 1 || rc.ChangedFiles[0].Path != "b.go" {
+				t.Errorf("ChangedFiles = %+v, want only b.go", rc.ChangedFiles)
+			}
+		})
+	}
+
+	unknown := []GatherOptions{
+		{BaseBranch: "origin/mian"},
+		{BaseCommit: "v9.9.9"},
+		{BaseCommit: "HEAD~1", HeadCommit: "nope"},
+	}
+
+	for _, opts := range unknown {
+		_, err := Gather(context.Background(), dir, opts)
+		if !errors.Is(err, ErrUnknownRef) || !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("Gather(%+v) error = %v, want ErrUnknownRef", opts, err)
+		}
+	}
+}
+
+func TestGatherUpstreamBase(t *testing.T) {
+	upstream := testutil.GitRepo(t)
+	testutil.CommitFile(t, upstream, "a.go")
+	testutil.Git(t, upstream, "branch", "-M", "main")
+
+	dir := testutil.GitRepo(t)
+	testutil.Git(t, dir, "remote", "add", "upstream", upstream)
+	testutil.Git(t, dir, "fetch", "-q", "upstream")
+	testutil.Git(t, dir, "checkout", "-q", "-b", "feature", "upstream/main")
+	testutil.CommitFile(t, dir, "b.go")
+
+	for _, base := range []string{"upstream/main", "refs/remotes/upstream/main"} {
+		rc, err := Gather(context.Background(), dir, GatherOptions{BaseBranch: base})
+		if err != nil {
+			t.Fatalf("Gather(%s) error = %v", base, err)
+		}
+
+		if len(rc.ChangedFiles) != 1 || rc.ChangedFiles[0].Path != "b.go" {
+			t.Errorf("Gather(%s) ChangedFiles = %+v, want only b.go", base, rc.ChangedFiles)
+		}
+	}
+
+	_, err := Gather(context.Background(), dir, GatherOptions{BaseBranch: "upstream/release"})
+	if !errors.Is(err, ErrUnknownRef) {
+		t.Fatalf("Gather(upstream/release) error = %v, want ErrUnknownRef", err)
+	}
+
+	if !strings.Contains(err.Error(), `git fetch upstream release`) {
+		t.Errorf("error = %v, want a fetch suggestion", err)
+	}
+}
+
+func TestFetchHint(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	testutil.Git(t, dir, "remote", "add", "upstream", "https://example.com/upstream.git")
+	testutil.Git(t, dir, "remote", "add", "team/fork", "https://example.com/fork.git")
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"upstream/main", "git fetch upstream main"},
+		{"refs/remotes/upstream/main", "git fetch upstream main"},
+		{"team/fork/dev", "git fetch team/fork dev"},
+		{"feature/login", ""},
+		{"upstream", ""},
+	}
+
+	for _, tt := range tests {
+		got := fetchHint(context.Background(), dir, tt.ref)
+		if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+			t.Errorf("fetchHint(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestGatherSameCommit(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	testutil.CommitFile(t, dir, "a.go")
+	testutil.CommitFile(t, dir, "b.go")
+
+	head, err := revParse(context.Background(), dir, "HEAD")
+	if err != nil {
+		t.Fatalf("revParse() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts GatherOptions
+		same bool
+	}{
+		{"same SHA twice", GatherOptions{BaseCommit: head, HeadCommit: head}, true},
+		{"SHA and HEAD", GatherOptions{BaseCommit: head, HeadCommit: "HEAD"}, true},
+		{"different commits", GatherOptions{BaseCommit: "HEAD~1", HeadCommit: head}, false},
+		{"working tree", GatherOptions{ReviewType: "uncommitted"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := Gather(context.Background(), dir, tt.opts)
+			if err != nil {
+				t.Fatalf("Gather() error = %v", err)
+			}
+
+			if rc.SameCommit != tt.same {
+				t.Errorf("SameCommit = %v, want %v", rc.SameCommit, tt.same)
+			}
+
+			if tt.same && len(rc.ChangedFiles) != 0 {
+				t.Errorf("ChangedFiles = %+v, want none", rc.ChangedFiles)
+			}
+		})
+	}
+}
+
+func TestLogCommits(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	testutil.CommitFile(t, dir, "a.go")
+	testutil.CommitFile(t, dir, "b.go")
+	testutil.CommitFile(t, dir, "c.go")
+
+	ctx := context.Background()
+
+	commits, err := logCommits(ctx, dir, "HEAD~2", "")
+	if err != nil {
+		t.Fatalf("logCommits() error = %v", err)
+	}
+
+	if len(commits) != 2 || commits[0].Subject != "c.go" || commits[1].Subject != "b.go" {
+		t.Errorf("logCommits(HEAD~2..HEAD) = %+v, want c.go then b.go", commits)
+	}
+
+	if commits[0].Hash == "" || commits[0].Body != "" {
+		t.Errorf("commits[0] = %+v, want a hash and no body", commits[0])
+	}
+
+	// The empty tree base of an initial commit includes all history
+	commits, err = logCommits(ctx, dir, emptyTreeSHA1, "HEAD")
+	if err != nil {
+		t.Fatalf("logCommits(empty tree) error = %v", err)
+	}
+
+	if len(commits) != 3 {
+		t.Errorf("logCommits(empty tree) = %d commits, want 3", len(commits))
+	}
+
+	commits, err = logCommits(ctx, dir, "HEAD", "")
+	if err != nil || len(commits) != 0 {
+		t.Errorf("logCommits(HEAD..HEAD) = %+v, %v; want none", commits, err)
+	}
+}
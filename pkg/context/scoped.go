@@ -0,0 +1,206 @@
+package context
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ContentMode selects how much of each changed file is embedded in the prompt.
+type ContentMode string
+
+// Content modes.
+const (
+	// ContentNone embeds no file content; the reviewer reads files itself.
+	ContentNone ContentMode = "none"
+
+	// ContentDiff embeds the changed regions plus ContentContext lines around them.
+	ContentDiff ContentMode = "diff"
+
+	// ContentFull embeds whole files, subject to the line and byte caps.
+	ContentFull ContentMode = "full"
+)
+
+// DefaultContentContext is the number of lines kept around each changed
+// region with ContentDiff.
+const DefaultContentContext = 10
+
+// ParseContentMode validates a --content value. Empty means ContentNone.
+func ParseContentMode(s string) (ContentMode, error) {
+	switch mode := ContentMode(s); mode {
+	case "":
+		return ContentNone, nil
+	case ContentNone, ContentDiff, ContentFull:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid content mode %q (want none, diff or full)", s)
+	}
+}
+
+// lineRange is an inclusive range of 1-based line numbers. A range with
+// end < start marks a point between lines, such as where lines were deleted.
+type lineRange struct {
+	start, end int
+}
+
+// parseHunkRanges returns the new-file line range of each hunk in a unified
+// diff, keyed by path.
+func parseHunkRanges(diff string) map[string][]lineRange {
+	ranges := make(map[string][]lineRange)
+
+	var path string
+
+	for line := range strings.SplitSeq(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			path = diffHeaderPath(line)
+		case strings.HasPrefix(line, "@@ ") && path != "":
+			if r, ok := parseHunkHeader(line); ok {
+				ranges[path] = append(ranges[path], r)
+			}
+		}
+	}
+
+	return ranges
+}
+
+// parseHunkHeader reads the new-file side of "@@ -a,b +c,d @@". A missing
+// count means one line.
+func parseHunkHeader(line string) (lineRange, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return lineRange{}, false
+	}
+
+	startText, countText, hasCount := strings.Cut(fields[2][1:], ",")
+
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return lineRange{}, false
+	}
+
+	count := 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return lineRange{}, false
+		}
+	}
+
+	// A pure deletion reports the line before it ("+c,0"); its point is after c
+	if count == 0 {
+		return lineRange{start: start + 1, end: start}, true
+	}
+
+	return lineRange{start: start, end: start + count - 1}, true
+}
+
+// scopeContent keeps the lines of content within contextLines of a changed
+// range, each prefixed with its line number, and replaces the rest with
+// "... (lines X-Y unchanged)" markers. Overlapping regions are merged.
+func scopeContent(content string, ranges []lineRange, contextLines int) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	total := len(lines)
+
+	var regions []lineRange
+
+	for _, r := range ranges {
+		start, end := max(r.start-contextLines, 1), min(r.end+contextLines, total)
+		if start > end {
+			continue
+		}
+
+		if n := len(regions); n > 0 && start <= regions[n-1].end+1 {
+			regions[n-1].end = max(regions[n-1].end, end)
+
+			continue
+		}
+
+		regions = append(regions, lineRange{start, end})
+	}
+
+	var sb strings.Builder
+
+	width := len(strconv.Itoa(total))
+	next := 1
+
+	for _, r := range regions {
+		writeGap(&sb, next, r.start-1)
+
+		for n := r.start; n <= r.end; n++ {
+			fmt.Fprintf(&sb, "%*d  %s\n", width, n, lines[n-1])
+		}
+
+		next = r.end + 1
+	}
+
+	writeGap(&sb, next, total)
+
+	return sb.String()
+}
+
+// writeGap writes the marker for omitted lines from..to, if any.
+func writeGap(sb *strings.Builder, from, to int) {
+	switch {
+	case to < from:
+	case from == to:
+		fmt.Fprintf(sb, "... (line %d unchanged)\n", from)
+	default:
+		fmt.Fprintf(sb, "... (lines %d-%d unchanged)\n", from, to)
+	}
+}
+
+// readChangedContents reads the changed files' content per opts.Content, as
+// of head: from the working tree when head is empty, otherwise from a
+// temporary worktree checked out at head, so the content matches the diff.
+// ContentDiff reads whole files without truncation, so line numbers stay
+// right, then scopes them to the regions diff changed.
+func readChangedContents(ctx context.Context, root, head string, files []FileContent, diff string, opts GatherOptions) error {
+	if head != "" {
+		return withWorktree(ctx, root, head, func(dir string) error {
+			return readChangedContents(ctx, dir, "", files, diff, opts)
+		})
+	}
+
+	if opts.Content != ContentDiff {
+		return readContents(ctx, root, files, opts)
+	}
+
+	whole := opts
+	whole.NoTruncate, whole.MaxFileBytes = true, 0
+
+	if err := readContents(ctx, root, files, whole); err != nil {
+		return err
+	}
+
+	scopeContents(files, diff, opts)
+
+	return nil
+}
+
+// scopeContents replaces each file's full Content with the regions the diff
+// changed, plus opts.ContentContext lines around them, then applies
+// opts.MaxFileBytes. Files the diff has no hunks for are left without content.
+func scopeContents(files []FileContent, diff string, opts GatherOptions) {
+	ranges := parseHunkRanges(diff)
+
+	for i := range files {
+		f := &files[i]
+		if f.Content == "" {
+			continue
+		}
+
+		fileRanges := ranges[f.Path]
+		if len(fileRanges) == 0 {
+			f.Content = ""
+
+			continue
+		}
+
+		var capped bool
+
+		f.Content, capped = capContent(scopeContent(f.Content, fileRanges, opts.ContentContext), 0, opts.MaxFileBytes)
+		f.Truncated = f.Truncated || capped
+		f.Scoped = true
+	}
+}
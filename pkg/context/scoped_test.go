@@ -0,0 +1,205 @@
+package context
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+)
+
+func TestParseContentMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ContentMode
+		wantErr bool
+	}{
+		{"", ContentNone, false},
+		{"none", ContentNone, false},
+		{"diff", ContentDiff, false},
+		{"full", ContentFull, false},
+		{"hunks", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseContentMode(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseContentMode(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseHunkRanges(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -3,2 +3,3 @@ func main() {
+ a
++b
+ c
+@@ -20 +21 @@
+-old
++new
+diff --git a/gone.go b/gone.go
+@@ -5,2 +4,0 @@
+-x
+-y
+`
+
+	want := map[string][]lineRange{
+		"main.go": {{3, 5}, {21, 21}},
+		"gone.go": {{5, 4}},
+	}
+
+	if got := parseHunkRanges(diff); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseHunkRanges() = %v, want %v", got, want)
+	}
+}
+
+func TestScopeContent(t *testing.T) {
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = "line" + strings.Repeat("x", i%3)
+	}
+
+	content := strings.Join(lines, "\n") + "\n"
+
+	tests := []struct {
+		name    string
+		ranges  []lineRange
+		context int
+		want    string
+	}{
+		{
+			name:    "one region with context",
+			ranges:  []lineRange{{10, 10}},
+			context: 2,
+			want: `... (lines 1-7 unchanged)
+ 8  linex
+ 9  linexx
+10  line
+11  linex
+12  linexx
+... (lines 13-30 unchanged)
+`,
+		},
+		{
+			name:    "nearby regions merge",
+			ranges:  []lineRange{{3, 3}, {6, 6}},
+			context: 1,
+			want: `... (line 1 unchanged)
+ 2  linex
+ 3  linexx
+ 4  line
+ 5  linex
+ 6  linexx
+ 7  line
+... (lines 8-30 unchanged)
+`,
+		},
+		{
+			name:    "deletion point clipped at the end",
+			ranges:  []lineRange{{31, 30}},
+			context: 2,
+			want: `... (lines 1-28 unchanged)
+29  linex
+30  linexx
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scopeContent(content, tt.ranges, tt.context); got != tt.want {
+				t.Errorf("scopeContent() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGatherContentDiff(t *testing.T) {
+	dir := testutil.GitRepo(t)
+
+	var lines []string
+	for i := range 40 {
+		lines = append(lines, "line "+string(rune('a'+i%26)))
+	}
+
+	writeTestFile(t, dir, "app.txt", strings.Join(lines, "\n")+"\n")
+	testutil.Git(t, dir, "add", "app.txt")
+	testutil.Git(t, dir, "commit", "-q", "-m", "initial")
+
+	lines[19] = "changed line"
+	writeTestFile(t, dir, "app.txt", strings.Join(lines, "\n")+"\n")
+
+	opts := DefaultGatherOptions()
+	opts.ReviewType = "uncommitted"
+	opts.Content = ContentDiff
+	opts.ContentContext = 1
+
+	rc, err := Gather(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	if len(rc.ChangedFiles) != 1 {
+		t.Fatalf("ChangedFiles = %d, want 1", len(rc.ChangedFiles))
+	}
+
+	f := rc.ChangedFiles[0]
+
+	// git's own 3 lines of diff context plus 1 more on each side
+	want := `... (lines 1-15 unchanged)
+16  line p
+17  line q
+18  line r
+19  line s
+20  changed line
+21  line u
+22  line v
+23  line w
+24  line x
+... (lines 25-40 unchanged)
+`
+	if !f.Scoped || f.Content != want {
+		t.Errorf("Scoped = %v, Content =\n%s\nwant\n%s", f.Scoped, f.Content, want)
+	}
+}
+
+func TestGatherContentAtHeadCommit(t *testing.T) {
+	dir := testutil.GitRepo(t)
+
+	writeTestFile(t, dir, "app.txt", "v1\n")
+	testutil.Git(t, dir, "add", "app.txt")
+	testutil.Git(t, dir, "commit", "-q", "-m", "v1")
+
+	writeTestFile(t, dir, "app.txt", "v2\n")
+	testutil.Git(t, dir, "commit", "-q", "-am", "v2")
+
+	// Uncommitted edits must not leak into a review of HEAD~1..HEAD
+	writeTestFile(t, dir, "app.txt", "v3 uncommitted\n")
+
+	for _, mode := range []ContentMode{ContentFull, ContentDiff} {
+		t.Run(string(mode), func(t *testing.T) {
+			opts := DefaultGatherOptions()
+			opts.ReviewType = "committed"
+			opts.Content = mode
+
+			rc, err := Gather(context.Background(), dir, opts)
+			if err != nil {
+				t.Fatalf("Gather() error = %v", err)
+			}
+
+			if len(rc.ChangedFiles) != 1 || !strings.Contains(rc.ChangedFiles[0].Content, "v2") ||
+				strings.Contains(rc.ChangedFiles[0].Content, "v3") {
+				t.Errorf("ChangedFiles = %+v, want the content at HEAD", rc.ChangedFiles)
+			}
+		})
+	}
+
+	if out := testutil.Git(t, dir, "worktree", "list", "--porcelain"); strings.Count(out, "worktree ") != 1 {
+		t.Errorf("temporary worktree left behind: %s", out)
+	}
+}
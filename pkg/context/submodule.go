@@ -0,0 +1,105 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gitlinkMode is the git file mode for submodule (gitlink) entries.
+const gitlinkMode = "160000"
+
+// SubmoduleChange is a submodule pointer update found in a diff.
+type SubmoduleChange struct {
+	// OldSHA is the previous submodule commit (empty when added).
+	OldSHA string
+
+	// NewSHA is the new submodule commit (empty when removed).
+	NewSHA string
+}
+
+// String returns the change as "old → new" with short SHAs.
+func (c SubmoduleChange) String() string {
+	return fmt.Sprintf("submodule %s → %s", shortSHA(c.OldSHA), shortSHA(c.NewSHA))
+}
+
+// parseSubmoduleChanges finds gitlink entries in a unified diff, keyed by path.
+// Git shows these as a one-line "Subproject commit <sha>" change with mode 160000.
+func parseSubmoduleChanges(diff string) map[string]SubmoduleChange {
+	changes := make(map[string]SubmoduleChange)
+
+	var (
+		path      string
+		change    SubmoduleChange
+		isGitlink bool
+	)
+
+	flush := func() {
+		if path != "" && isGitlink {
+			changes[path] = change
+		}
+	}
+
+	for line := range strings.SplitSeq(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+
+			path, change, isGitlink = diffHeaderPath(line), SubmoduleChange{}, false
+		case strings.HasPrefix(line, "index ") && strings.HasSuffix(line, " "+gitlinkMode),
+			strings.HasPrefix(line, "new file mode "+gitlinkMode),
+			strings.HasPrefix(line, "deleted file mode "+gitlinkMode):
+			isGitlink = true
+		case strings.HasPrefix(line, "-Subproject commit "):
+			change.OldSHA = strings.TrimPrefix(line, "-Subproject commit ")
+			isGitlink = true
+		case strings.HasPrefix(line, "+Subproject commit "):
+			change.NewSHA = strings.TrimPrefix(line, "+Subproject commit ")
+			isGitlink = true
+		}
+	}
+
+	flush()
+
+	return changes
+}
+
+// diffHeaderPath extracts the new path from a "diff --git a/x b/y" header.
+func diffHeaderPath(header string) string {
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		return header[i+len(" b/"):]
+	}
+
+	return ""
+}
+
+// annotateSubmodules marks changed files that are submodule pointer updates.
+func annotateSubmodules(files []FileContent, diff string) {
+	changes := parseSubmoduleChanges(diff)
+	if len(changes) == 0 {
+		return
+	}
+
+	for i := range files {
+		change, ok := changes[files[i].Path]
+		if !ok {
+			continue
+		}
+
+		files[i].IsSubmodule = true
+		files[i].Note = change.String()
+		files[i].Language = "text"
+	}
+}
+
+// shortSHA abbreviates a commit SHA for display; empty becomes "none".
+func shortSHA(sha string) string {
+	if sha == "" {
+		return "none"
+	}
+
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+
+	return sha
+}
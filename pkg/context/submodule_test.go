@@ -0,0 +1,70 @@
+package context
+
+import "testing"
+
+const submoduleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-package old
++package main
+diff --git a/vendor/lib b/vendor/lib
+index 3f786850e387550fdab836ed7e6dc881de23001b..89e6c98d92887913cadf06b2adb97f26cde4849b 160000
+--- a/vendor/lib
++++ b/vendor/lib
+@@ -1 +1 @@
+-Subproject commit 3f786850e387550fdab836ed7e6dc881de23001b
++Subproject commit 89e6c98d92887913cadf06b2adb97f26cde4849b
+diff --git a/third_party/new b/third_party/new
+new file mode 160000
+index 0000000..abcdef0
+--- /dev/null
++++ b/third_party/new
+@@ -0,0 +1 @@
++Subproject commit abcdef0123456789abcdef0123456789abcdef01
+`
+
+func TestParseSubmoduleChanges(t *testing.T) {
+	changes := parseSubmoduleChanges(submoduleDiff)
+
+	if len(changes) != 2 {
+		t.Fatalf("got %d submodule changes, want 2: %v", len(changes), changes)
+	}
+
+	if _, ok := changes["main.go"]; ok {
+		t.Error("regular file should not be reported as a submodule")
+	}
+
+	lib := changes["vendor/lib"]
+	if lib.OldSHA != "3f786850e387550fdab836ed7e6dc881de23001b" || lib.NewSHA != "89e6c98d92887913cadf06b2adb97f26cde4849b" {
+		t.Errorf("vendor/lib = %+v", lib)
+	}
+	if got, want := lib.String(), "submodule 3f786850e387 → 89e6c98d9288"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	added := changes["third_party/new"]
+	if got, want := added.String(), "submodule none → abcdef012345"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestAnnotateSubmodules(t *testing.T) {
+	files := []FileContent{
+		{Path: "main.go", Language: "go", LinesAdded: 1, LinesDeleted: 1},
+		{Path: "vendor/lib", Language: "text", LinesAdded: 1, LinesDeleted: 1},
+	}
+
+	annotateSubmodules(files, submoduleDiff)
+
+	if files[0].IsSubmodule || files[0].Note != "" {
+		t.Errorf("main.go annotated as submodule: %+v", files[0])
+	}
+	if !files[1].IsSubmodule {
+		t.Error("vendor/lib should be marked as a submodule")
+	}
+	if files[1].Note != "submodule 3f786850e387 → 89e6c98d9288" {
+		t.Errorf("Note = %q", files[1].Note)
+	}
+}
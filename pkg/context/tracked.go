@@ -0,0 +1,32 @@
+package context
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// TrackedFiles returns which of paths, relative to the repo root, git
+// tracks. The result is keyed by slash-separated path.
+func TrackedFiles(ctx context.Context, repoPath string, paths []string) (map[string]bool, error) {
+	tracked := make(map[string]bool, len(paths))
+	if len(paths) == 0 {
+		return tracked, nil
+	}
+
+	args := append([]string{"-C", repoPath, "ls-files", "-z", "--full-name", "--"}, paths...)
+
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("list tracked files: %w", err)
+	}
+
+	for name := range bytes.SplitSeq(out, []byte{0}) {
+		if len(name) > 0 {
+			tracked[NormalizePath(string(name))] = true
+		}
+	}
+
+	return tracked, nil
+}
@@ -0,0 +1,95 @@
+package context
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// untrackedChanges returns a diff and DiffFile entries showing every
+// untracked, non-ignored file in root as added, as "git add -N" would. The
+// index is left untouched.
+func untrackedChanges(ctx context.Context, root string) (string, []git.DiffFile, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", root, "ls-files", "-z", "--others", "--exclude-standard").Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("list untracked files: %w", err)
+	}
+
+	var (
+		sb    strings.Builder
+		files []git.DiffFile
+	)
+
+	for name := range bytes.SplitSeq(out, []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+
+		path := string(name)
+
+		// A trailing slash marks a nested repository, not a file to diff
+		if strings.HasSuffix(path, "/") {
+			continue
+		}
+
+		patch, err := untrackedPatch(ctx, root, path)
+		if err != nil {
+			return "", nil, err
+		}
+
+		sb.WriteString(patch)
+		files = append(files, untrackedDiffFile(path, patch))
+	}
+
+	return sb.String(), files, nil
+}
+
+// untrackedPatch diffs path against the empty file.
+func untrackedPatch(ctx context.Context, root, path string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", root, "diff", "--no-index", "--no-color", "--", os.DevNull, path).Output()
+
+	// --no-index exits 1 when the files differ, which they always do here.
+	// It also exits 1 when it can't read path, so exit 1 only counts as
+	// success when it produced a patch.
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(out) > 0) {
+		if exitErr != nil && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("diff untracked file %s: %w: %s", path, err, bytes.TrimSpace(exitErr.Stderr))
+		}
+
+		return "", fmt.Errorf("diff untracked file %s: %w", path, err)
+	}
+
+	return string(out), nil
+}
+
+// untrackedDiffFile describes the added file path from its patch. Only
+// lines after the first hunk header are counted, so content starting with
+// "++" isn't mistaken for the "+++" file header.
+func untrackedDiffFile(path, patch string) git.DiffFile {
+	df := git.DiffFile{
+		Path:   NormalizePath(path),
+		Status: git.FileAdded,
+	}
+
+	inHunks := false
+
+	for line := range strings.SplitSeq(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "Binary files "):
+			df.IsBinary = true
+		case strings.HasPrefix(line, "@@ "):
+			inHunks = true
+		case inHunks && strings.HasPrefix(line, "+"):
+			df.LinesAdded++
+		}
+	}
+
+	return df
+}
@@ -0,0 +1,151 @@
+package context
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+	"github.com/crealfy/crea-review/internal/testutil"
+)
+
+func TestGatherIncludeUntracked(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	testutil.CommitFile(t, dir, "main.go")
+
+	writeTestFile(t, dir, "main.go", "main.go\nchanged\n")
+	writeTestFile(t, dir, "new.go", "package main\n\n++ not a header\n")
+	writeTestFile(t, dir, ".gitignore", "*.log\n")
+	writeTestFile(t, dir, "debug.log", "ignored\n")
+
+	tests := []struct {
+		name      string
+		untracked bool
+		want      []string
+	}{
+		{"tracked changes only", false, []string{"main.go"}},
+		{"with untracked files", true, []string{"main.go", ".gitignore", "new.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultGatherOptions()
+			opts.ReviewType = "uncommitted"
+			opts.IncludeUntracked = tt.untracked
+
+			rc, err := Gather(context.Background(), dir, opts)
+			if err != nil {
+				t.Fatalf("Gather() error = %v", err)
+			}
+
+			var got []string
+			for _, f := range rc.ChangedFiles {
+				got = append(got, f.Path)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ChangedFiles = %v, want %v", got, tt.want)
+			}
+
+			if tt.untracked && !strings.Contains(rc.Diff, "+++ b/new.go") {
+				t.Errorf("Diff doesn't include new.go:\n%s", rc.Diff)
+			}
+		})
+	}
+}
+
+func TestIncludeUntrackedCommittedHead(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	testutil.CommitFile(t, dir, "a.go")
+	testutil.CommitFile(t, dir, "b.go")
+	writeTestFile(t, dir, "new.go", "package main\n")
+
+	opts := DefaultGatherOptions()
+	opts.ReviewType = "committed"
+	opts.IncludeUntracked = true
+
+	rc, err := Gather(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	if len(rc.ChangedFiles) != 1 || rc.ChangedFiles[0].Path != "b.go" {
+		t.Errorf("ChangedFiles = %+v, want only b.go", rc.ChangedFiles)
+	}
+}
+
+func TestUntrackedChangesSkipsNestedRepos(t *testing.T) {
+	dir := testutil.GitRepo(t)
+	testutil.CommitFile(t, dir, "main.go")
+	writeTestFile(t, dir, "new.go", "package main\n")
+
+	nested := filepath.Join(dir, "vendor", "lib")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	testutil.Git(t, nested, "init", "-q")
+	writeTestFile(t, nested, "lib.go", "package lib\n")
+
+	diff, files, err := untrackedChanges(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("untrackedChanges() error = %v", err)
+	}
+
+	if len(files) != 1 || files[0].Path != "new.go" {
+		t.Errorf("files = %+v, want only new.go", files)
+	}
+
+	if strings.Contains(diff, "vendor/lib") {
+		t.Errorf("diff includes the nested repository:\n%s", diff)
+	}
+}
+
+func TestUntrackedPatchMissingFile(t *testing.T) {
+	dir := testutil.GitRepo(t)
+
+	_, err := untrackedPatch(context.Background(), dir, "missing.txt")
+	if err == nil || !strings.Contains(err.Error(), "missing.txt") {
+		t.Errorf("untrackedPatch(missing file) error = %v, want an error naming the file", err)
+	}
+}
+
+func TestUntrackedDiffFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  git.DiffFile
+	}{
+		{
+			name: "text",
+			patch: `diff --git a/new.go b/new.go
+new file mode 100644
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,3 @@
++package main
++
++++ x
+`,
+			want: git.DiffFile{Path: "new.go", Status: git.FileAdded, LinesAdded: 3},
+		},
+		{
+			name: "binary",
+			patch: `diff --git a/new.go b/new.go
+new file mode 100644
+Binary files /dev/null and b/new.go differ
+`,
+			want: git.DiffFile{Path: "new.go", Status: git.FileAdded, IsBinary: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := untrackedDiffFile("new.go", tt.patch); got != tt.want {
+				t.Errorf("untrackedDiffFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package context
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// withWorktree checks commit out into a temporary detached worktree of
+// repoPath, calls fn with its directory and removes the worktree afterwards.
+func withWorktree(ctx context.Context, repoPath, commit string, fn func(dir string) error) error {
+	base, err := os.MkdirTemp("", "creareview-wt-")
+	if err != nil {
+		return fmt.Errorf("create worktree dir: %w", err)
+	}
+
+	defer os.RemoveAll(base)
+
+	wt, err := git.CreateWorktreeDetached(ctx, repoPath, base, commit)
+	if err != nil {
+		return fmt.Errorf("create worktree at %s: %w", commit, err)
+	}
+
+	// Cleanup must run even when ctx is cancelled
+	defer func() {
+		_ = git.RemoveWorktree(context.WithoutCancel(ctx), repoPath, wt.Path, true)
+	}()
+
+	return fn(wt.Path)
+}
@@ -0,0 +1,4 @@
+// Package integration holds end-to-end tests of the review pipeline:
+// gathering a change from a real git repository, scoring it, reviewing it
+// with a fake backend and recording the session. It has no non-test code.
+package integration
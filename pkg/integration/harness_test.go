@@ -0,0 +1,176 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+	"github.com/crealfy/crea-pipe/pkg/agent/mock"
+	"github.com/crealfy/crea-review/pkg/batch"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/priority"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// fakeBackend is the backend the harness registers. It answers with the
+// fakeAgent installed by useFakeAgent.
+const fakeBackend = "integration-fake"
+
+var (
+	registerOnce sync.Once
+
+	currentMu   sync.Mutex
+	currentFake *fakeAgent
+)
+
+// fakeAgent answers every review with a fixed response and records the
+// prompts it was sent.
+type fakeAgent struct {
+	mu       sync.Mutex
+	response string
+	prompts  []string
+}
+
+// useFakeAgent makes fakeBackend answer with response for the rest of the
+// test. Tests using it must not run in parallel.
+func useFakeAgent(t *testing.T, response string) *fakeAgent {
+	t.Helper()
+
+	registerOnce.Do(func() {
+		review.RegisterBackend(fakeBackend, func() agent.Agent {
+			currentMu.Lock()
+			defer currentMu.Unlock()
+
+			return currentFake.agent()
+		})
+	})
+
+	fake := &fakeAgent{response: response}
+
+	currentMu.Lock()
+	currentFake = fake
+	currentMu.Unlock()
+
+	t.Cleanup(func() {
+		currentMu.Lock()
+		currentFake = nil
+		currentMu.Unlock()
+	})
+
+	return fake
+}
+
+// agent returns a mock agent backed by f.
+func (f *fakeAgent) agent() agent.Agent {
+	a := mock.New()
+	a.RunFunc = func(_ context.Context, prompt string, _ *agent.Config) (*agent.Response, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		f.prompts = append(f.prompts, prompt)
+
+		return &agent.Response{Text: f.response, Model: "fake-model"}, nil
+	}
+
+	return a
+}
+
+// sent returns the prompts f received so far.
+func (f *fakeAgent) sent() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.prompts...)
+}
+
+// pipelineRun is what one batch through the pipeline produced.
+type pipelineRun struct {
+	reviewCtx *rcontext.ReviewContext
+	scores    []priority.Score
+	result    *review.Result
+	sess      *session.Session
+}
+
+// runPipeline reviews one batch of the change in repo the way the CLI
+// does: gather the change, score it and keep the top maxFiles files, create
+// a session, review with fakeBackend and save the findings. With
+// continueFrom set, files reviewed earlier in that session chain are
+// skipped. Selection and session bookkeeping go through package batch, as
+// in the CLI.
+func runPipeline(t *testing.T, repo string, store *session.Store, opts rcontext.GatherOptions, maxFiles, continueFrom int) pipelineRun {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if continueFrom > 0 {
+		reviewed, _, err := store.CollectReviewedFiles(continueFrom)
+		if err != nil {
+			t.Fatalf("CollectReviewedFiles(%d) error = %v", continueFrom, err)
+		}
+
+		opts.ExcludeFiles = reviewed
+	}
+
+	reviewCtx, err := rcontext.Gather(ctx, repo, opts)
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	scorer := priority.NewScorer(reviewCtx.RepoPath).WithExclude(opts.ExcludeFiles)
+	if err := scorer.ApplyConfig(reviewCtx.Config); err != nil {
+		t.Fatalf("ApplyConfig() error = %v", err)
+	}
+
+	scores, err := scorer.ScoreFiles(ctx, reviewCtx.ChangedFiles)
+	if err != nil {
+		t.Fatalf("ScoreFiles() error = %v", err)
+	}
+
+	reviewCtx.ChangedFiles = batch.Files(reviewCtx.ChangedFiles, batch.Limit(scores, maxFiles, nil))
+
+	sess := batch.NewSession(reviewCtx, batch.Counts{Scored: len(scores), Previous: len(opts.ExcludeFiles)}, continueFrom)
+	if err := store.Create(sess); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	reviewer, err := review.NewReviewer(fakeBackend)
+	if err != nil {
+		t.Fatalf("NewReviewer() error = %v", err)
+	}
+
+	cfg := reviewCtx.Config
+
+	result, err := reviewer.Review(ctx, reviewCtx, review.Options{
+		Instructions:     cfg.Instructions,
+		PathInstructions: cfg.PathInstructions,
+		Categories:       cfg.Categories,
+		SeverityDefaults: cfg.Severities,
+		Focus:            cfg.Focus,
+	})
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	sess.Findings = result.Findings
+	sess.Status = session.StatusCompleted
+
+	if err := store.Save(sess); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	return pipelineRun{reviewCtx: reviewCtx, scores: scores, result: result, sess: sess}
+}
+
+// newTestStore opens a session store for repo in a temporary state dir.
+func newTestStore(t *testing.T, repo string) *session.Store {
+	t.Helper()
+
+	store, err := session.NewStore(repo, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	return store
+}
@@ -0,0 +1,147 @@
+package integration
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// initialFiles is the fixture committed before each change under review.
+var initialFiles = map[string]string{
+	"main.go":                  "package main\n\nfunc main() {}\n",
+	"pkg/auth/login.go":        "package auth\n\nfunc Login() error { return nil }\n",
+	"pkg/util/strings.go":      "package util\n\nfunc Trim(s string) string { return s }\n",
+	"docs/guide.md":            "# Guide\n",
+	"review.yaml":              "instructions: Check error handling.\npath_instructions:\n  - path: auth/\n    instructions: Check session expiry.\n",
+	"pkg/util/strings_test.go": "package util\n",
+}
+
+func TestPipelineReviewsChangeAndRecordsSession(t *testing.T) {
+	repo := testutil.GitRepo(t)
+	testutil.Commit(t, repo, "initial", initialFiles)
+
+	testutil.WriteFiles(t, repo, map[string]string{
+		"pkg/auth/login.go":   "package auth\n\nfunc Login(token string) error {\n\tif token == \"\" {\n\t\treturn nil\n\t}\n\n\treturn nil\n}\n",
+		"pkg/util/strings.go": "package util\n\nimport \"strings\"\n\nfunc Trim(s string) string { return strings.TrimSpace(s) }\n",
+	})
+
+	fake := useFakeAgent(t, "FINDING: [pkg/auth/login.go:5] [error] [security]\n"+
+		"DESCRIPTION: An empty token logs the user in\n"+
+		"SUGGESTED_FIX: Return an error for an empty token\n\n"+review.CompletionMarker+"\n")
+	store := newTestStore(t, repo)
+
+	opts := rcontext.DefaultGatherOptions()
+	opts.ReviewType = "uncommitted"
+
+	run := runPipeline(t, repo, store, opts, 10, 0)
+
+	// Critical paths outrank plain utility code
+	var ranked []string
+	for _, s := range run.scores {
+		ranked = append(ranked, s.Path)
+	}
+
+	if want := []string{"pkg/auth/login.go", "pkg/util/strings.go"}; !slices.Equal(ranked, want) {
+		t.Errorf("scored %v, want %v", ranked, want)
+	}
+
+	prompts := fake.sent()
+	if len(prompts) != 1 {
+		t.Fatalf("agent got %d prompts, want 1", len(prompts))
+	}
+
+	for _, want := range []string{"pkg/auth/login.go", "pkg/util/strings.go", "Check error handling.", "Check session expiry."} {
+		if !strings.Contains(prompts[0], want) {
+			t.Errorf("prompt is missing %q:\n%s", want, prompts[0])
+		}
+	}
+
+	if len(run.result.Findings) != 1 || run.result.Findings[0].Severity != "error" {
+		t.Fatalf("Findings = %+v, want the fake agent's error", run.result.Findings)
+	}
+
+	// The session round-trips through the store
+	saved, err := store.Load(run.sess.ID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if saved.Status != session.StatusCompleted || saved.FilesReviewed != 2 || saved.FilesRemaining != 0 {
+		t.Errorf("saved session = %+v, want completed with 2 files reviewed", saved)
+	}
+
+	findings, err := store.LoadFindings(run.sess.ID)
+	if err != nil {
+		t.Fatalf("LoadFindings() error = %v", err)
+	}
+
+	if len(findings) != 1 || findings[0].File != "pkg/auth/login.go" || findings[0].Line != 5 {
+		t.Errorf("stored findings = %+v, want pkg/auth/login.go:5", findings)
+	}
+}
+
+func TestPipelineContinuesAcrossBatches(t *testing.T) {
+	repo := testutil.GitRepo(t)
+	testutil.Commit(t, repo, "initial", initialFiles)
+
+	testutil.Commit(t, repo, "change", map[string]string{
+		"main.go":             "package main\n\nfunc main() {\n\trun()\n}\n\nfunc run() {}\n",
+		"pkg/auth/login.go":   "package auth\n\nfunc Login() error { return check() }\n\nfunc check() error { return nil }\n",
+		"pkg/util/strings.go": "package util\n\nfunc Trim(s string) string { return s + \"\" }\n",
+	})
+
+	fake := useFakeAgent(t, review.CompletionMarker+"\n")
+	store := newTestStore(t, repo)
+
+	opts := rcontext.DefaultGatherOptions()
+	opts.ReviewType = "committed"
+
+	var (
+		reviewed []string
+		prev     int
+	)
+
+	for batch := 1; batch <= 3; batch++ {
+		run := runPipeline(t, repo, store, opts, 1, prev)
+
+		if len(run.sess.Files) != 1 {
+			t.Fatalf("batch %d reviewed %v, want one file", batch, run.sess.Files)
+		}
+
+		if want := 3 - batch; run.sess.FilesRemaining != want {
+			t.Errorf("batch %d FilesRemaining = %d, want %d", batch, run.sess.FilesRemaining, want)
+		}
+
+		reviewed = append(reviewed, run.sess.Files...)
+		prev = run.sess.ID
+	}
+
+	// Highest priority first, and no file twice
+	if reviewed[0] != "pkg/auth/login.go" {
+		t.Errorf("first batch reviewed %s, want the critical pkg/auth/login.go", reviewed[0])
+	}
+
+	slices.Sort(reviewed)
+
+	if want := []string{"main.go", "pkg/auth/login.go", "pkg/util/strings.go"}; !slices.Equal(reviewed, want) {
+		t.Errorf("reviewed %v across batches, want %v", reviewed, want)
+	}
+
+	if got := len(fake.sent()); got != 3 {
+		t.Errorf("agent got %d prompts, want 3", got)
+	}
+
+	all, _, err := store.CollectReviewedFiles(prev)
+	if err != nil {
+		t.Fatalf("CollectReviewedFiles() error = %v", err)
+	}
+
+	if len(all) != 3 {
+		t.Errorf("CollectReviewedFiles() = %v, want all 3 files", all)
+	}
+}
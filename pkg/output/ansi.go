@@ -0,0 +1,13 @@
+package output
+
+import "regexp"
+
+// ansiPattern matches terminal escape sequences: CSI sequences such as
+// colors and cursor movement, OSC sequences such as hyperlinks and window
+// titles, and the remaining two-byte escapes.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes terminal escape sequences from s.
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "no escapes here", "no escapes here"},
+		{"colors", "\x1b[1;31merror\x1b[0m: nil deref", "error: nil deref"},
+		{"cursor movement", "\x1b[2K\x1b[1Adone", "done"},
+		{"hyperlink", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"title with bell", "\x1b]0;title\x07text", "text"},
+		{"two-byte escape", "a\x1bMb", "ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(tt.in); got != tt.want {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatterWithStripANSI(t *testing.T) {
+	out := &Output{
+		Findings: []session.Finding{
+			{File: "main.go", Line: 3, Severity: "error", Category: "bug", Description: "nil \x1b[31mderef\x1b[0m"},
+		},
+	}
+
+	var colored, stripped strings.Builder
+
+	if err := NewFormatter(FormatPlain).Render(&colored, out); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if err := NewFormatter(FormatPlain).WithStripANSI().Render(&stripped, out); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if !strings.Contains(colored.String(), "\x1b[") {
+		t.Fatalf("colored output has no escapes to strip:\n%q", colored.String())
+	}
+
+	if strings.Contains(stripped.String(), "\x1b") {
+		t.Errorf("stripped output still has escapes:\n%q", stripped.String())
+	}
+
+	if stripped.String() != StripANSI(colored.String()) {
+		t.Errorf("stripped output = %q, want %q", stripped.String(), StripANSI(colored.String()))
+	}
+}
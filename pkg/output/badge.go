@@ -0,0 +1,68 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// badgeColors maps the worst finding severity to a shields.io color.
+var badgeColors = map[string]string{
+	"error":      "red",
+	"warning":    "orange",
+	"suggestion": "yellow",
+}
+
+// Badge is a shields.io endpoint badge
+// (https://shields.io/badges/endpoint-badge).
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// NewBadge summarizes a session's findings as a badge colored by the worst
+// severity. Failed sessions get a grey "failed" badge.
+func NewBadge(sess *session.Session) Badge {
+	badge := Badge{SchemaVersion: 1, Label: "review", Color: "brightgreen"}
+
+	switch {
+	case sess.Status == session.StatusFailed:
+		badge.Message, badge.Color = "failed", "lightgrey"
+
+		return badge
+	case len(sess.Findings) == 0:
+		badge.Message = "no issues"
+
+		return badge
+	case len(sess.Findings) == 1:
+		badge.Message = "1 issue"
+	default:
+		badge.Message = fmt.Sprintf("%d issues", len(sess.Findings))
+	}
+
+	// Unknown severities keep blue; later, more severe matches win
+	badge.Color = "blue"
+
+	for _, severity := range []string{"suggestion", "warning", "error"} {
+		for _, f := range sess.Findings {
+			if f.Severity == severity {
+				badge.Color = badgeColors[severity]
+			}
+		}
+	}
+
+	return badge
+}
+
+// WriteBadge writes badge as single-line JSON.
+func WriteBadge(w io.Writer, badge Badge) error {
+	if err := json.NewEncoder(w).Encode(badge); err != nil {
+		return fmt.Errorf("write badge: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestNewBadge(t *testing.T) {
+	tests := []struct {
+		name      string
+		sess      *session.Session
+		wantMsg   string
+		wantColor string
+	}{
+		{
+			name:      "no findings",
+			sess:      &session.Session{Status: session.StatusCompleted},
+			wantMsg:   "no issues",
+			wantColor: "brightgreen",
+		},
+		{
+			name:      "one suggestion",
+			sess:      &session.Session{Findings: []session.Finding{{Severity: "suggestion"}}},
+			wantMsg:   "1 issue",
+			wantColor: "yellow",
+		},
+		{
+			name: "worst severity wins",
+			sess: &session.Session{Findings: []session.Finding{
+				{Severity: "suggestion"}, {Severity: "error"}, {Severity: "warning"},
+			}},
+			wantMsg:   "3 issues",
+			wantColor: "red",
+		},
+		{
+			name:      "unknown severity",
+			sess:      &session.Session{Findings: []session.Finding{{Severity: "info"}}},
+			wantMsg:   "1 issue",
+			wantColor: "blue",
+		},
+		{
+			name:      "failed session",
+			sess:      &session.Session{Status: session.StatusFailed, Findings: []session.Finding{{Severity: "error"}}},
+			wantMsg:   "failed",
+			wantColor: "lightgrey",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewBadge(tt.sess)
+			if got.SchemaVersion != 1 || got.Label != "review" {
+				t.Errorf("NewBadge() = %+v, want schemaVersion 1 and label review", got)
+			}
+
+			if got.Message != tt.wantMsg || got.Color != tt.wantColor {
+				t.Errorf("NewBadge() = %q/%q, want %q/%q", got.Message, got.Color, tt.wantMsg, tt.wantColor)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// checkstyleReport is the root element of a checkstyle XML report.
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+// checkstyleFile groups errors for a single file.
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+// checkstyleError is a single finding in checkstyle format.
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// formatCheckstyle writes checkstyle XML, grouping findings by file in order of first appearance.
+func (f *Formatter) formatCheckstyle(w io.Writer, output *Output) error {
+	report := checkstyleReport{Version: "4.3"}
+	index := make(map[string]int)
+
+	for _, finding := range output.Findings {
+		i, ok := index[finding.File]
+		if !ok {
+			i = len(report.Files)
+			index[finding.File] = i
+			report.Files = append(report.Files, checkstyleFile{Name: finding.File})
+		}
+
+		report.Files[i].Errors = append(report.Files[i].Errors, checkstyleError{
+			Line:     finding.Line,
+			Severity: checkstyleSeverity(finding.Severity),
+			Message:  finding.Description + idSuffix(finding.ID),
+			Source:   finding.Category,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// checkstyleSeverity maps a finding severity to checkstyle's error/warning/info.
+func checkstyleSeverity(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "info"
+	}
+}
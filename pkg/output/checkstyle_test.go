@@ -0,0 +1,115 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestFormatCheckstyle(t *testing.T) {
+	formatter := NewFormatter(FormatCheckstyle)
+
+	result := &review.Result{
+		Findings: []session.Finding{
+			{File: "auth.go", Line: 10, Severity: "error", Category: "security", Description: "SQL injection"},
+			{File: "main.go", Line: 3, Severity: "suggestion", Category: "style", Description: "rename <var>"},
+			{File: "auth.go", Line: 20, Severity: "warning", Category: "bug", Description: "unchecked error"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, result, nil); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Error("output should start with XML header")
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v\n%s", err, buf.String())
+	}
+
+	if len(report.Files) != 2 {
+		t.Fatalf("len(Files) = %d, want 2", len(report.Files))
+	}
+
+	auth := report.Files[0]
+	if auth.Name != "auth.go" || len(auth.Errors) != 2 {
+		t.Errorf("Files[0] = %s with %d errors, want auth.go with 2", auth.Name, len(auth.Errors))
+	}
+	if auth.Errors[0].Severity != "error" || auth.Errors[0].Source != "security" {
+		t.Errorf("Errors[0] = %+v, want severity error, source security", auth.Errors[0])
+	}
+	if auth.Errors[1].Line != 20 || auth.Errors[1].Severity != "warning" {
+		t.Errorf("Errors[1] = %+v, want line 20 warning", auth.Errors[1])
+	}
+
+	mainFile := report.Files[1]
+	if mainFile.Errors[0].Severity != "info" {
+		t.Errorf("suggestion severity = %q, want info", mainFile.Errors[0].Severity)
+	}
+	if mainFile.Errors[0].Message != "rename <var>" {
+		t.Errorf("Message = %q, want escaped round-trip", mainFile.Errors[0].Message)
+	}
+}
+
+func TestFormatCheckstyleWindowsPaths(t *testing.T) {
+	result := &review.Result{
+		Findings: []session.Finding{
+			{File: `pkg\auth.go`, Line: 1, Severity: "error", Category: "bug", Description: "a"},
+			{File: "pkg/auth.go", Line: 2, Severity: "error", Category: "bug", Description: "b"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatCheckstyle).Format(&buf, result, nil); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+
+	if len(report.Files) != 1 || report.Files[0].Name != "pkg/auth.go" || len(report.Files[0].Errors) != 2 {
+		t.Errorf("Files = %+v, want both findings under pkg/auth.go", report.Files)
+	}
+
+	if result.Findings[0].File != `pkg\auth.go` {
+		t.Error("formatting must not modify the caller's findings")
+	}
+}
+
+func TestFormatCheckstyleNoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatCheckstyle).Format(&buf, &review.Result{}, nil); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+
+	if len(report.Files) != 0 {
+		t.Errorf("len(Files) = %d, want 0", len(report.Files))
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"json", "plain", "prompt-only", "checkstyle", "jsonl"} {
+		if _, err := ParseFormat(name); err != nil {
+			t.Errorf("ParseFormat(%q) error = %v", name, err)
+		}
+	}
+
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
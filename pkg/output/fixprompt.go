@@ -0,0 +1,69 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// DefaultFixPromptTemplate is the built-in implementation prompt, the
+// handoff to a fixing agent such as crea-pipe. It is a text/template
+// executed with FixPromptData.
+const DefaultFixPromptTemplate = `Fix the following code review issues:
+
+{{range $i, $f := .Findings}}{{inc $i}}. [{{upper .Category}}] {{.Location}}{{if .ID}} (id {{.ID}}){{end}}
+   Issue: {{.Description}}
+{{if .SuggestedFix}}   Fix: {{.SuggestedFix}}
+{{end}}{{if .DocURL}}   Docs: {{.DocURL}}
+{{end}}
+{{end}}Apply the fixes while maintaining code style and ensuring tests still pass.
+`
+
+// FixPromptData is the value fix prompt templates are executed with.
+type FixPromptData struct {
+	// Findings are the findings to fix, with the fields of session.Finding
+	// and the .Location method.
+	Findings []session.Finding
+}
+
+// fixPromptFuncs are the helper functions available to fix prompt templates.
+var fixPromptFuncs = template.FuncMap{
+	"inc":   func(i int) int { return i + 1 },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// defaultFixPrompt is the parsed DefaultFixPromptTemplate.
+var defaultFixPrompt = template.Must(ParseFixPromptTemplate(DefaultFixPromptTemplate))
+
+// ParseFixPromptTemplate parses an implementation prompt template with the
+// helper functions inc, upper and lower. Unknown fields are reported here
+// rather than at render time.
+func ParseFixPromptTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("fix-prompt").Funcs(fixPromptFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse fix prompt template: %w", err)
+	}
+
+	// Render a sample so field typos inside {{range .Findings}} surface too
+	sample := FixPromptData{Findings: []session.Finding{{ID: "1", File: "f", Line: 1, SuggestedFix: "x", DocURL: "u"}}}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("invalid fix prompt template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// RenderFixPrompt renders the implementation prompt for findings with tmpl.
+func RenderFixPrompt(tmpl *template.Template, findings []session.Finding) (string, error) {
+	var sb strings.Builder
+
+	if err := tmpl.Execute(&sb, FixPromptData{Findings: findings}); err != nil {
+		return "", fmt.Errorf("render fix prompt: %w", err)
+	}
+
+	return sb.String(), nil
+}
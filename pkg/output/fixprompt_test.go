@@ -0,0 +1,81 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestRenderFixPrompt(t *testing.T) {
+	findings := []session.Finding{
+		{ID: "1a2b3c4d", File: "auth.go", Line: 10, Category: "security", Description: "SQL injection", SuggestedFix: "use placeholders"},
+		{File: "main.go", Line: 3, EndLine: 5, Category: "style", Description: "rename", DocURL: "https://example.com/style"},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		got, err := RenderFixPrompt(defaultFixPrompt, findings)
+		if err != nil {
+			t.Fatalf("RenderFixPrompt() error = %v", err)
+		}
+
+		want := `Fix the following code review issues:
+
+1. [SECURITY] auth.go:10 (id 1a2b3c4d)
+   Issue: SQL injection
+   Fix: use placeholders
+
+2. [STYLE] main.go:3-5
+   Issue: rename
+   Docs: https://example.com/style
+
+Apply the fixes while maintaining code style and ensuring tests still pass.
+`
+		if got != want {
+			t.Errorf("RenderFixPrompt() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		tmpl, err := ParseFixPromptTemplate(`Fix these and add a regression test for each:
+{{range .Findings}}- {{.Location}}: {{.Description}}
+{{end}}Use Conventional Commits.
+`)
+		if err != nil {
+			t.Fatalf("ParseFixPromptTemplate() error = %v", err)
+		}
+
+		got, err := RenderFixPrompt(tmpl, findings)
+		if err != nil {
+			t.Fatalf("RenderFixPrompt() error = %v", err)
+		}
+
+		want := `Fix these and add a regression test for each:
+- auth.go:10: SQL injection
+- main.go:3-5: rename
+Use Conventional Commits.
+`
+		if got != want {
+			t.Errorf("RenderFixPrompt() =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
+
+func TestParseFixPromptTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"syntax error", "{{range .Findings}}"},
+		{"unknown top-level field", "{{.Summary}}"},
+		{"unknown finding field", "{{range .Findings}}{{.Path}}{{end}}"},
+		{"unknown function", "{{title .Findings}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFixPromptTemplate(tt.text); err == nil {
+				t.Errorf("ParseFixPromptTemplate(%q) expected error", tt.text)
+			}
+		})
+	}
+}
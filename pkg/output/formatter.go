@@ -2,11 +2,16 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
+	"text/template"
 
+	rcontext "github.com/crealfy/crea-review/pkg/context"
 	"github.com/crealfy/crea-review/pkg/review"
 	"github.com/crealfy/crea-review/pkg/session"
 )
@@ -18,12 +23,30 @@ const (
 	FormatJSON       Format = "json"
 	FormatPlain      Format = "plain"
 	FormatPromptOnly Format = "prompt-only"
+	FormatCheckstyle Format = "checkstyle"
+	FormatJSONL      Format = "jsonl"
 )
 
+// ParseFormat validates a format name.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case FormatJSON, FormatPlain, FormatPromptOnly, FormatCheckstyle, FormatJSONL:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (want json, plain, prompt-only, checkstyle, jsonl)", name)
+	}
+}
+
 // Output represents the formatted review output.
 type Output struct {
-	// SessionID is the session identifier.
-	SessionID int `json:"session_id"`
+	// SessionID is the session identifier, omitted with --no-session.
+	SessionID int `json:"session_id,omitempty"`
+
+	// BaseCommit is the base commit the review compared against.
+	BaseCommit string `json:"base_commit,omitempty"`
+
+	// HeadCommit is the head commit (empty for the working tree).
+	HeadCommit string `json:"head_commit,omitempty"`
 
 	// TotalFiles is the total files in the diff.
 	TotalFiles int `json:"total_files"`
@@ -32,14 +55,41 @@ type Output struct {
 	ReviewedFiles int `json:"reviewed_files"`
 
 	// RemainingFiles is the number of files remaining.
-	RemainingFiles int `json:"remaining_files,omitempty"`
+	// Always present so scripts can rely on it.
+	RemainingFiles int `json:"remaining_files"`
+
+	// Complete is true when no files remain, i.e. no --continue is needed.
+	Complete bool `json:"complete"`
+
+	// Partial is true when the review was interrupted and the findings come
+	// from an incomplete response.
+	Partial bool `json:"partial,omitempty"`
+
+	// Cached is true when the AI response was reused from the --cache.
+	Cached bool `json:"cached,omitempty"`
+
+	// ReviewedFilePaths lists the files reviewed in this session.
+	ReviewedFilePaths []string `json:"reviewed_file_paths,omitempty"`
+
+	// PrimaryLanguage is the most changed language in the diff.
+	PrimaryLanguage string `json:"primary_language,omitempty"`
 
 	// Summary is a human-readable summary.
 	Summary string `json:"summary"`
 
+	// OmittedFindings is the number of findings dropped by --max-findings.
+	OmittedFindings int `json:"omitted_findings,omitempty"`
+
+	// BaselinedFindings is the number of known findings suppressed by --baseline.
+	BaselinedFindings int `json:"baselined_findings,omitempty"`
+
 	// Findings contains the review findings.
 	Findings []session.Finding `json:"findings"`
 
+	// SuggestedReviewers are the CODEOWNERS owners of the reviewed files
+	// (--suggest-reviewers).
+	SuggestedReviewers []string `json:"suggested_reviewers,omitempty"`
+
 	// ImplementationPrompt is the prompt for crea-pipe to fix issues.
 	ImplementationPrompt string `json:"implementation_prompt,omitempty"`
 
@@ -55,8 +105,14 @@ type Output struct {
 
 // Formatter formats review results.
 type Formatter struct {
-	format  Format
-	noColor bool
+	format       Format
+	noColor      bool
+	compactJSON  bool
+	promptHeader bool
+	stripANSI    bool
+
+	// findingTemplate, when set, replaces the format (see WithFindingTemplate).
+	findingTemplate *template.Template
 }
 
 // NewFormatter creates a new formatter.
@@ -73,9 +129,55 @@ func (f *Formatter) WithNoColor() *Formatter {
 	return f
 }
 
+// WithStripANSI removes terminal escape sequences from the rendered output,
+// whether they come from forced colors or from finding text.
+func (f *Formatter) WithStripANSI() *Formatter {
+	f.stripANSI = true
+
+	return f
+}
+
+// WithCompactJSON writes JSON on a single line without indentation.
+func (f *Formatter) WithCompactJSON() *Formatter {
+	f.compactJSON = true
+
+	return f
+}
+
+// WithPromptHeader prefixes prompt-only output with a commented header
+// (session, base and head commit) so fix runs can be traced back to the review.
+func (f *Formatter) WithPromptHeader() *Formatter {
+	f.promptHeader = true
+
+	return f
+}
+
 // Format formats the review result and writes to the writer.
 func (f *Formatter) Format(w io.Writer, result *review.Result, sess *session.Session) error {
-	output := f.buildOutput(result, sess)
+	return f.Render(w, BuildOutput(result, sess))
+}
+
+// Render writes an already-built Output, so one review can be rendered in several formats.
+func (f *Formatter) Render(w io.Writer, output *Output) error {
+	if !f.stripANSI {
+		return f.render(w, output)
+	}
+
+	var buf bytes.Buffer
+	if err := f.render(&buf, output); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, StripANSI(buf.String()))
+
+	return err
+}
+
+// render writes output in the formatter's format.
+func (f *Formatter) render(w io.Writer, output *Output) error {
+	if f.findingTemplate != nil {
+		return f.formatFindingTemplate(w, output)
+	}
 
 	switch f.format {
 	case FormatJSON:
@@ -84,18 +186,28 @@ func (f *Formatter) Format(w io.Writer, result *review.Result, sess *session.Ses
 		return f.formatPlain(w, output)
 	case FormatPromptOnly:
 		return f.formatPromptOnly(w, output)
+	case FormatCheckstyle:
+		return f.formatCheckstyle(w, output)
+	case FormatJSONL:
+		return f.formatJSONL(w, output)
 	default:
 		return f.formatJSON(w, output)
 	}
 }
 
-// buildOutput creates the output structure from the result.
-func (f *Formatter) buildOutput(result *review.Result, sess *session.Session) *Output {
+// BuildOutput creates the output structure from the result.
+func BuildOutput(result *review.Result, sess *session.Session) *Output {
+	findings := normalizeFindingPaths(result.Findings)
+
 	output := &Output{
-		Findings: result.Findings,
-		Summary:  buildSummary(result.Findings),
-		Cost:     result.Cost,
-		Model:    result.Model,
+		Complete:          true,
+		Findings:          findings,
+		Summary:           buildSummary(findings, result.OmittedFindings),
+		OmittedFindings:   result.OmittedFindings,
+		BaselinedFindings: result.BaselinedFindings,
+		Cached:            result.Cached,
+		Cost:              result.Cost,
+		Model:             result.Model,
 	}
 
 	// Build token usage string
@@ -106,23 +218,63 @@ func (f *Formatter) buildOutput(result *review.Result, sess *session.Session) *O
 
 	if sess != nil {
 		output.SessionID = sess.ID
+		output.BaseCommit = sess.BaseCommit
+		output.HeadCommit = sess.HeadCommit
 		output.TotalFiles = sess.TotalFilesInDiff
 		output.ReviewedFiles = sess.FilesReviewed
 		output.RemainingFiles = sess.FilesRemaining
+		output.Complete = sess.FilesRemaining == 0
+		output.ReviewedFilePaths = normalizePaths(sess.Files)
+	}
+
+	if result.Partial {
+		output.Partial = true
+		output.Complete = false
 	}
 
 	// Build implementation prompt
-	if len(result.Findings) > 0 {
-		output.ImplementationPrompt = buildImplementationPrompt(result.Findings)
+	if len(findings) > 0 {
+		output.ImplementationPrompt = buildImplementationPrompt(findings)
 	}
 
 	return output
 }
 
+// normalizePaths returns a copy of paths with forward slashes.
+func normalizePaths(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	normalized := make([]string, len(paths))
+	for i, p := range paths {
+		normalized[i] = rcontext.NormalizePath(p)
+	}
+
+	return normalized
+}
+
+// normalizeFindingPaths returns a copy of findings with forward-slash file paths,
+// so findings from any platform group under one file.
+func normalizeFindingPaths(findings []session.Finding) []session.Finding {
+	if findings == nil {
+		return nil
+	}
+
+	normalized := slices.Clone(findings)
+	for i := range normalized {
+		normalized[i].File = rcontext.NormalizePath(normalized[i].File)
+	}
+
+	return normalized
+}
+
 // formatJSON writes JSON output.
 func (f *Formatter) formatJSON(w io.Writer, output *Output) error {
 	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
+	if !f.compactJSON {
+		enc.SetIndent("", "  ")
+	}
 
 	return enc.Encode(output)
 }
@@ -163,17 +315,31 @@ func (f *Formatter) formatPlain(w io.Writer, output *Output) error {
 			severityIcon := f.severityIcon(finding.Severity)
 			sb.WriteString(fmt.Sprintf("%d. %s [%s] %s\n",
 				i+1, severityIcon, finding.Severity, finding.Category))
-			sb.WriteString(fmt.Sprintf("   File: %s:%d\n", finding.File, finding.Line))
+			sb.WriteString(fmt.Sprintf("   File: %s\n", finding.Location()))
+
+			if finding.ID != "" {
+				sb.WriteString(fmt.Sprintf("   ID: %s\n", finding.ID))
+			}
+
 			sb.WriteString(fmt.Sprintf("   %s\n", finding.Description))
+			writeSnippet(&sb, finding)
 
 			if finding.SuggestedFix != "" {
 				sb.WriteString(fmt.Sprintf("   Fix: %s\n", finding.SuggestedFix))
 			}
 
+			if finding.DocURL != "" {
+				sb.WriteString(fmt.Sprintf("   Docs: %s\n", finding.DocURL))
+			}
+
 			sb.WriteString("\n")
 		}
 	}
 
+	if len(output.SuggestedReviewers) > 0 {
+		sb.WriteString(fmt.Sprintf("\nSuggested reviewers: %s\n", strings.Join(output.SuggestedReviewers, ", ")))
+	}
+
 	_, err := w.Write([]byte(sb.String()))
 
 	return err
@@ -181,6 +347,12 @@ func (f *Formatter) formatPlain(w io.Writer, output *Output) error {
 
 // formatPromptOnly writes minimal output for piping to crea-pipe.
 func (f *Formatter) formatPromptOnly(w io.Writer, output *Output) error {
+	if f.promptHeader {
+		if _, err := io.WriteString(w, promptHeader(output)); err != nil {
+			return err
+		}
+	}
+
 	if output.ImplementationPrompt == "" {
 		_, err := fmt.Fprintln(w, "No issues found in code review.")
 
@@ -192,6 +364,24 @@ func (f *Formatter) formatPromptOnly(w io.Writer, output *Output) error {
 	return err
 }
 
+// promptHeader returns the commented traceability header for prompt-only output.
+func promptHeader(output *Output) string {
+	head := output.HeadCommit
+	if head == "" {
+		head = "working tree"
+	}
+
+	var sb strings.Builder
+
+	if output.SessionID > 0 {
+		sb.WriteString(fmt.Sprintf("# creareview session: %d\n", output.SessionID))
+	}
+	sb.WriteString(fmt.Sprintf("# base: %s\n", output.BaseCommit))
+	sb.WriteString(fmt.Sprintf("# head: %s\n\n", head))
+
+	return sb.String()
+}
+
 // severityIcon returns an icon for the severity level.
 func (f *Formatter) severityIcon(severity string) string {
 	if f.noColor {
@@ -215,8 +405,9 @@ func (f *Formatter) severityIcon(severity string) string {
 	}
 }
 
-// buildSummary creates a human-readable summary of findings.
-func buildSummary(findings []session.Finding) string {
+// buildSummary creates a human-readable summary of findings,
+// noting any omitted by a findings cap.
+func buildSummary(findings []session.Finding, omitted int) string {
 	if len(findings) == 0 {
 		return "No issues found"
 	}
@@ -227,104 +418,82 @@ func buildSummary(findings []session.Finding) string {
 	}
 
 	var parts []string
-	categories := []string{"bug", "security", "performance", "style", "testing"}
 
-	for _, cat := range categories {
-		if count := counts[cat]; count > 0 {
-			parts = append(parts, fmt.Sprintf("%d %s", count, pluralize(cat, count)))
-		}
+	for _, cat := range summaryCategories(counts) {
+		count := counts[cat]
+		parts = append(parts, fmt.Sprintf("%d %s", count, pluralize(cat, count)))
 	}
 
 	total := len(findings)
 
-	return fmt.Sprintf("Found %d %s: %s",
+	summary := fmt.Sprintf("Found %d %s: %s",
 		total,
 		pluralize("issue", total),
 		strings.Join(parts, ", "))
-}
 
-// pluralize adds 's' for plural.
-func pluralize(word string, count int) string {
-	if count == 1 {
-		return word
+	if omitted > 0 {
+		summary += fmt.Sprintf(" (%d additional %s omitted)", omitted, pluralize("finding", omitted))
 	}
 
-	return word + "s"
+	return summary
 }
 
-// buildImplementationPrompt creates a prompt for crea-pipe to fix issues.
-func buildImplementationPrompt(findings []session.Finding) string {
-	var sb strings.Builder
-
-	sb.WriteString("Fix the following code review issues:\n\n")
+// knownCategories are the built-in finding categories, listed first in summaries.
+var knownCategories = []string{"bug", "security", "performance", "style", "testing"}
 
-	for i, f := range findings {
-		sb.WriteString(fmt.Sprintf("%d. [%s] %s:%d\n",
-			i+1, strings.ToUpper(f.Category), f.File, f.Line))
-		sb.WriteString(fmt.Sprintf("   Issue: %s\n", f.Description))
+// summaryCategories returns the categories present in counts: known categories
+// in their fixed order, then any others alphabetically. Blank categories are skipped.
+func summaryCategories(counts map[string]int) []string {
+	var ordered []string
 
-		if f.SuggestedFix != "" {
-			sb.WriteString(fmt.Sprintf("   Fix: %s\n", f.SuggestedFix))
+	for _, cat := range knownCategories {
+		if counts[cat] > 0 {
+			ordered = append(ordered, cat)
 		}
-
-		sb.WriteString("\n")
 	}
 
-	sb.WriteString("Apply the fixes while maintaining code style and ensuring tests still pass.\n")
+	for _, cat := range slices.Sorted(maps.Keys(counts)) {
+		if cat != "" && !slices.Contains(knownCategories, cat) {
+			ordered = append(ordered, cat)
+		}
+	}
 
-	return sb.String()
+	return ordered
 }
 
-// FormatSessionList formats a list of sessions.
-func FormatSessionList(w io.Writer, sessions []*session.Session) error {
-	if len(sessions) == 0 {
-		_, err := fmt.Fprintln(w, "No review sessions found.")
-
-		return err
-	}
-
-	if _, err := fmt.Fprintln(w, "Review Sessions"); err != nil {
-		return err
+// pluralize returns word in the plural unless count is 1: "ies" replaces a
+// "y" after a consonant, "es" follows s, x, z, ch and sh, and "s" follows
+// anything else. Categories are user-defined, so this covers the regular cases.
+func pluralize(word string, count int) string {
+	if count == 1 {
+		return word
 	}
 
-	if _, err := fmt.Fprintln(w, "==============="); err != nil {
-		return err
+	switch {
+	case len(word) > 1 && strings.HasSuffix(word, "y") && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
 	}
+}
 
-	if _, err := fmt.Fprintln(w); err != nil {
-		return err
+// idSuffix returns " (id X)" for a finding ID, or "" when there is none.
+func idSuffix(id string) string {
+	if id == "" {
+		return ""
 	}
 
-	for _, sess := range sessions {
-		status := string(sess.Status)
-		if sess.FilesRemaining > 0 {
-			status = fmt.Sprintf("%s (%d remaining)", status, sess.FilesRemaining)
-		}
-
-		baseCommit := sess.BaseCommit
-		if len(baseCommit) > 7 {
-			baseCommit = baseCommit[:7]
-		}
-
-		if _, err := fmt.Fprintf(w, "Session %d: %d files, %s, base=%s\n",
-			sess.ID, sess.FilesReviewed, sess.CreatedAt.Format("2006-01-02 15:04"), baseCommit); err != nil {
-			return err
-		}
-
-		if sess.ContinuedFrom > 0 {
-			if _, err := fmt.Fprintf(w, "  └─ continued from session %d\n", sess.ContinuedFrom); err != nil {
-				return err
-			}
-		}
-
-		if _, err := fmt.Fprintf(w, "  └─ status: %s, findings: %d\n", status, len(sess.Findings)); err != nil {
-			return err
-		}
+	return " (id " + id + ")"
+}
 
-		if _, err := fmt.Fprintln(w); err != nil {
-			return err
-		}
-	}
+// buildImplementationPrompt creates a prompt for crea-pipe to fix issues,
+// from DefaultFixPromptTemplate.
+func buildImplementationPrompt(findings []session.Finding) string {
+	// The default template is validated at init and renders any findings
+	prompt, _ := RenderFixPrompt(defaultFixPrompt, findings)
 
-	return nil
+	return prompt
 }
@@ -3,8 +3,8 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
-	"time"
 
 	"github.com/crealfy/crea-review/pkg/review"
 	"github.com/crealfy/crea-review/pkg/session"
@@ -30,6 +30,7 @@ func TestFormatJSON(t *testing.T) {
 		TotalFilesInDiff: 10,
 		FilesReviewed:    5,
 		FilesRemaining:   5,
+		Files:            []string{"main.go", `pkg\util.go`},
 	}
 
 	var buf bytes.Buffer
@@ -53,259 +54,160 @@ func TestFormatJSON(t *testing.T) {
 	if len(output.Findings) != 1 {
 		t.Errorf("len(Findings) = %d, want 1", len(output.Findings))
 	}
+	if output.RemainingFiles != 5 || output.Complete {
+		t.Errorf("RemainingFiles = %d, Complete = %v, want 5 and false", output.RemainingFiles, output.Complete)
+	}
+	if got := strings.Join(output.ReviewedFilePaths, ","); got != "main.go,pkg/util.go" {
+		t.Errorf("ReviewedFilePaths = %q, want %q", got, "main.go,pkg/util.go")
+	}
 }
 
-func TestFormatPlain(t *testing.T) {
-	formatter := NewFormatter(FormatPlain)
+func TestFormatJSONComplete(t *testing.T) {
+	var buf bytes.Buffer
 
-	result := &review.Result{
-		Findings: []session.Finding{
-			{
-				File:        "main.go",
-				Line:        10,
-				Severity:    "error",
-				Category:    "bug",
-				Description: "Null pointer dereference",
-			},
-		},
+	sess := &session.Session{ID: 3, TotalFilesInDiff: 4, FilesReviewed: 4}
+	if err := NewFormatter(FormatJSON).Format(&buf, &review.Result{}, sess); err != nil {
+		t.Fatalf("Format() error = %v", err)
 	}
 
-	var buf bytes.Buffer
-	err := formatter.Format(&buf, result, nil)
-	if err != nil {
-		t.Fatalf("Format() error = %v", err)
+	// Both keys must be present even at their zero values
+	for _, key := range []string{`"remaining_files": 0`, `"complete": true`} {
+		if !strings.Contains(buf.String(), key) {
+			t.Errorf("output missing %s:\n%s", key, buf.String())
+		}
 	}
+}
 
-	output := buf.String()
+func TestFormatJSONWithoutSession(t *testing.T) {
+	var buf bytes.Buffer
 
-	if !contains(output, "Code Review Results") {
-		t.Error("output should contain header")
+	// --no-session runs build a session in memory that never gets an ID
+	sess := &session.Session{TotalFilesInDiff: 4, FilesReviewed: 2, FilesRemaining: 2}
+	if err := NewFormatter(FormatJSON).Format(&buf, &review.Result{}, sess); err != nil {
+		t.Fatalf("Format() error = %v", err)
 	}
-	if !contains(output, "main.go:10") {
-		t.Error("output should contain file location")
+
+	if strings.Contains(buf.String(), "session_id") {
+		t.Errorf("output has session_id without a session:\n%s", buf.String())
 	}
-	if !contains(output, "Null pointer dereference") {
-		t.Error("output should contain description")
+	if !strings.Contains(buf.String(), `"remaining_files": 2`) {
+		t.Errorf("output missing remaining_files:\n%s", buf.String())
 	}
 }
 
-func TestFormatPromptOnly(t *testing.T) {
-	formatter := NewFormatter(FormatPromptOnly)
-
+func TestFormatJSONCompact(t *testing.T) {
 	result := &review.Result{
 		Findings: []session.Finding{
-			{
-				File:         "main.go",
-				Line:         10,
-				Severity:     "error",
-				Category:     "security",
-				Description:  "SQL injection",
-				SuggestedFix: "Use parameterized queries",
-			},
+			{File: "main.go", Line: 10, Severity: "error", Category: "bug", Description: "test issue"},
 		},
 	}
 
 	var buf bytes.Buffer
-	err := formatter.Format(&buf, result, nil)
-	if err != nil {
+	if err := NewFormatter(FormatJSON).WithCompactJSON().Format(&buf, result, nil); err != nil {
 		t.Fatalf("Format() error = %v", err)
 	}
 
-	output := buf.String()
-
-	if !contains(output, "Fix the following") {
-		t.Error("output should contain fix instructions")
-	}
-	if !contains(output, "SQL injection") {
-		t.Error("output should contain issue description")
-	}
-	if !contains(output, "parameterized queries") {
-		t.Error("output should contain suggested fix")
-	}
-}
-
-func TestFormatPromptOnlyNoFindings(t *testing.T) {
-	formatter := NewFormatter(FormatPromptOnly)
-
-	result := &review.Result{
-		Findings: []session.Finding{},
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+		t.Errorf("compact JSON spans %d lines, want 1:\n%s", lines, buf.String())
 	}
 
-	var buf bytes.Buffer
-	err := formatter.Format(&buf, result, nil)
-	if err != nil {
-		t.Fatalf("Format() error = %v", err)
+	var output Output
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
 	}
 
-	output := buf.String()
-
-	if !contains(output, "No issues found") {
-		t.Error("output should indicate no issues")
+	if len(output.Findings) != 1 {
+		t.Errorf("len(Findings) = %d, want 1", len(output.Findings))
 	}
 }
 
-func TestBuildSummary(t *testing.T) {
-	tests := []struct {
-		findings []session.Finding
-		expected string
-	}{
-		{
-			findings: nil,
-			expected: "No issues found",
-		},
-		{
-			findings: []session.Finding{{Category: "bug"}},
-			expected: "Found 1 issue: 1 bug",
-		},
-		{
-			findings: []session.Finding{
-				{Category: "bug"},
-				{Category: "security"},
-				{Category: "security"},
-			},
-			expected: "Found 3 issues: 1 bug, 2 securitys",
+func TestFindingIDsInFormats(t *testing.T) {
+	result := &review.Result{
+		Findings: []session.Finding{
+			{ID: "a1b2c3d4", File: "main.go", Line: 10, Severity: "error", Category: "bug", Description: "nil deref"},
 		},
 	}
 
-	for _, tt := range tests {
-		summary := buildSummary(tt.findings)
-		if summary != tt.expected {
-			t.Errorf("buildSummary() = %q, want %q", summary, tt.expected)
+	for format, want := range map[Format]string{
+		FormatJSON:       `"id": "a1b2c3d4"`,
+		FormatJSONL:      `"id":"a1b2c3d4"`,
+		FormatPlain:      "ID: a1b2c3d4",
+		FormatPromptOnly: "main.go:10 (id a1b2c3d4)",
+		FormatCheckstyle: "nil deref (id a1b2c3d4)",
+	} {
+		var buf bytes.Buffer
+		if err := NewFormatter(format).Format(&buf, result, nil); err != nil {
+			t.Fatalf("%s: Format() error = %v", format, err)
 		}
-	}
-}
 
-func TestPluralize(t *testing.T) {
-	tests := []struct {
-		word     string
-		count    int
-		expected string
-	}{
-		{"bug", 1, "bug"},
-		{"bug", 2, "bugs"},
-		{"issue", 0, "issues"},
-		{"issue", 1, "issue"},
-	}
-
-	for _, tt := range tests {
-		got := pluralize(tt.word, tt.count)
-		if got != tt.expected {
-			t.Errorf("pluralize(%q, %d) = %q, want %q", tt.word, tt.count, got, tt.expected)
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%s output missing %q:\n%s", format, want, buf.String())
 		}
 	}
 }
 
-func TestSeverityIcon(t *testing.T) {
-	// With colors
-	formatter := NewFormatter(FormatPlain)
-
-	if icon := formatter.severityIcon("error"); icon != "❌" {
-		t.Errorf("severityIcon(error) = %q, want ❌", icon)
-	}
-	if icon := formatter.severityIcon("warning"); icon != "⚠️" {
-		t.Errorf("severityIcon(warning) = %q, want ⚠️", icon)
+func TestBuildOutputPartial(t *testing.T) {
+	result := &review.Result{
+		Findings: []session.Finding{{File: "main.go", Line: 1, Severity: "error"}},
+		Partial:  true,
 	}
-	if icon := formatter.severityIcon("suggestion"); icon != "💡" {
-		t.Errorf("severityIcon(suggestion) = %q, want 💡", icon)
+
+	out := BuildOutput(result, &session.Session{ID: 3})
+	if !out.Partial || out.Complete {
+		t.Errorf("Partial = %v, Complete = %v, want partial and incomplete", out.Partial, out.Complete)
 	}
 
-	// Without colors
-	formatterNoColor := NewFormatter(FormatPlain).WithNoColor()
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatJSON).Format(&buf, &review.Result{}, nil); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
 
-	if icon := formatterNoColor.severityIcon("error"); icon != "[X]" {
-		t.Errorf("severityIcon(error) no-color = %q, want [X]", icon)
+	if strings.Contains(buf.String(), "partial") {
+		t.Errorf("complete review output mentions partial:\n%s", buf.String())
 	}
 }
 
-func TestBuildImplementationPrompt(t *testing.T) {
-	findings := []session.Finding{
-		{
-			File:         "main.go",
-			Line:         10,
-			Category:     "security",
-			Description:  "SQL injection vulnerability",
-			SuggestedFix: "Use parameterized queries",
+func TestRenderMultipleFormats(t *testing.T) {
+	result := &review.Result{
+		Findings: []session.Finding{
+			{File: "main.go", Line: 10, Severity: "warning", Category: "bug", Description: "test issue"},
 		},
 	}
 
-	prompt := buildImplementationPrompt(findings)
+	out := BuildOutput(result, &session.Session{ID: 2})
 
-	if !contains(prompt, "Fix the following") {
-		t.Error("prompt should contain fix instructions")
-	}
-	if !contains(prompt, "[SECURITY]") {
-		t.Error("prompt should contain category")
-	}
-	if !contains(prompt, "main.go:10") {
-		t.Error("prompt should contain file location")
-	}
-	if !contains(prompt, "SQL injection") {
-		t.Error("prompt should contain description")
-	}
-	if !contains(prompt, "parameterized queries") {
-		t.Error("prompt should contain suggested fix")
+	for _, format := range []Format{FormatJSON, FormatPlain, FormatCheckstyle, FormatJSONL} {
+		var buf bytes.Buffer
+		if err := NewFormatter(format).Render(&buf, out); err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+
+		if !strings.Contains(buf.String(), "test issue") {
+			t.Errorf("Render(%s) output missing finding:\n%s", format, buf.String())
+		}
 	}
 }
 
-func TestFormatSessionList(t *testing.T) {
-	sessions := []*session.Session{
-		{
-			ID:             1,
-			CreatedAt:      time.Date(2026, 2, 11, 10, 30, 0, 0, time.UTC),
-			BaseCommit:     "abc1234567890",
-			FilesReviewed:  50,
-			FilesRemaining: 0,
-			Status:         session.StatusCompleted,
-			Findings:       []session.Finding{{}, {}},
-		},
-		{
-			ID:             2,
-			CreatedAt:      time.Date(2026, 2, 11, 11, 0, 0, 0, time.UTC),
-			BaseCommit:     "def4567890123",
-			FilesReviewed:  50,
-			FilesRemaining: 47,
-			Status:         session.StatusInProgress,
-			ContinuedFrom:  1,
-			Findings:       []session.Finding{{}},
-		},
-	}
+func TestFormatSuggestedReviewers(t *testing.T) {
+	out := BuildOutput(&review.Result{}, nil)
+	out.SuggestedReviewers = []string{"@alice", "@crealfy/security-team"}
 
-	var buf bytes.Buffer
-	err := FormatSessionList(&buf, sessions)
-	if err != nil {
-		t.Fatalf("FormatSessionList() error = %v", err)
+	var plain bytes.Buffer
+	if err := NewFormatter(FormatPlain).Render(&plain, out); err != nil {
+		t.Fatalf("Render(plain) error = %v", err)
 	}
 
-	output := buf.String()
-
-	if !contains(output, "Review Sessions") {
-		t.Error("output should contain header")
-	}
-	if !contains(output, "Session 1") {
-		t.Error("output should contain session 1")
-	}
-	if !contains(output, "Session 2") {
-		t.Error("output should contain session 2")
-	}
-	if !contains(output, "continued from session 1") {
-		t.Error("output should show continuation")
-	}
-	if !contains(output, "47 remaining") {
-		t.Error("output should show remaining files")
+	if !contains(plain.String(), "Suggested reviewers: @alice, @crealfy/security-team\n") {
+		t.Errorf("plain output missing reviewers:\n%s", plain.String())
 	}
-}
 
-func TestFormatSessionListEmpty(t *testing.T) {
-	var buf bytes.Buffer
-	err := FormatSessionList(&buf, nil)
-	if err != nil {
-		t.Fatalf("FormatSessionList() error = %v", err)
+	var jsonOut bytes.Buffer
+	if err := NewFormatter(FormatJSON).Render(&jsonOut, out); err != nil {
+		t.Fatalf("Render(json) error = %v", err)
 	}
 
-	output := buf.String()
-
-	if !contains(output, "No review sessions found") {
-		t.Error("output should indicate no sessions")
+	if !contains(jsonOut.String(), `"suggested_reviewers": [`) {
+		t.Errorf("JSON output missing suggested_reviewers:\n%s", jsonOut.String())
 	}
 }
 
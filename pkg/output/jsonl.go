@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// jsonlMeta is the first line of JSON-lines output: the review metadata without findings.
+type jsonlMeta struct {
+	Type            string   `json:"type"`
+	SessionID       int      `json:"session_id,omitempty"`
+	BaseCommit      string   `json:"base_commit,omitempty"`
+	HeadCommit      string   `json:"head_commit,omitempty"`
+	TotalFiles      int      `json:"total_files"`
+	ReviewedFiles   int      `json:"reviewed_files"`
+	RemainingFiles  int      `json:"remaining_files"`
+	Complete        bool     `json:"complete"`
+	PrimaryLanguage string   `json:"primary_language,omitempty"`
+	Findings        int      `json:"findings"`
+	OmittedFindings int      `json:"omitted_findings,omitempty"`
+	Summary         string   `json:"summary"`
+	Reviewers       []string `json:"suggested_reviewers,omitempty"`
+	Cost            float64  `json:"cost,omitempty"`
+	Model           string   `json:"model,omitempty"`
+	TokenUsage      string   `json:"token_usage,omitempty"`
+}
+
+// jsonlFinding is one finding line of JSON-lines output.
+type jsonlFinding struct {
+	Type string `json:"type"`
+	session.Finding
+}
+
+// formatJSONL writes a meta line followed by one line per finding, each a
+// standalone JSON object tagged with "type" ("meta" or "finding").
+func (f *Formatter) formatJSONL(w io.Writer, output *Output) error {
+	enc := json.NewEncoder(w)
+
+	meta := jsonlMeta{
+		Type:            "meta",
+		SessionID:       output.SessionID,
+		BaseCommit:      output.BaseCommit,
+		HeadCommit:      output.HeadCommit,
+		TotalFiles:      output.TotalFiles,
+		ReviewedFiles:   output.ReviewedFiles,
+		RemainingFiles:  output.RemainingFiles,
+		Complete:        output.Complete,
+		PrimaryLanguage: output.PrimaryLanguage,
+		Findings:        len(output.Findings),
+		OmittedFindings: output.OmittedFindings,
+		Summary:         output.Summary,
+		Reviewers:       output.SuggestedReviewers,
+		Cost:            output.Cost,
+		Model:           output.Model,
+		TokenUsage:      output.TokenUsage,
+	}
+	if err := enc.Encode(meta); err != nil {
+		return err
+	}
+
+	for _, finding := range output.Findings {
+		if err := enc.Encode(jsonlFinding{Type: "finding", Finding: finding}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
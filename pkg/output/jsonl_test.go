@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestFormatJSONL(t *testing.T) {
+	result := &review.Result{
+		Findings: []session.Finding{
+			{File: "auth.go", Line: 10, Severity: "error", Category: "security", Description: "SQL injection"},
+			{File: `pkg\main.go`, Line: 3, Severity: "suggestion", Category: "style", Description: "multi\nline"},
+		},
+		Model: "sonnet",
+	}
+	sess := &session.Session{ID: 4, TotalFilesInDiff: 5, FilesReviewed: 2, FilesRemaining: 3}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatJSONL).Format(&buf, result, sess); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var lines []map[string]any
+
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line %d does not parse: %v\n%s", len(lines)+1, err, scanner.Text())
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3 (meta + 2 findings)", len(lines))
+	}
+
+	meta := lines[0]
+	if meta["type"] != "meta" || meta["session_id"] != float64(4) || meta["remaining_files"] != float64(3) {
+		t.Errorf("meta = %v, want type meta, session 4, 3 remaining", meta)
+	}
+	if meta["findings"] != float64(2) || meta["complete"] != false || meta["model"] != "sonnet" {
+		t.Errorf("meta = %v, want 2 findings, incomplete, model sonnet", meta)
+	}
+
+	if lines[1]["type"] != "finding" || lines[1]["file"] != "auth.go" || lines[1]["severity"] != "error" {
+		t.Errorf("lines[1] = %v, want auth.go error finding", lines[1])
+	}
+	if lines[2]["file"] != "pkg/main.go" || lines[2]["description"] != "multi\nline" {
+		t.Errorf("lines[2] = %v, want normalized path and intact description", lines[2])
+	}
+}
+
+func TestFormatJSONLNoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatJSONL).Format(&buf, &review.Result{}, nil); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	var meta map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &meta); err != nil {
+		t.Fatalf("output is not a single JSON line: %v\n%s", err, buf.String())
+	}
+
+	if meta["type"] != "meta" || meta["findings"] != float64(0) {
+		t.Errorf("meta = %v, want type meta with 0 findings", meta)
+	}
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"path"
+	"strings"
+)
+
+// RelativeTo returns a copy of output with finding and reviewed file paths shown relative to base,
+// a repo-relative directory. Paths outside base get a "../" prefix.
+// The original output (and the session) keep repo-relative paths.
+func RelativeTo(output *Output, base string) *Output {
+	base = strings.Trim(path.Clean(base), "/")
+	if base == "" || base == "." {
+		return output
+	}
+
+	rebased := *output
+	rebased.Findings = normalizeFindingPaths(output.Findings)
+
+	for i := range rebased.Findings {
+		rebased.Findings[i].File = relPath(base, rebased.Findings[i].File)
+	}
+
+	rebased.ReviewedFilePaths = normalizePaths(output.ReviewedFilePaths)
+	for i := range rebased.ReviewedFilePaths {
+		rebased.ReviewedFilePaths[i] = relPath(base, rebased.ReviewedFilePaths[i])
+	}
+
+	if len(rebased.Findings) > 0 {
+		rebased.ImplementationPrompt = buildImplementationPrompt(rebased.Findings)
+	}
+
+	return &rebased
+}
+
+// relPath returns target relative to base; both are clean, slash-separated and repo-relative.
+func relPath(base, target string) string {
+	baseParts := strings.Split(base, "/")
+	targetParts := strings.Split(path.Clean(target), "/")
+
+	common := 0
+	for common < len(baseParts) && common < len(targetParts)-1 && baseParts[common] == targetParts[common] {
+		common++
+	}
+
+	parts := make([]string, 0, len(baseParts)-common+len(targetParts)-common)
+	for range baseParts[common:] {
+		parts = append(parts, "..")
+	}
+
+	parts = append(parts, targetParts[common:]...)
+
+	return strings.Join(parts, "/")
+}
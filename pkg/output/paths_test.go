@@ -0,0 +1,51 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestRelativeTo(t *testing.T) {
+	out := &Output{
+		Findings: []session.Finding{
+			{File: "services/api/handler.go", Line: 1, Category: "bug", Description: "a"},
+			{File: "services/api/internal/db.go", Line: 2, Category: "bug", Description: "b"},
+			{File: "services/web/app.js", Line: 3, Category: "bug", Description: "c"},
+			{File: "go.mod", Line: 4, Category: "bug", Description: "d"},
+		},
+		ReviewedFilePaths: []string{"services/api/handler.go", "go.mod"},
+	}
+
+	got := RelativeTo(out, "services/api/")
+
+	want := []string{"handler.go", "internal/db.go", "../web/app.js", "../../go.mod"}
+	for i, w := range want {
+		if got.Findings[i].File != w {
+			t.Errorf("Findings[%d].File = %q, want %q", i, got.Findings[i].File, w)
+		}
+	}
+
+	if got.ReviewedFilePaths[0] != "handler.go" || got.ReviewedFilePaths[1] != "../../go.mod" {
+		t.Errorf("ReviewedFilePaths = %v, want [handler.go ../../go.mod]", got.ReviewedFilePaths)
+	}
+
+	if !contains(got.ImplementationPrompt, "internal/db.go:2") {
+		t.Errorf("ImplementationPrompt should use displayed paths:\n%s", got.ImplementationPrompt)
+	}
+
+	if out.Findings[0].File != "services/api/handler.go" {
+		t.Error("RelativeTo must not modify the original output")
+	}
+
+	if RelativeTo(out, ".") != out {
+		t.Error("repo root base should return output unchanged")
+	}
+}
+
+func TestRelPathSameNamePrefix(t *testing.T) {
+	// "api" must not match a sibling "api2" directory
+	if got := relPath("svc/api", "svc/api2/x.go"); got != "../api2/x.go" {
+		t.Errorf("relPath() = %q, want ../api2/x.go", got)
+	}
+}
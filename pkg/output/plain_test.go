@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestFormatPlain(t *testing.T) {
+	formatter := NewFormatter(FormatPlain)
+
+	result := &review.Result{
+		Findings: []session.Finding{
+			{
+				File:        "main.go",
+				Line:        10,
+				Severity:    "error",
+				Category:    "bug",
+				Description: "Null pointer dereference",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := formatter.Format(&buf, result, nil)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	output := buf.String()
+
+	if !contains(output, "Code Review Results") {
+		t.Error("output should contain header")
+	}
+	if !contains(output, "main.go:10") {
+		t.Error("output should contain file location")
+	}
+	if !contains(output, "Null pointer dereference") {
+		t.Error("output should contain description")
+	}
+}
+
+func TestFormatDocURL(t *testing.T) {
+	result := &review.Result{
+		Findings: []session.Finding{
+			{File: "main.go", Line: 10, Severity: "error", Category: "security", Description: "SQL injection", DocURL: "https://example.com/sql"},
+		},
+	}
+
+	for _, format := range []Format{FormatPlain, FormatPromptOnly} {
+		var buf bytes.Buffer
+		if err := NewFormatter(format).Format(&buf, result, nil); err != nil {
+			t.Fatalf("Format(%s) error = %v", format, err)
+		}
+
+		if !contains(buf.String(), "   Docs: https://example.com/sql\n") {
+			t.Errorf("%s output missing doc link:\n%s", format, buf.String())
+		}
+	}
+}
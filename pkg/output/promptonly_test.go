@@ -0,0 +1,123 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestFormatPromptOnly(t *testing.T) {
+	formatter := NewFormatter(FormatPromptOnly)
+
+	result := &review.Result{
+		Findings: []session.Finding{
+			{
+				File:         "main.go",
+				Line:         10,
+				Severity:     "error",
+				Category:     "security",
+				Description:  "SQL injection",
+				SuggestedFix: "Use parameterized queries",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := formatter.Format(&buf, result, nil)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	output := buf.String()
+
+	if !contains(output, "Fix the following") {
+		t.Error("output should contain fix instructions")
+	}
+	if !contains(output, "SQL injection") {
+		t.Error("output should contain issue description")
+	}
+	if !contains(output, "parameterized queries") {
+		t.Error("output should contain suggested fix")
+	}
+}
+
+func TestFormatPromptOnlyHeader(t *testing.T) {
+	result := &review.Result{
+		Findings: []session.Finding{
+			{File: "main.go", Line: 10, Severity: "error", Category: "bug", Description: "nil deref"},
+		},
+	}
+	sess := &session.Session{ID: 7, BaseCommit: "abc123"}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatPromptOnly).WithPromptHeader().Format(&buf, result, sess); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	want := "# creareview session: 7\n# base: abc123\n# head: working tree\n\nFix the following"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("output = %q, want prefix %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := NewFormatter(FormatPromptOnly).Format(&buf, result, sess); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	if strings.HasPrefix(buf.String(), "#") {
+		t.Error("prompt-only output should be headerless by default")
+	}
+}
+
+func TestFormatPromptOnlyNoFindings(t *testing.T) {
+	formatter := NewFormatter(FormatPromptOnly)
+
+	result := &review.Result{
+		Findings: []session.Finding{},
+	}
+
+	var buf bytes.Buffer
+	err := formatter.Format(&buf, result, nil)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	output := buf.String()
+
+	if !contains(output, "No issues found") {
+		t.Error("output should indicate no issues")
+	}
+}
+
+func TestBuildImplementationPrompt(t *testing.T) {
+	findings := []session.Finding{
+		{
+			File:         "main.go",
+			Line:         10,
+			Category:     "security",
+			Description:  "SQL injection vulnerability",
+			SuggestedFix: "Use parameterized queries",
+		},
+	}
+
+	prompt := buildImplementationPrompt(findings)
+
+	if !contains(prompt, "Fix the following") {
+		t.Error("prompt should contain fix instructions")
+	}
+	if !contains(prompt, "[SECURITY]") {
+		t.Error("prompt should contain category")
+	}
+	if !contains(prompt, "main.go:10") {
+		t.Error("prompt should contain file location")
+	}
+	if !contains(prompt, "SQL injection") {
+		t.Error("prompt should contain description")
+	}
+	if !contains(prompt, "parameterized queries") {
+		t.Error("prompt should contain suggested fix")
+	}
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// FormatSessionList formats a list of sessions.
+func FormatSessionList(w io.Writer, sessions []*session.Session) error {
+	if len(sessions) == 0 {
+		_, err := fmt.Fprintln(w, "No review sessions found.")
+
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "Review Sessions"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "==============="); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	for _, sess := range sessions {
+		status := string(sess.Status)
+		if sess.FilesRemaining > 0 {
+			status = fmt.Sprintf("%s (%d remaining)", status, sess.FilesRemaining)
+		}
+
+		baseCommit := sess.BaseCommit
+		if len(baseCommit) > 7 {
+			baseCommit = baseCommit[:7]
+		}
+
+		if _, err := fmt.Fprintf(w, "Session %d: %d files, %s, base=%s\n",
+			sess.ID, sess.FilesReviewed, sess.CreatedAt.Format("2006-01-02 15:04"), baseCommit); err != nil {
+			return err
+		}
+
+		if sess.ContinuedFrom > 0 {
+			if _, err := fmt.Fprintf(w, "  └─ continued from session %d\n", sess.ContinuedFrom); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "  └─ status: %s, findings: %d\n", status, sess.FindingCount); err != nil {
+			return err
+		}
+
+		if len(sess.Tags) > 0 {
+			if _, err := fmt.Fprintf(w, "  └─ tags: %s\n", strings.Join(sess.Tags, ", ")); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormatSessionListJSON writes sessions as a JSON array, including each
+// session's invocation, for --list-sessions --format json.
+func (f *Formatter) FormatSessionListJSON(w io.Writer, sessions []*session.Session) error {
+	if sessions == nil {
+		sessions = []*session.Session{}
+	}
+
+	enc := json.NewEncoder(w)
+	if !f.compactJSON {
+		enc.SetIndent("", "  ")
+	}
+
+	return enc.Encode(sessions)
+}
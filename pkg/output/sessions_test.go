@@ -0,0 +1,115 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestFormatSessionList(t *testing.T) {
+	sessions := []*session.Session{
+		{
+			ID:             1,
+			CreatedAt:      time.Date(2026, 2, 11, 10, 30, 0, 0, time.UTC),
+			BaseCommit:     "abc1234567890",
+			FilesReviewed:  50,
+			FilesRemaining: 0,
+			Status:         session.StatusCompleted,
+			FindingCount:   2,
+		},
+		{
+			ID:             2,
+			CreatedAt:      time.Date(2026, 2, 11, 11, 0, 0, 0, time.UTC),
+			BaseCommit:     "def4567890123",
+			FilesReviewed:  50,
+			FilesRemaining: 47,
+			Status:         session.StatusInProgress,
+			ContinuedFrom:  1,
+			Tags:           []string{"security", "release"},
+			FindingCount:   1,
+		},
+	}
+
+	var buf bytes.Buffer
+	err := FormatSessionList(&buf, sessions)
+	if err != nil {
+		t.Fatalf("FormatSessionList() error = %v", err)
+	}
+
+	output := buf.String()
+
+	if !contains(output, "Review Sessions") {
+		t.Error("output should contain header")
+	}
+	if !contains(output, "Session 1") {
+		t.Error("output should contain session 1")
+	}
+	if !contains(output, "Session 2") {
+		t.Error("output should contain session 2")
+	}
+	if !contains(output, "continued from session 1") {
+		t.Error("output should show continuation")
+	}
+	if !contains(output, "47 remaining") {
+		t.Error("output should show remaining files")
+	}
+	if !contains(output, "tags: security, release") {
+		t.Error("output should show tags")
+	}
+}
+
+func TestFormatSessionListJSON(t *testing.T) {
+	sessions := []*session.Session{
+		{
+			ID:     1,
+			Status: session.StatusCompleted,
+			Invocation: &session.Invocation{
+				Backend:  "claude",
+				Model:    "opus",
+				MaxFiles: 15,
+				EnvKeys:  []string{"API_TOKEN"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatJSON).FormatSessionListJSON(&buf, sessions); err != nil {
+		t.Fatalf("FormatSessionListJSON() error = %v", err)
+	}
+
+	var got []*session.Session
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0].Invocation == nil || got[0].Invocation.Model != "opus" {
+		t.Errorf("sessions = %+v, want one session with its invocation", got)
+	}
+
+	buf.Reset()
+	if err := NewFormatter(FormatJSON).FormatSessionListJSON(&buf, nil); err != nil {
+		t.Fatalf("FormatSessionListJSON(nil) error = %v", err)
+	}
+
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty list = %q, want []", buf.String())
+	}
+}
+
+func TestFormatSessionListEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	err := FormatSessionList(&buf, nil)
+	if err != nil {
+		t.Fatalf("FormatSessionList() error = %v", err)
+	}
+
+	output := buf.String()
+
+	if !contains(output, "No review sessions found") {
+		t.Error("output should indicate no sessions")
+	}
+}
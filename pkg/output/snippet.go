@@ -0,0 +1,32 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// writeSnippet writes the finding's source context, if any, with line
+// numbers and the flagged lines marked with ">".
+func writeSnippet(sb *strings.Builder, finding session.Finding) {
+	if finding.Context == nil {
+		return
+	}
+
+	last := finding.Context.StartLine + len(finding.Context.Lines) - 1
+	width := len(strconv.Itoa(last))
+	end := max(finding.EndLine, finding.Line)
+
+	for i, line := range finding.Context.Lines {
+		n := finding.Context.StartLine + i
+
+		marker := " "
+		if n >= finding.Line && n <= end {
+			marker = ">"
+		}
+
+		sb.WriteString(fmt.Sprintf("   %s %*d | %s\n", marker, width, n, line))
+	}
+}
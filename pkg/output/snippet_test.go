@@ -0,0 +1,49 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestWriteSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		finding session.Finding
+		want    string
+	}{
+		{
+			name:    "no context",
+			finding: session.Finding{Line: 3},
+			want:    "",
+		},
+		{
+			name: "single line marked",
+			finding: session.Finding{Line: 9, Context: &session.Snippet{
+				StartLine: 8,
+				Lines:     []string{"a", "b", "c"},
+			}},
+			want: "      8 | a\n   >  9 | b\n     10 | c\n",
+		},
+		{
+			name: "range marked",
+			finding: session.Finding{Line: 1, EndLine: 2, Context: &session.Snippet{
+				StartLine: 1,
+				Lines:     []string{"x", "y", "z"},
+			}},
+			want: "   > 1 | x\n   > 2 | y\n     3 | z\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			writeSnippet(&sb, tt.finding)
+
+			if sb.String() != tt.want {
+				t.Errorf("writeSnippet() =\n%q\nwant\n%q", sb.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestBuildSummary(t *testing.T) {
+	tests := []struct {
+		findings []session.Finding
+		omitted  int
+		expected string
+	}{
+		{
+			findings: nil,
+			expected: "No issues found",
+		},
+		{
+			findings: []session.Finding{{Category: "bug"}, {Category: "bug"}},
+			omitted:  5,
+			expected: "Found 2 issues: 2 bugs (5 additional findings omitted)",
+		},
+		{
+			findings: []session.Finding{{Category: "bug"}},
+			expected: "Found 1 issue: 1 bug",
+		},
+		{
+			findings: []session.Finding{
+				{Category: "bug"},
+				{Category: "security"},
+				{Category: "security"},
+			},
+			expected: "Found 3 issues: 1 bug, 2 securities",
+		},
+		{
+			findings: []session.Finding{
+				{Category: "accessibility"},
+				{Category: "style"},
+				{Category: "compliance"},
+				{Category: "accessibility"},
+				{Category: "bug"},
+			},
+			expected: "Found 5 issues: 1 bug, 1 style, 2 accessibilities, 1 compliance",
+		},
+	}
+
+	for _, tt := range tests {
+		summary := buildSummary(tt.findings, tt.omitted)
+		if summary != tt.expected {
+			t.Errorf("buildSummary() = %q, want %q", summary, tt.expected)
+		}
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		word     string
+		count    int
+		expected string
+	}{
+		{"bug", 1, "bug"},
+		{"bug", 2, "bugs"},
+		{"issue", 0, "issues"},
+		{"issue", 1, "issue"},
+		{"security", 2, "securities"},
+		{"accessibility", 3, "accessibilities"},
+		{"key", 2, "keys"},
+		{"process", 2, "processes"},
+		{"regex", 2, "regexes"},
+		{"patch", 2, "patches"},
+		{"y", 2, "ys"},
+	}
+
+	for _, tt := range tests {
+		got := pluralize(tt.word, tt.count)
+		if got != tt.expected {
+			t.Errorf("pluralize(%q, %d) = %q, want %q", tt.word, tt.count, got, tt.expected)
+		}
+	}
+}
+
+func TestSeverityIcon(t *testing.T) {
+	// With colors
+	formatter := NewFormatter(FormatPlain)
+
+	if icon := formatter.severityIcon("error"); icon != "❌" {
+		t.Errorf("severityIcon(error) = %q, want ❌", icon)
+	}
+	if icon := formatter.severityIcon("warning"); icon != "⚠️" {
+		t.Errorf("severityIcon(warning) = %q, want ⚠️", icon)
+	}
+	if icon := formatter.severityIcon("suggestion"); icon != "💡" {
+		t.Errorf("severityIcon(suggestion) = %q, want 💡", icon)
+	}
+
+	// Without colors
+	formatterNoColor := NewFormatter(FormatPlain).WithNoColor()
+
+	if icon := formatterNoColor.severityIcon("error"); icon != "[X]" {
+		t.Errorf("severityIcon(error) no-color = %q, want [X]", icon)
+	}
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// ParseFindingTemplate parses a text/template rendered once per finding.
+// Fields are those of session.Finding: .File, .Line, .EndLine, .Severity,
+// .Category, .Description, .SuggestedFix and .DocURL, plus .Location.
+// Unknown fields are reported here rather than at render time.
+func ParseFindingTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("finding").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse finding template: %w", err)
+	}
+
+	// Catch references to fields a finding doesn't have
+	if err := tmpl.Execute(io.Discard, session.Finding{}); err != nil {
+		return nil, fmt.Errorf("invalid finding template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// WithFindingTemplate renders each finding with tmpl instead of the format.
+func (f *Formatter) WithFindingTemplate(tmpl *template.Template) *Formatter {
+	f.findingTemplate = tmpl
+
+	return f
+}
+
+// formatFindingTemplate writes each finding rendered with the finding template,
+// one after another. Each rendering ends with a newline unless it already does.
+func (f *Formatter) formatFindingTemplate(w io.Writer, output *Output) error {
+	var sb strings.Builder
+
+	for _, finding := range output.Findings {
+		sb.Reset()
+
+		if err := f.findingTemplate.Execute(&sb, finding); err != nil {
+			return fmt.Errorf("render finding %s: %w", finding.Location(), err)
+		}
+
+		if !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteByte('\n')
+		}
+
+		if _, err := io.WriteString(w, sb.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
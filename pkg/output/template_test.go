@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/review"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestFindingTemplate(t *testing.T) {
+	tmpl, err := ParseFindingTemplate(`{{.Location}} [{{.Severity}}/{{.Category}}] {{.Description}}{{if .SuggestedFix}} -> {{.SuggestedFix}}{{end}}`)
+	if err != nil {
+		t.Fatalf("ParseFindingTemplate() error = %v", err)
+	}
+
+	result := &review.Result{
+		Findings: []session.Finding{
+			{File: "auth.go", Line: 10, Severity: "error", Category: "security", Description: "SQL injection", SuggestedFix: "use placeholders"},
+			{File: `pkg\main.go`, Line: 3, EndLine: 5, Severity: "suggestion", Category: "style", Description: "rename"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatJSON).WithFindingTemplate(tmpl).Format(&buf, result, nil); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	want := "auth.go:10 [error/security] SQL injection -> use placeholders\n" +
+		"pkg/main.go:3-5 [suggestion/style] rename\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestParseFindingTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"syntax error", "{{.File"},
+		{"unknown field", "{{.Path}}:{{.Line}}"},
+		{"unknown function", "{{upper .File}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFindingTemplate(tt.text); err == nil {
+				t.Errorf("ParseFindingTemplate(%q) expected error", tt.text)
+			}
+		})
+	}
+}
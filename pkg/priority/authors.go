@@ -0,0 +1,46 @@
+package priority
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crealfy/crea-pipe/pkg/git"
+)
+
+// fileAuthors returns the number of distinct commit authors of path up to HEAD.
+func fileAuthors(ctx context.Context, repoPath, path string) (int, error) {
+	commits, err := git.Log(ctx, repoPath, git.LogOptions{Path: path})
+	if err != nil {
+		return 0, fmt.Errorf("authors of %s: %w", path, err)
+	}
+
+	authors := make(map[string]bool)
+	for _, c := range commits {
+		authors[c.Author] = true
+	}
+
+	return len(authors), nil
+}
+
+// addAuthorScores adds the authors component to each score, normalized by
+// the most authors of any file in the batch.
+func (s *Scorer) addAuthorScores(scores []Score) {
+	if s.weights.Authors <= 0 {
+		return
+	}
+
+	maxAuthors := 0
+	for _, sc := range scores {
+		maxAuthors = max(maxAuthors, sc.Authors)
+	}
+
+	if maxAuthors == 0 {
+		return
+	}
+
+	for i := range scores {
+		score := float64(scores[i].Authors) / float64(maxAuthors) * 100 * s.weights.Authors
+		scores[i].Breakdown.AuthorsScore = score
+		scores[i].Total += score
+	}
+}
@@ -0,0 +1,76 @@
+package priority
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/crealfy/crea-review/internal/testutil"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestScoreFilesAuthors(t *testing.T) {
+	dir := testutil.GitRepo(t)
+
+	for _, c := range []struct{ file, author string }{
+		{"shared.go", "alice"},
+		{"shared.go", "bob"},
+		{"shared.go", "carol"},
+		{"shared.go", "alice"},
+		{"solo.go", "alice"},
+	} {
+		testutil.CommitAs(t, dir, c.author, c.file, map[string]string{c.file: c.author + "\n"})
+	}
+
+	files := []rcontext.FileContent{
+		{Path: "solo.go", LinesAdded: 10},
+		{Path: "shared.go", LinesAdded: 10},
+	}
+
+	t.Run("off by default", func(t *testing.T) {
+		scores, err := NewScorer(dir).ScoreFiles(context.Background(), files)
+		if err != nil {
+			t.Fatalf("ScoreFiles() error = %v", err)
+		}
+
+		for _, s := range scores {
+			if s.Authors != 0 || s.Breakdown.AuthorsScore != 0 {
+				t.Errorf("%s: Authors = %d, AuthorsScore = %v; want 0 without the weight", s.Path, s.Authors, s.Breakdown.AuthorsScore)
+			}
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		w := DefaultWeights()
+		w.Authors = 0.2
+
+		scores, err := NewScorer(dir).WithWeights(w).ScoreFiles(context.Background(), files)
+		if err != nil {
+			t.Fatalf("ScoreFiles() error = %v", err)
+		}
+
+		byPath := make(map[string]Score)
+		for _, s := range scores {
+			byPath[s.Path] = s
+		}
+
+		shared, solo := byPath["shared.go"], byPath["solo.go"]
+
+		if shared.Authors != 3 || solo.Authors != 1 {
+			t.Errorf("Authors = %d and %d, want 3 and 1", shared.Authors, solo.Authors)
+		}
+
+		// Normalized by the batch maximum of 3 authors
+		if shared.Breakdown.AuthorsScore != 20 {
+			t.Errorf("shared AuthorsScore = %v, want 20", shared.Breakdown.AuthorsScore)
+		}
+
+		if want := 20.0 / 3; math.Abs(solo.Breakdown.AuthorsScore-want) > 1e-9 {
+			t.Errorf("solo AuthorsScore = %v, want %v", solo.Breakdown.AuthorsScore, want)
+		}
+
+		if scores[0].Path != "shared.go" {
+			t.Errorf("top file = %s, want shared.go", scores[0].Path)
+		}
+	})
+}
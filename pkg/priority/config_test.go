@@ -0,0 +1,77 @@
+package priority
+
+import (
+	"context"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestDefaultWeights(t *testing.T) {
+	w := DefaultWeights()
+
+	total := w.LinesChanged + w.Criticality + w.Churn + w.TestCoverage + w.Recency
+	if total != 1.0 {
+		t.Errorf("weights sum = %f, want 1.0", total)
+	}
+
+	if w.LinesChanged != 0.30 {
+		t.Errorf("LinesChanged = %f, want 0.30", w.LinesChanged)
+	}
+	if w.Criticality != 0.25 {
+		t.Errorf("Criticality = %f, want 0.25", w.Criticality)
+	}
+}
+
+func TestScorerWithWeights(t *testing.T) {
+	customWeights := Weights{
+		LinesChanged: 0.5,
+		Criticality:  0.2,
+		Churn:        0.1,
+		TestCoverage: 0.1,
+		Recency:      0.1,
+	}
+
+	scorer := NewScorer("/test/repo").WithWeights(customWeights)
+
+	if scorer.weights != customWeights {
+		t.Error("custom weights not applied")
+	}
+}
+
+func TestScorerApplyConfig(t *testing.T) {
+	churn := 0.4
+
+	scorer := NewScorer("/test/repo")
+	err := scorer.ApplyConfig(&rcontext.Config{
+		CriticalPaths: []string{"(?i)/ledger/"},
+		Weights:       rcontext.WeightsConfig{Churn: &churn},
+	})
+	if err != nil {
+		t.Fatalf("ApplyConfig() error = %v", err)
+	}
+
+	if scorer.weights.Churn != 0.4 {
+		t.Errorf("Churn = %f, want 0.4", scorer.weights.Churn)
+	}
+	if scorer.weights.LinesChanged != DefaultWeights().LinesChanged {
+		t.Errorf("LinesChanged = %f, want default", scorer.weights.LinesChanged)
+	}
+
+	score := scorer.ScoreFile(context.Background(), rcontext.FileContent{Path: "pkg/ledger/entry.go"}, 1, nil)
+	if !score.IsCriticalPath {
+		t.Error("configured critical path should mark pkg/ledger/entry.go critical")
+	}
+
+	if !scorer.isCritical("pkg/auth/handler.go") {
+		t.Error("built-in critical paths should still apply")
+	}
+
+	if err := NewScorer("/test/repo").ApplyConfig(nil); err != nil {
+		t.Errorf("ApplyConfig(nil) error = %v", err)
+	}
+
+	if err := NewScorer("/test/repo").ApplyConfig(&rcontext.Config{CriticalPaths: []string{"("}}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
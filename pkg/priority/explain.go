@@ -0,0 +1,59 @@
+package priority
+
+import (
+	"fmt"
+	"strings"
+)
+
+// highChurn is the commit count from which churn is called "high".
+const highChurn = 20
+
+// Reasons returns short human-friendly reasons for the file's score, such
+// as "critical-path" or "high churn (32)", in a fixed order. Files with
+// nothing to review, such as pure renames, have none.
+func (s Score) Reasons() []string {
+	var reasons []string
+
+	if s.IsCriticalPath {
+		reasons = append(reasons, "critical-path")
+	}
+
+	if s.Total == 0 {
+		return reasons
+	}
+
+	switch {
+	case s.Hunks > 1:
+		reasons = append(reasons, fmt.Sprintf("%d lines in %d hunks", s.LinesChanged, s.Hunks))
+	case s.LinesChanged > 0:
+		reasons = append(reasons, fmt.Sprintf("%d lines changed", s.LinesChanged))
+	}
+
+	switch {
+	case s.ChurnCount >= highChurn:
+		reasons = append(reasons, fmt.Sprintf("high churn (%d)", s.ChurnCount))
+	case s.ChurnCount > 0:
+		reasons = append(reasons, fmt.Sprintf("churn (%d)", s.ChurnCount))
+	}
+
+	if s.Breakdown.TestCoverageScore > 0 {
+		reasons = append(reasons, "no tests")
+	}
+
+	if s.Breakdown.AuthorsScore > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d authors", s.Authors))
+	}
+
+	return reasons
+}
+
+// Explain returns a one-line rationale for the file's score, e.g.
+// "score 72.5: critical-path, 40 lines changed, high churn (32), no tests".
+func (s Score) Explain() string {
+	reasons := s.Reasons()
+	if len(reasons) == 0 {
+		return fmt.Sprintf("score %.1f: nothing to review", s.Total)
+	}
+
+	return fmt.Sprintf("score %.1f: %s", s.Total, strings.Join(reasons, ", "))
+}
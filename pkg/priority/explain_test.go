@@ -0,0 +1,63 @@
+package priority
+
+import "testing"
+
+func TestScoreExplain(t *testing.T) {
+	tests := []struct {
+		name  string
+		score Score
+		want  string
+	}{
+		{
+			name: "critical untested file with high churn",
+			score: Score{
+				Path:           "pkg/auth/login.go",
+				Total:          72.5,
+				LinesChanged:   40,
+				IsCriticalPath: true,
+				ChurnCount:     32,
+				Breakdown:      Breakdown{TestCoverageScore: 15, ChurnScore: 12.8},
+			},
+			want: "score 72.5: critical-path, 40 lines changed, high churn (32), no tests",
+		},
+		{
+			name: "tested file with some churn in several hunks",
+			score: Score{
+				Total:        20,
+				LinesChanged: 12,
+				Hunks:        3,
+				ChurnCount:   4,
+				HasTests:     true,
+			},
+			want: "score 20.0: 12 lines in 3 hunks, churn (4)",
+		},
+		{
+			name: "authors counted",
+			score: Score{
+				Total:        30,
+				LinesChanged: 5,
+				Authors:      6,
+				Breakdown:    Breakdown{AuthorsScore: 10},
+			},
+			want: "score 30.0: 5 lines changed, 6 authors",
+		},
+		{
+			name:  "pure rename on a critical path",
+			score: Score{IsCriticalPath: true},
+			want:  "score 0.0: critical-path",
+		},
+		{
+			name:  "nothing to review",
+			score: Score{},
+			want:  "score 0.0: nothing to review",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.score.Explain(); got != tt.want {
+				t.Errorf("Explain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package priority
+
+import (
+	"context"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestScoreFilesByHunks(t *testing.T) {
+	files := []rcontext.FileContent{
+		{Path: "vendor/blob.js", LinesAdded: 1000, Hunks: 1},
+		{Path: "pkg/service/orders.go", LinesAdded: 30, LinesDeleted: 10, Hunks: 12},
+	}
+
+	tests := []struct {
+		name    string
+		byHunks bool
+		want    string
+	}{
+		{"by lines the big block wins", false, "vendor/blob.js"},
+		{"by hunks scattered edits win", true, "pkg/service/orders.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := NewScorer(t.TempDir()).WithHunkScoring(tt.byHunks)
+
+			scores, err := scorer.ScoreFiles(context.Background(), files)
+			if err != nil {
+				t.Fatalf("ScoreFiles() error = %v", err)
+			}
+
+			if scores[0].Path != tt.want {
+				t.Errorf("top file = %s, want %s", scores[0].Path, tt.want)
+			}
+
+			// LinesChanged stays the raw line count either way
+			for _, s := range scores {
+				if s.Path == "vendor/blob.js" && s.LinesChanged != 1000 {
+					t.Errorf("LinesChanged = %d, want 1000", s.LinesChanged)
+				}
+			}
+		})
+	}
+}
+
+func TestScoreFilesAddedOnly(t *testing.T) {
+	files := []rcontext.FileContent{
+		{Path: "pkg/legacy/cleanup.go", LinesAdded: 5, LinesDeleted: 400},
+		{Path: "pkg/service/orders.go", LinesAdded: 60, LinesDeleted: 10},
+	}
+
+	tests := []struct {
+		name      string
+		addedOnly bool
+		want      string
+		wantLines map[string]int
+	}{
+		{"deletions count", false, "pkg/legacy/cleanup.go", map[string]int{"pkg/legacy/cleanup.go": 405, "pkg/service/orders.go": 70}},
+		{"added only", true, "pkg/service/orders.go", map[string]int{"pkg/legacy/cleanup.go": 5, "pkg/service/orders.go": 60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := NewScorer(t.TempDir()).WithAddedOnly(tt.addedOnly)
+
+			scores, err := scorer.ScoreFiles(context.Background(), files)
+			if err != nil {
+				t.Fatalf("ScoreFiles() error = %v", err)
+			}
+
+			if scores[0].Path != tt.want {
+				t.Errorf("top file = %s, want %s", scores[0].Path, tt.want)
+			}
+
+			for _, s := range scores {
+				if s.LinesChanged != tt.wantLines[s.Path] {
+					t.Errorf("%s LinesChanged = %d, want %d", s.Path, s.LinesChanged, tt.wantLines[s.Path])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package priority
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON writes scores as an indented JSON array, with each score's
+// breakdown. The field names are stable; an empty list is written as [].
+func WriteJSON(w io.Writer, scores []Score) error {
+	if scores == nil {
+		scores = []Score{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(scores)
+}
@@ -0,0 +1,72 @@
+package priority
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []Score
+		want   string
+	}{
+		{
+			name:   "empty",
+			scores: nil,
+			want:   "[]\n",
+		},
+		{
+			name: "score with breakdown",
+			scores: []Score{{
+				Path:           "pkg/auth/login.go",
+				Total:          72.5,
+				LinesChanged:   40,
+				Hunks:          2,
+				IsCriticalPath: true,
+				ChurnCount:     32,
+				Breakdown: Breakdown{
+					LinesChangedScore: 30,
+					CriticalityScore:  25,
+					ChurnScore:        12.5,
+					RecencyScore:      5,
+				},
+			}},
+			want: `[
+  {
+    "path": "pkg/auth/login.go",
+    "total": 72.5,
+    "lines_changed": 40,
+    "hunks": 2,
+    "critical_path": true,
+    "churn": 32,
+    "authors": 0,
+    "has_tests": false,
+    "breakdown": {
+      "lines_changed": 30,
+      "criticality": 25,
+      "churn": 12.5,
+      "test_coverage": 0,
+      "recency": 5,
+      "authors": 0
+    }
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+
+			if err := WriteJSON(&sb, tt.scores); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+
+			if sb.String() != tt.want {
+				t.Errorf("WriteJSON() =\n%s\nwant\n%s", sb.String(), tt.want)
+			}
+		})
+	}
+}
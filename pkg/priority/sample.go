@@ -0,0 +1,119 @@
+package priority
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+// SampleStrategy selects which files Sample keeps.
+type SampleStrategy string
+
+// Sample strategies.
+const (
+	// SampleStratified keeps critical-path files first, then takes files
+	// round-robin across directories, largest changes first in each, so
+	// every part of the change is represented.
+	SampleStratified SampleStrategy = "stratified"
+
+	// SampleLargest keeps the files with the most changed lines.
+	SampleLargest SampleStrategy = "largest"
+)
+
+// ParseSampleStrategy validates a --sample-strategy value. Empty means
+// SampleStratified.
+func ParseSampleStrategy(s string) (SampleStrategy, error) {
+	switch strategy := SampleStrategy(s); strategy {
+	case "":
+		return SampleStratified, nil
+	case SampleStratified, SampleLargest:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid sample strategy %q (want stratified or largest)", s)
+	}
+}
+
+// Sample picks n of files to score when a change is too large to score in
+// full, using only what the diff already says: paths, critical path
+// patterns and changed lines. No git history is read. The sample keeps the
+// order of files; all files are returned when there are at most n.
+func (s *Scorer) Sample(files []rcontext.FileContent, n int, strategy SampleStrategy) []rcontext.FileContent {
+	if n <= 0 || len(files) <= n {
+		return files
+	}
+
+	// Rank by index so equal files keep a stable order
+	order := make([]int, len(files))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(s.lines(files[b]), s.lines(files[a]))
+	})
+
+	var picked []int
+
+	switch strategy {
+	case SampleLargest:
+		picked = order[:n]
+	default:
+		picked = s.stratify(files, order, n)
+	}
+
+	slices.Sort(picked)
+
+	sample := make([]rcontext.FileContent, 0, n)
+	for _, i := range picked {
+		sample = append(sample, files[i])
+	}
+
+	return sample
+}
+
+// stratify picks n indexes from order (largest changes first): critical
+// files, then one file per directory in turn.
+func (s *Scorer) stratify(files []rcontext.FileContent, order []int, n int) []int {
+	var (
+		picked []int
+		dirs   []string
+		byDir  = make(map[string][]int)
+	)
+
+	for _, i := range order {
+		p := rcontext.NormalizePath(files[i].Path)
+
+		if s.isCritical(p) && len(picked) < n {
+			picked = append(picked, i)
+
+			continue
+		}
+
+		dir := path.Dir(p)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+
+		byDir[dir] = append(byDir[dir], i)
+	}
+
+	slices.Sort(dirs)
+
+	for len(picked) < n {
+		for _, dir := range dirs {
+			if len(picked) == n {
+				break
+			}
+
+			if queue := byDir[dir]; len(queue) > 0 {
+				picked = append(picked, queue[0])
+				byDir[dir] = queue[1:]
+			}
+		}
+	}
+
+	return picked
+}
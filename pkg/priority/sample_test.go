@@ -0,0 +1,84 @@
+package priority
+
+import (
+	"slices"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestParseSampleStrategy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    SampleStrategy
+		wantErr bool
+	}{
+		{"", SampleStratified, false},
+		{"stratified", SampleStratified, false},
+		{"largest", SampleLargest, false},
+		{"random", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSampleStrategy(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSampleStrategy(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestScorerSample(t *testing.T) {
+	files := []rcontext.FileContent{
+		{Path: "vendor/a/big.go", LinesAdded: 900},
+		{Path: "vendor/a/bigger.go", LinesAdded: 1000},
+		{Path: "vendor/a/huge.go", LinesAdded: 1200},
+		{Path: "pkg/auth/login.go", LinesAdded: 3},
+		{Path: "docs/readme.md", LinesAdded: 10},
+		{Path: "cmd/main.go", LinesAdded: 20},
+	}
+
+	tests := []struct {
+		name     string
+		n        int
+		strategy SampleStrategy
+		want     []string
+	}{
+		{
+			name:     "under the threshold keeps everything",
+			n:        10,
+			strategy: SampleStratified,
+			want:     []string{"vendor/a/big.go", "vendor/a/bigger.go", "vendor/a/huge.go", "pkg/auth/login.go", "docs/readme.md", "cmd/main.go"},
+		},
+		{
+			name:     "largest",
+			n:        3,
+			strategy: SampleLargest,
+			want:     []string{"vendor/a/big.go", "vendor/a/bigger.go", "vendor/a/huge.go"},
+		},
+		{
+			name:     "stratified keeps critical files and spreads across directories",
+			n:        4,
+			strategy: SampleStratified,
+			want:     []string{"vendor/a/huge.go", "pkg/auth/login.go", "docs/readme.md", "cmd/main.go"},
+		},
+		{
+			name:     "stratified wraps around directories",
+			n:        5,
+			strategy: SampleStratified,
+			want:     []string{"vendor/a/bigger.go", "vendor/a/huge.go", "pkg/auth/login.go", "docs/readme.md", "cmd/main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range NewScorer(".").Sample(files, tt.n, tt.strategy) {
+				got = append(got, f.Path)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Sample() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@
 package priority
 
 import (
+	"cmp"
 	"context"
-	"path/filepath"
+	"fmt"
+	"path"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/crealfy/crea-pipe/pkg/git"
@@ -14,43 +17,55 @@ import (
 // Score represents a file's priority score.
 type Score struct {
 	// Path is the file path.
-	Path string
+	Path string `json:"path"`
 
 	// Total is the overall priority score (0-100).
-	Total float64
+	Total float64 `json:"total"`
 
 	// LinesChanged is the number of lines changed.
-	LinesChanged int
+	LinesChanged int `json:"lines_changed"`
+
+	// Hunks is the number of separate hunks changed.
+	Hunks int `json:"hunks"`
 
 	// IsCriticalPath indicates if the file is in a critical path.
-	IsCriticalPath bool
+	IsCriticalPath bool `json:"critical_path"`
 
 	// ChurnCount is the historical change frequency.
-	ChurnCount int
+	ChurnCount int `json:"churn"`
+
+	// Authors is the number of distinct commit authors of the file. It is
+	// only counted when the Authors weight is set.
+	Authors int `json:"authors"`
 
 	// HasTests indicates if the file has associated tests.
-	HasTests bool
+	HasTests bool `json:"has_tests"`
 
 	// Breakdown contains the score components.
-	Breakdown Breakdown
+	Breakdown Breakdown `json:"breakdown"`
 }
 
 // Breakdown contains the individual score components.
 type Breakdown struct {
-	// LinesChangedScore is the score from lines changed (0-30).
-	LinesChangedScore float64
+	// LinesChangedScore is the score from lines changed, or from hunks
+	// with WithHunkScoring (0-30).
+	LinesChangedScore float64 `json:"lines_changed"`
 
 	// CriticalityScore is the score from critical path detection (0-25).
-	CriticalityScore float64
+	CriticalityScore float64 `json:"criticality"`
 
 	// ChurnScore is the score from historical churn (0-20).
-	ChurnScore float64
+	ChurnScore float64 `json:"churn"`
 
 	// TestCoverageScore is the score from test coverage (0-15).
-	TestCoverageScore float64
+	TestCoverageScore float64 `json:"test_coverage"`
 
 	// RecencyScore is the score from recency (0-10).
-	RecencyScore float64
+	RecencyScore float64 `json:"recency"`
+
+	// AuthorsScore is the score from distinct authors, normalized across
+	// the batch (0 unless the Authors weight is set).
+	AuthorsScore float64 `json:"authors"`
 }
 
 // Weights defines the scoring weights.
@@ -60,6 +75,10 @@ type Weights struct {
 	Churn        float64
 	TestCoverage float64
 	Recency      float64
+
+	// Authors weights files by their number of distinct authors. It is off
+	// by default so the default weights still sum to 1.
+	Authors float64
 }
 
 // DefaultWeights returns the default scoring weights.
@@ -75,8 +94,12 @@ func DefaultWeights() Weights {
 
 // Scorer calculates priority scores for files.
 type Scorer struct {
-	repoPath string
-	weights  Weights
+	repoPath      string
+	weights       Weights
+	extraCritical []*regexp.Regexp
+	exclude       map[string]bool
+	byHunks       bool
+	addedOnly     bool
 }
 
 // NewScorer creates a new priority scorer.
@@ -94,49 +117,180 @@ func (s *Scorer) WithWeights(w Weights) *Scorer {
 	return s
 }
 
+// WithExclude skips the given files (e.g. already reviewed in a continued session)
+// entirely: they get no score and cost no git calls.
+// They still count as existing tests for the files that are scored.
+func (s *Scorer) WithExclude(paths []string) *Scorer {
+	s.exclude = make(map[string]bool, len(paths))
+	for _, p := range paths {
+		s.exclude[rcontext.NormalizePath(p)] = true
+	}
+
+	return s
+}
+
+// WithHunkScoring sizes files by their number of hunks instead of lines
+// changed, so many scattered edits outrank one large block such as a
+// vendored or generated file.
+func (s *Scorer) WithHunkScoring(enabled bool) *Scorer {
+	s.byHunks = enabled
+
+	return s
+}
+
+// WithAddedOnly counts only added lines as changed, so deletions don't
+// raise a file's priority.
+func (s *Scorer) WithAddedOnly(enabled bool) *Scorer {
+	s.addedOnly = enabled
+
+	return s
+}
+
+// lines returns the changed lines of f: added and deleted, or only added
+// with WithAddedOnly.
+func (s *Scorer) lines(f rcontext.FileContent) int {
+	if s.addedOnly {
+		return f.LinesAdded
+	}
+
+	return f.LinesAdded + f.LinesDeleted
+}
+
+// size returns the measure the lines-changed component is based on:
+// changed lines (see lines), or hunks with WithHunkScoring. A file with
+// changed lines but no hunk count counts as one hunk.
+func (s *Scorer) size(f rcontext.FileContent) int {
+	lines := s.lines(f)
+	if !s.byHunks {
+		return lines
+	}
+
+	if f.Hunks == 0 && lines > 0 {
+		return 1
+	}
+
+	return f.Hunks
+}
+
+// ApplyConfig applies team config: weight overrides and extra critical path patterns.
+func (s *Scorer) ApplyConfig(cfg *rcontext.Config) error {
+	if cfg == nil {
+		return nil
+	}
+
+	w := cfg.Weights
+	applyWeight(&s.weights.LinesChanged, w.LinesChanged)
+	applyWeight(&s.weights.Criticality, w.Criticality)
+	applyWeight(&s.weights.Churn, w.Churn)
+	applyWeight(&s.weights.TestCoverage, w.TestCoverage)
+	applyWeight(&s.weights.Recency, w.Recency)
+	applyWeight(&s.weights.Authors, w.Authors)
+
+	for _, pattern := range cfg.CriticalPaths {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("compile critical path %q: %w", pattern, err)
+		}
+
+		s.extraCritical = append(s.extraCritical, re)
+	}
+
+	return nil
+}
+
+// applyWeight overrides dst when src is set.
+func applyWeight(dst *float64, src *float64) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+// isCritical checks the built-in and configured critical path patterns.
+func (s *Scorer) isCritical(path string) bool {
+	if isCriticalPath(path) {
+		return true
+	}
+
+	for _, pattern := range s.extraCritical {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ScoreFiles scores a list of changed files by priority.
 func (s *Scorer) ScoreFiles(ctx context.Context, files []rcontext.FileContent) ([]Score, error) {
-	// Find max lines changed for normalization
+	// Find max lines changed for normalization, over the files being scored
 	maxLines := 1
 
 	for _, f := range files {
-		total := f.LinesAdded + f.LinesDeleted
-		if total > maxLines {
-			maxLines = total
+		if s.exclude[rcontext.NormalizePath(f.Path)] {
+			continue
 		}
+
+		maxLines = max(maxLines, s.size(f))
 	}
 
 	// Build test file map for test detection
 	testFiles := make(map[string]bool)
 
 	for _, f := range files {
-		if isTestFile(f.Path) {
-			testFiles[f.Path] = true
+		if path := rcontext.NormalizePath(f.Path); IsTestFile(path) {
+			testFiles[path] = true
 		}
 	}
 
 	scores := make([]Score, 0, len(files))
 
 	for _, f := range files {
-		score := s.scoreFile(ctx, f, maxLines, testFiles)
+		f.Path = rcontext.NormalizePath(f.Path)
+		if s.exclude[f.Path] {
+			continue
+		}
+
+		score := s.ScoreFile(ctx, f, maxLines, testFiles)
 		scores = append(scores, score)
 	}
 
+	s.addAuthorScores(scores)
+
 	// Sort by total score descending
 	sortByScore(scores)
 
 	return scores, nil
 }
 
-// scoreFile calculates the priority score for a single file.
-func (s *Scorer) scoreFile(ctx context.Context, f rcontext.FileContent, maxLines int, testFiles map[string]bool) Score {
-	linesChanged := f.LinesAdded + f.LinesDeleted
+// ScoreFile calculates the priority score for a single file, for tools that
+// want the scorer without the rest of the pipeline. ScoreFiles uses it for
+// each file in a batch.
+//
+// maxLines is the largest size in the batch (LinesAdded+LinesDeleted,
+// LinesAdded with WithAddedOnly, or hunks with WithHunkScoring), used to normalize the lines-changed score;
+// 0 or less scores f against itself.
+// testFiles holds the slash-separated paths of test files in the change
+// (see IsTestFile) and may be nil.
+// Submodule pointer changes and pure renames score zero: there is no content to review.
+// With an Authors weight, ScoreFile counts the file's authors, but the
+// authors component is normalized across the batch and added by ScoreFiles.
+func (s *Scorer) ScoreFile(ctx context.Context, f rcontext.FileContent, maxLines int, testFiles map[string]bool) Score {
+	f.Path = rcontext.NormalizePath(f.Path)
+	if maxLines <= 0 {
+		maxLines = max(s.size(f), 1)
+	}
+
+	if f.IsSubmodule || f.IsPureRename() {
+		return Score{Path: f.Path, IsCriticalPath: s.isCritical(f.Path)}
+	}
+
+	linesChanged := s.lines(f)
 
 	// Lines changed score (0-30)
-	linesScore := (float64(linesChanged) / float64(maxLines)) * 100 * s.weights.LinesChanged
+	linesScore := (float64(s.size(f)) / float64(maxLines)) * 100 * s.weights.LinesChanged
 
 	// Criticality score (0-25)
-	isCritical := isCriticalPath(f.Path)
+	isCritical := s.isCritical(f.Path)
 	criticalScore := 0.0
 	if isCritical {
 		criticalScore = 100 * s.weights.Criticality
@@ -162,7 +316,7 @@ func (s *Scorer) scoreFile(ctx context.Context, f rcontext.FileContent, maxLines
 	// Files without tests get higher priority (need more scrutiny)
 	hasTests := hasAssociatedTests(f.Path, testFiles)
 	testScore := 0.0
-	if !hasTests && !isTestFile(f.Path) {
+	if !hasTests && !IsTestFile(f.Path) {
 		testScore = 100 * s.weights.TestCoverage
 	}
 
@@ -173,12 +327,19 @@ func (s *Scorer) scoreFile(ctx context.Context, f rcontext.FileContent, maxLines
 
 	total := linesScore + criticalScore + churnScore + testScore + recencyScore
 
+	authors := 0
+	if s.weights.Authors > 0 {
+		authors, _ = fileAuthors(ctx, s.repoPath, f.Path)
+	}
+
 	return Score{
 		Path:           f.Path,
 		Total:          total,
 		LinesChanged:   linesChanged,
+		Hunks:          f.Hunks,
 		IsCriticalPath: isCritical,
 		ChurnCount:     churnCount,
+		Authors:        authors,
 		HasTests:       hasTests,
 		Breakdown: Breakdown{
 			LinesChangedScore: linesScore,
@@ -219,9 +380,9 @@ func isCriticalPath(path string) bool {
 	return false
 }
 
-// isTestFile checks if a file is a test file.
-func isTestFile(path string) bool {
-	base := filepath.Base(path)
+// IsTestFile reports whether a path is a test file by common naming and directory conventions.
+func IsTestFile(file string) bool {
+	base := path.Base(file)
 
 	// Go tests
 	if strings.HasSuffix(base, "_test.go") {
@@ -251,7 +412,7 @@ func isTestFile(path string) bool {
 	}
 
 	// Check for test directories
-	dir := filepath.Dir(path)
+	dir := path.Dir(file)
 
 	return strings.Contains(dir, "/test/") ||
 		strings.Contains(dir, "/tests/") ||
@@ -261,26 +422,26 @@ func isTestFile(path string) bool {
 }
 
 // hasAssociatedTests checks if a source file has associated test files.
-func hasAssociatedTests(path string, testFiles map[string]bool) bool {
-	if isTestFile(path) {
+func hasAssociatedTests(file string, testFiles map[string]bool) bool {
+	if IsTestFile(file) {
 		return true
 	}
 
-	ext := filepath.Ext(path)
-	base := strings.TrimSuffix(filepath.Base(path), ext)
-	dir := filepath.Dir(path)
+	ext := path.Ext(file)
+	base := strings.TrimSuffix(path.Base(file), ext)
+	dir := path.Dir(file)
 
 	// Check common test file patterns
 	testPatterns := []string{
 		// Go
-		filepath.Join(dir, base+"_test.go"),
+		path.Join(dir, base+"_test.go"),
 		// JavaScript/TypeScript
-		filepath.Join(dir, base+".test"+ext),
-		filepath.Join(dir, base+".spec"+ext),
-		filepath.Join(dir, "__tests__", base+ext),
+		path.Join(dir, base+".test"+ext),
+		path.Join(dir, base+".spec"+ext),
+		path.Join(dir, "__tests__", base+ext),
 		// Python
-		filepath.Join(dir, "test_"+base+ext),
-		filepath.Join(dir, base+"_test"+ext),
+		path.Join(dir, "test_"+base+ext),
+		path.Join(dir, base+"_test"+ext),
 	}
 
 	for _, pattern := range testPatterns {
@@ -292,13 +453,10 @@ func hasAssociatedTests(path string, testFiles map[string]bool) bool {
 	return false
 }
 
-// sortByScore sorts scores by total descending.
+// sortByScore sorts scores by total descending, then by path, so equal
+// scores always come out in the same order.
 func sortByScore(scores []Score) {
-	for i := range scores {
-		for j := i + 1; j < len(scores); j++ {
-			if scores[j].Total > scores[i].Total {
-				scores[i], scores[j] = scores[j], scores[i]
-			}
-		}
-	}
+	slices.SortStableFunc(scores, func(a, b Score) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), strings.Compare(a.Path, b.Path))
+	})
 }
@@ -7,22 +7,6 @@ import (
 	rcontext "github.com/crealfy/crea-review/pkg/context"
 )
 
-func TestDefaultWeights(t *testing.T) {
-	w := DefaultWeights()
-
-	total := w.LinesChanged + w.Criticality + w.Churn + w.TestCoverage + w.Recency
-	if total != 1.0 {
-		t.Errorf("weights sum = %f, want 1.0", total)
-	}
-
-	if w.LinesChanged != 0.30 {
-		t.Errorf("LinesChanged = %f, want 0.30", w.LinesChanged)
-	}
-	if w.Criticality != 0.25 {
-		t.Errorf("Criticality = %f, want 0.25", w.Criticality)
-	}
-}
-
 func TestIsCriticalPath(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -87,9 +71,9 @@ func TestIsTestFile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.path, func(t *testing.T) {
-			got := isTestFile(tt.path)
+			got := IsTestFile(tt.path)
 			if got != tt.expected {
-				t.Errorf("isTestFile(%q) = %v, want %v", tt.path, got, tt.expected)
+				t.Errorf("IsTestFile(%q) = %v, want %v", tt.path, got, tt.expected)
 			}
 		})
 	}
@@ -123,23 +107,33 @@ func TestHasAssociatedTests(t *testing.T) {
 	}
 }
 
-func TestSortByScore(t *testing.T) {
-	scores := []Score{
-		{Path: "low.go", Total: 10},
-		{Path: "high.go", Total: 90},
-		{Path: "medium.go", Total: 50},
+func TestHasAssociatedTestsSameBasename(t *testing.T) {
+	// Only pkg/a has a handler test; pkg/b/handler.go must not borrow it.
+	testFiles := map[string]bool{
+		"pkg/a/handler_test.go": true,
+		"web/a/auth.test.js":    true,
+		"svc/a/test_models.py":  true,
 	}
 
-	sortByScore(scores)
-
-	if scores[0].Path != "high.go" {
-		t.Errorf("scores[0].Path = %q, want %q", scores[0].Path, "high.go")
-	}
-	if scores[1].Path != "medium.go" {
-		t.Errorf("scores[1].Path = %q, want %q", scores[1].Path, "medium.go")
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"pkg/a/handler.go", true},
+		{"pkg/b/handler.go", false},
+		{"handler.go", false},
+		{"web/a/auth.js", true},
+		{"web/b/auth.js", false},
+		{"svc/a/models.py", true},
+		{"svc/b/models.py", false},
 	}
-	if scores[2].Path != "low.go" {
-		t.Errorf("scores[2].Path = %q, want %q", scores[2].Path, "low.go")
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := hasAssociatedTests(tt.path, testFiles); got != tt.expected {
+				t.Errorf("hasAssociatedTests(%q) = %v, want %v", tt.path, got, tt.expected)
+			}
+		})
 	}
 }
 
@@ -191,22 +185,6 @@ func TestNewScorer(t *testing.T) {
 	}
 }
 
-func TestScorerWithWeights(t *testing.T) {
-	customWeights := Weights{
-		LinesChanged: 0.5,
-		Criticality:  0.2,
-		Churn:        0.1,
-		TestCoverage: 0.1,
-		Recency:      0.1,
-	}
-
-	scorer := NewScorer("/test/repo").WithWeights(customWeights)
-
-	if scorer.weights != customWeights {
-		t.Error("custom weights not applied")
-	}
-}
-
 func TestScoreFileBasic(t *testing.T) {
 	scorer := NewScorer("/test/repo")
 	testFiles := make(map[string]bool)
@@ -218,7 +196,7 @@ func TestScoreFileBasic(t *testing.T) {
 
 	// Use context.Background() instead of nil
 	ctx := context.Background()
-	score := scorer.scoreFile(ctx, file, 100, testFiles)
+	score := scorer.ScoreFile(ctx, file, 100, testFiles)
 
 	// Should have lines changed score
 	if score.Breakdown.LinesChangedScore == 0 {
@@ -240,6 +218,64 @@ func TestScoreFileBasic(t *testing.T) {
 	}
 }
 
+func TestScoreFileSubmodule(t *testing.T) {
+	scorer := NewScorer("/test/repo")
+
+	file := rcontext.FileContent{
+		Path:         "vendor/lib",
+		LinesAdded:   1,
+		LinesDeleted: 1,
+		IsSubmodule:  true,
+	}
+
+	score := scorer.ScoreFile(context.Background(), file, 1, map[string]bool{})
+	if score.Total != 0 {
+		t.Errorf("Total = %v, want 0 for submodule pointer change", score.Total)
+	}
+}
+
+func TestScoreFileRename(t *testing.T) {
+	scorer := NewScorer("/test/repo")
+	testFiles := map[string]bool{}
+
+	pure := rcontext.FileContent{Path: "pkg/moved.go", OldPath: "lib/moved.go", Status: "renamed"}
+	edited := rcontext.FileContent{Path: "pkg/new.go", OldPath: "pkg/old.go", Status: "renamed", LinesAdded: 4, LinesDeleted: 2}
+
+	pureScore := scorer.ScoreFile(context.Background(), pure, 6, testFiles)
+	editedScore := scorer.ScoreFile(context.Background(), edited, 6, testFiles)
+
+	if pureScore.Total != 0 {
+		t.Errorf("pure rename Total = %v, want 0", pureScore.Total)
+	}
+	if editedScore.Total <= pureScore.Total || editedScore.LinesChanged != 6 {
+		t.Errorf("rename+edit score = %+v, want it scored like a modified file", editedScore)
+	}
+}
+
+func TestScoreFilePublic(t *testing.T) {
+	scorer := NewScorer(t.TempDir())
+
+	file := rcontext.FileContent{Path: `pkg\auth\login.go`, LinesAdded: 10, LinesDeleted: 2}
+
+	// No batch: lines are normalized against the file itself and nothing has tests
+	score := scorer.ScoreFile(context.Background(), file, 0, nil)
+
+	if score.Path != "pkg/auth/login.go" {
+		t.Errorf("Path = %q, want normalized pkg/auth/login.go", score.Path)
+	}
+	if score.LinesChanged != 12 || !score.IsCriticalPath || score.HasTests {
+		t.Errorf("score = %+v, want 12 lines, critical, no tests", score)
+	}
+	if score.Breakdown.LinesChangedScore != 100*DefaultWeights().LinesChanged {
+		t.Errorf("LinesChangedScore = %v, want full weight", score.Breakdown.LinesChangedScore)
+	}
+
+	withTests := scorer.ScoreFile(context.Background(), file, 24, map[string]bool{"pkg/auth/login_test.go": true})
+	if !withTests.HasTests || withTests.Total >= score.Total {
+		t.Errorf("with tests and a larger batch: score = %+v, want HasTests and lower total than %v", withTests, score.Total)
+	}
+}
+
 func TestScoreFiles(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -305,6 +341,20 @@ func TestScoreFiles(t *testing.T) {
 					utilsScore != nil && !utilsScore.HasTests
 			},
 		},
+		{
+			name: "windows paths are normalized",
+			files: []rcontext.FileContent{
+				{Path: `pkg\auth\handler.go`, LinesAdded: 20, LinesDeleted: 0},
+				{Path: `pkg\auth\handler_test.go`, LinesAdded: 30, LinesDeleted: 0},
+			},
+			wantCount: 2,
+			verifyScores: func(scores []Score) bool {
+				handlerScore := findScore(scores, "pkg/auth/handler.go")
+
+				return handlerScore != nil && handlerScore.HasTests &&
+					findScore(scores, "pkg/auth/handler_test.go") != nil
+			},
+		},
 		{
 			name: "binary files should have zero lines",
 			files: []rcontext.FileContent{
@@ -345,3 +395,43 @@ func findScore(scores []Score, path string) *Score {
 
 	return nil
 }
+
+func TestScoreFilesExclude(t *testing.T) {
+	files := []rcontext.FileContent{
+		{Path: "pkg/big/reviewed.go", LinesAdded: 500},
+		{Path: "pkg/auth/handler.go", LinesAdded: 20},
+		{Path: "pkg/auth/handler_test.go", LinesAdded: 30},
+		{Path: "pkg/utils/helper.go", LinesAdded: 10},
+	}
+
+	scorer := NewScorer(t.TempDir()).WithExclude([]string{`pkg\big\reviewed.go`, "pkg/auth/handler_test.go"})
+
+	scores, err := scorer.ScoreFiles(context.Background(), files)
+	if err != nil {
+		t.Fatalf("ScoreFiles() error = %v", err)
+	}
+
+	if len(scores) != 2 {
+		t.Fatalf("got %d scores, want 2", len(scores))
+	}
+
+	for _, excluded := range []string{"pkg/big/reviewed.go", "pkg/auth/handler_test.go"} {
+		if findScore(scores, excluded) != nil {
+			t.Errorf("excluded file %s was scored", excluded)
+		}
+	}
+
+	handler := findScore(scores, "pkg/auth/handler.go")
+	if handler == nil {
+		t.Fatal("handler.go was not scored")
+	}
+
+	if !handler.HasTests {
+		t.Error("handler.go should keep HasTests from its excluded test file")
+	}
+
+	// Lines are normalized over the remaining files, so the largest one gets the full weight
+	if want := 100 * DefaultWeights().LinesChanged; handler.Breakdown.LinesChangedScore != want {
+		t.Errorf("LinesChangedScore = %v, want %v", handler.Breakdown.LinesChangedScore, want)
+	}
+}
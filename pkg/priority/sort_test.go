@@ -0,0 +1,46 @@
+package priority
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortByScore(t *testing.T) {
+	scores := []Score{
+		{Path: "low.go", Total: 10},
+		{Path: "high.go", Total: 90},
+		{Path: "medium.go", Total: 50},
+	}
+
+	sortByScore(scores)
+
+	if scores[0].Path != "high.go" {
+		t.Errorf("scores[0].Path = %q, want %q", scores[0].Path, "high.go")
+	}
+	if scores[1].Path != "medium.go" {
+		t.Errorf("scores[1].Path = %q, want %q", scores[1].Path, "medium.go")
+	}
+	if scores[2].Path != "low.go" {
+		t.Errorf("scores[2].Path = %q, want %q", scores[2].Path, "low.go")
+	}
+}
+
+func TestSortByScoreTies(t *testing.T) {
+	scores := []Score{
+		{Path: "c.go", Total: 10},
+		{Path: "a.go", Total: 10},
+		{Path: "top.go", Total: 20},
+		{Path: "b.go", Total: 10},
+	}
+
+	sortByScore(scores)
+
+	var got []string
+	for _, s := range scores {
+		got = append(got, s.Path)
+	}
+
+	if want := []string{"top.go", "a.go", "b.go", "c.go"}; !slices.Equal(got, want) {
+		t.Errorf("sortByScore() order = %v, want %v", got, want)
+	}
+}
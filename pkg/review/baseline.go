@@ -0,0 +1,130 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// baselineVersion is the current baseline file format.
+const baselineVersion = 1
+
+// Baseline is a set of known finding fingerprints to suppress, so legacy
+// issues aren't re-reported on every run.
+type Baseline struct {
+	fingerprints map[string]bool
+}
+
+// baselineFile is the on-disk baseline format.
+type baselineFile struct {
+	Version      int      `json:"version"`
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// Fingerprint identifies a finding independently of its line number, so it
+// survives edits elsewhere in the file: a hash of the file, category and
+// whitespace-normalized description.
+func Fingerprint(f session.Finding) string {
+	key := strings.Join([]string{
+		rcontext.NormalizePath(f.File),
+		f.Category,
+		strings.Join(strings.Fields(f.Description), " "),
+	}, "\x00")
+
+	h := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(h[:8])
+}
+
+// AssignIDs sets each finding's ID to the first 8 hex digits of its
+// Fingerprint, so the same issue keeps its ID across runs and formats.
+// Findings sharing a fingerprint (e.g. the same issue on several lines) get
+// "-2", "-3", ... suffixes in order.
+func AssignIDs(findings []session.Finding) {
+	seen := make(map[string]int, len(findings))
+
+	for i := range findings {
+		id := Fingerprint(findings[i])[:8]
+
+		seen[id]++
+		if n := seen[id]; n > 1 {
+			id = fmt.Sprintf("%s-%d", id, n)
+		}
+
+		findings[i].ID = id
+	}
+}
+
+// LoadBaseline reads a baseline written by WriteBaseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+
+	var file baselineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+
+	if file.Version != baselineVersion {
+		return nil, fmt.Errorf("baseline %s: unsupported version %d", path, file.Version)
+	}
+
+	b := &Baseline{fingerprints: make(map[string]bool, len(file.Fingerprints))}
+	for _, fp := range file.Fingerprints {
+		b.fingerprints[fp] = true
+	}
+
+	return b, nil
+}
+
+// WriteBaseline writes the fingerprints of findings to path, sorted and
+// deduplicated so the file diffs cleanly in version control.
+func WriteBaseline(path string, findings []session.Finding) error {
+	file := baselineFile{Version: baselineVersion, Fingerprints: []string{}}
+	for _, f := range findings {
+		file.Fingerprints = append(file.Fingerprints, Fingerprint(f))
+	}
+
+	slices.Sort(file.Fingerprints)
+	file.Fingerprints = slices.Compact(file.Fingerprints)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Filter drops findings whose fingerprint is in the baseline.
+// Returns the remaining findings and the number suppressed.
+func (b *Baseline) Filter(findings []session.Finding) ([]session.Finding, int) {
+	if b == nil || len(b.fingerprints) == 0 {
+		return findings, 0
+	}
+
+	kept := make([]session.Finding, 0, len(findings))
+
+	for _, f := range findings {
+		if b.fingerprints[Fingerprint(f)] {
+			continue
+		}
+
+		kept = append(kept, f)
+	}
+
+	return kept, len(findings) - len(kept)
+}
@@ -0,0 +1,128 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestFingerprint(t *testing.T) {
+	base := session.Finding{File: "auth.go", Line: 10, Severity: "error", Category: "security", Description: "SQL injection in query"}
+
+	moved := base
+	moved.Line = 42
+	moved.Severity = "warning"
+
+	reflowed := base
+	reflowed.File = `auth.go`
+	reflowed.Description = "SQL  injection\nin query"
+
+	if Fingerprint(moved) != Fingerprint(base) {
+		t.Error("fingerprint should ignore line and severity")
+	}
+	if Fingerprint(reflowed) != Fingerprint(base) {
+		t.Error("fingerprint should ignore whitespace differences")
+	}
+
+	for _, other := range []session.Finding{
+		{File: "other.go", Category: base.Category, Description: base.Description},
+		{File: base.File, Category: "bug", Description: base.Description},
+		{File: base.File, Category: base.Category, Description: "XSS in template"},
+	} {
+		if Fingerprint(other) == Fingerprint(base) {
+			t.Errorf("Fingerprint(%+v) collides with base", other)
+		}
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	known := []session.Finding{
+		{File: "legacy.go", Line: 5, Category: "bug", Description: "unchecked error"},
+		{File: "legacy.go", Line: 9, Category: "bug", Description: "unchecked error"},
+		{File: "old.go", Line: 1, Category: "style", Description: "long function"},
+	}
+
+	if err := WriteBaseline(path, known); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+
+	if len(baseline.fingerprints) != 2 {
+		t.Errorf("baseline has %d fingerprints, want 2 (duplicates merged)", len(baseline.fingerprints))
+	}
+
+	current := []session.Finding{
+		{File: "legacy.go", Line: 7, Category: "bug", Description: "unchecked error"},
+		{File: "new.go", Line: 3, Category: "security", Description: "hardcoded secret"},
+	}
+
+	kept, suppressed := baseline.Filter(current)
+	if suppressed != 1 || len(kept) != 1 || kept[0].File != "new.go" {
+		t.Errorf("Filter() = %+v, %d suppressed; want only new.go, 1 suppressed", kept, suppressed)
+	}
+}
+
+func TestBaselineNil(t *testing.T) {
+	var baseline *Baseline
+
+	findings := []session.Finding{{File: "a.go"}}
+	if kept, suppressed := baseline.Filter(findings); len(kept) != 1 || suppressed != 0 {
+		t.Errorf("nil Filter() = %+v, %d; want findings unchanged", kept, suppressed)
+	}
+}
+
+func TestLoadBaselineErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	badVersion := filepath.Join(dir, "v2.json")
+	if err := os.WriteFile(badVersion, []byte(`{"version": 2, "fingerprints": []}`), 0o644); err != nil {
+		t.Fatalf("failed to write baseline: %v", err)
+	}
+
+	notJSON := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(notJSON, []byte("fingerprints"), 0o644); err != nil {
+		t.Fatalf("failed to write baseline: %v", err)
+	}
+
+	for _, path := range []string{badVersion, notJSON, filepath.Join(dir, "missing.json")} {
+		if _, err := LoadBaseline(path); err == nil {
+			t.Errorf("LoadBaseline(%s) expected error", filepath.Base(path))
+		}
+	}
+}
+
+func TestAssignIDs(t *testing.T) {
+	findings := []session.Finding{
+		{File: "a.go", Line: 1, Category: "bug", Description: "nil check"},
+		{File: "a.go", Line: 9, Category: "bug", Description: "nil check"},
+		{File: "b.go", Line: 1, Category: "bug", Description: "nil check"},
+		{File: "a.go", Line: 5, Category: "bug", Description: "nil check"},
+	}
+
+	AssignIDs(findings)
+
+	base := Fingerprint(findings[0])[:8]
+	want := []string{base, base + "-2", Fingerprint(findings[2])[:8], base + "-3"}
+
+	for i, f := range findings {
+		if f.ID != want[i] {
+			t.Errorf("findings[%d].ID = %q, want %q", i, f.ID, want[i])
+		}
+	}
+
+	// Stable across runs and line moves
+	again := []session.Finding{{File: "a.go", Line: 40, Category: "bug", Description: "nil  check"}}
+	AssignIDs(again)
+
+	if again[0].ID != base {
+		t.Errorf("ID after line move = %q, want %q", again[0].ID, base)
+	}
+}
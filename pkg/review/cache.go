@@ -0,0 +1,200 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+)
+
+// DefaultCacheTTL is how long cached responses are reused by default.
+const DefaultCacheTTL = 24 * time.Hour
+
+// ResponseCache stores agent responses on disk, keyed by a hash of the
+// backend, model and final prompt, so an identical review can be repeated
+// without calling the AI. The raw response is cached rather than the parsed
+// Result, so parsing changes apply to cached responses too.
+type ResponseCache struct {
+	// Dir holds one JSON file per cached response.
+	Dir string
+
+	// TTL is how long a response is reused; zero means DefaultCacheTTL.
+	TTL time.Duration
+
+	// Refresh skips cached responses but still stores the new one.
+	Refresh bool
+
+	// now returns the current time; tests override it.
+	now func() time.Time
+}
+
+// cachedResponse is the on-disk form of a cached agent response.
+type cachedResponse struct {
+	CreatedAt    time.Time     `json:"created_at"`
+	Model        string        `json:"model,omitempty"`
+	Text         string        `json:"text"`
+	InputTokens  int           `json:"input_tokens,omitempty"`
+	OutputTokens int           `json:"output_tokens,omitempty"`
+	TotalTokens  int           `json:"total_tokens,omitempty"`
+	Duration     time.Duration `json:"duration,omitempty"`
+}
+
+// NewResponseCache returns a cache in dir whose entries expire after ttl
+// (DefaultCacheTTL when zero).
+func NewResponseCache(dir string, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{Dir: dir, TTL: ttl}
+}
+
+// cacheKey hashes everything that determines the response.
+func cacheKey(backend Backend, model, prompt string) string {
+	sum := sha256.Sum256([]byte(string(backend) + "\x00" + model + "\x00" + prompt))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheable reports whether a response is worth reusing: it parsed into
+// findings or ends a clean review with CompletionMarker. An empty or cut-off
+// response is left uncached so the next run asks again.
+func cacheable(response string, findings int) bool {
+	return findings > 0 || strings.Contains(response, CompletionMarker)
+}
+
+// get returns the response cached under key, if present and not expired.
+// Unreadable or expired entries are removed and count as misses.
+func (c *ResponseCache) get(key string) (*cachedResponse, bool) {
+	if c.Refresh {
+		return nil, false
+	}
+
+	path := c.path(key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil || c.clock().Sub(entry.CreatedAt) > c.ttl() {
+		_ = os.Remove(path)
+
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// put stores response under key.
+func (c *ResponseCache) put(key string, response *agent.Response) error {
+	data, err := json.MarshalIndent(cachedResponse{
+		CreatedAt:    c.clock(),
+		Model:        response.Model,
+		Text:         response.Text,
+		InputTokens:  response.InputTokens,
+		OutputTokens: response.OutputTokens,
+		TotalTokens:  response.TotalTokens,
+		Duration:     response.Duration,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cached response: %w", err)
+	}
+
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+
+	// Write then rename, so a concurrent reader never sees half an entry
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write cached response: %w", err)
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("write cached response: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes every cached response and returns how many were removed.
+// A missing cache directory is not an error.
+func (c *ResponseCache) Clear() (int, error) {
+	entries, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("read cache dir: %w", err)
+	}
+
+	removed := 0
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(c.Dir, e.Name())); err != nil {
+			return removed, fmt.Errorf("remove cached response: %w", err)
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// result builds a Result from a cached response. Cost is zero: nothing was spent.
+func (e *cachedResponse) result(opts Options) *Result {
+	return &Result{
+		Findings:     parseFindings(e.Text, newFindingRules(opts)),
+		RawResponse:  e.Text,
+		Cached:       true,
+		InputTokens:  e.InputTokens,
+		OutputTokens: e.OutputTokens,
+		TotalTokens:  e.TotalTokens,
+		Model:        e.Model,
+		Duration:     e.Duration,
+	}
+}
+
+// path returns the file holding the entry for key.
+func (c *ResponseCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// ttl returns the effective time to live.
+func (c *ResponseCache) ttl() time.Duration {
+	if c.TTL > 0 {
+		return c.TTL
+	}
+
+	return DefaultCacheTTL
+}
+
+// clock returns the current time.
+func (c *ResponseCache) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+
+	return time.Now()
+}
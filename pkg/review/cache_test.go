@@ -0,0 +1,163 @@
+package review
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+	"github.com/crealfy/crea-pipe/pkg/agent/mock"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+// countingAgent returns a mock agent answering with text and counting its runs.
+func countingAgent(text string, runs *int) *mock.Agent {
+	a := mock.New()
+	a.RunFunc = func(context.Context, string, *agent.Config) (*agent.Response, error) {
+		*runs++
+
+		return &agent.Response{Text: text, Model: "test-model", InputTokens: 100, OutputTokens: 20, TotalTokens: 120, Cost: 0.5}, nil
+	}
+
+	return a
+}
+
+func TestReviewUsesResponseCache(t *testing.T) {
+	var runs int
+
+	r := &Reviewer{agent: countingAgent("FINDING: [main.go:3] [error] [bug]\nDESCRIPTION: nil dereference\n", &runs), backend: BackendClaude}
+	reviewCtx := &rcontext.ReviewContext{ChangedFiles: []rcontext.FileContent{{Path: "main.go", Status: "modified"}}}
+	opts := Options{Cache: NewResponseCache(t.TempDir(), time.Hour)}
+
+	first, err := r.Review(context.Background(), reviewCtx, opts)
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	second, err := r.Review(context.Background(), reviewCtx, opts)
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	if runs != 1 {
+		t.Errorf("agent ran %d times, want 1", runs)
+	}
+
+	if first.Cached || !second.Cached {
+		t.Errorf("Cached = %v then %v, want false then true", first.Cached, second.Cached)
+	}
+
+	if len(second.Findings) != 1 || second.Findings[0].Description != "nil dereference" {
+		t.Errorf("cached Findings = %+v", second.Findings)
+	}
+
+	if second.Cost != 0 || second.TotalTokens != 120 || second.Model != "test-model" {
+		t.Errorf("cached result = %+v, want zero cost and the original usage", second)
+	}
+
+	// A different model or prompt misses
+	if _, err := r.Review(context.Background(), reviewCtx, Options{Cache: opts.Cache, Model: "other"}); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	reviewCtx.ChangedFiles[0].Path = "other.go"
+	if _, err := r.Review(context.Background(), reviewCtx, opts); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	if runs != 3 {
+		t.Errorf("agent ran %d times, want 3 after a model and a prompt change", runs)
+	}
+}
+
+func TestReviewSkipsCachingIncompleteResponses(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		cached   bool
+	}{
+		{"findings", "FINDING: [main.go:3] [error] [bug]\nDESCRIPTION: nil dereference\n", true},
+		{"clean with marker", "No issues found.\n" + CompletionMarker + "\n", true},
+		{"empty", "", false},
+		{"cut off", "Looking at main.go, the handler", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int
+
+			r := &Reviewer{agent: countingAgent(tt.response, &runs), backend: BackendClaude}
+			reviewCtx := &rcontext.ReviewContext{ChangedFiles: []rcontext.FileContent{{Path: "main.go", Status: "modified"}}}
+			opts := Options{Cache: NewResponseCache(t.TempDir(), time.Hour)}
+
+			for range 2 {
+				if _, err := r.Review(context.Background(), reviewCtx, opts); err != nil {
+					t.Fatalf("Review() error = %v", err)
+				}
+			}
+
+			wantRuns := 2
+			if tt.cached {
+				wantRuns = 1
+			}
+
+			if runs != wantRuns {
+				t.Errorf("agent ran %d times, want %d", runs, wantRuns)
+			}
+		})
+	}
+}
+
+func TestResponseCacheExpiryAndRefresh(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := &ResponseCache{Dir: t.TempDir(), TTL: time.Hour, now: func() time.Time { return now }}
+
+	if err := cache.put("k", &agent.Response{Text: "cached"}); err != nil {
+		t.Fatalf("put() error = %v", err)
+	}
+
+	if entry, ok := cache.get("k"); !ok || entry.Text != "cached" {
+		t.Fatalf("get() = %+v, %v; want a hit", entry, ok)
+	}
+
+	cache.Refresh = true
+	if _, ok := cache.get("k"); ok {
+		t.Error("get() with Refresh should miss")
+	}
+
+	cache.Refresh = false
+	now = now.Add(2 * time.Hour)
+
+	if _, ok := cache.get("k"); ok {
+		t.Error("get() after the TTL should miss")
+	}
+
+	if _, err := os.Stat(cache.path("k")); !os.IsNotExist(err) {
+		t.Errorf("expired entry should be removed, stat error = %v", err)
+	}
+}
+
+func TestResponseCacheClear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache := NewResponseCache(dir, 0)
+
+	if n, err := cache.Clear(); err != nil || n != 0 {
+		t.Errorf("Clear() on a missing dir = %d, %v; want 0, nil", n, err)
+	}
+
+	for _, key := range []string{"a", "b"} {
+		if err := cache.put(key, &agent.Response{Text: key}); err != nil {
+			t.Fatalf("put() error = %v", err)
+		}
+	}
+
+	if n, err := cache.Clear(); err != nil || n != 2 {
+		t.Errorf("Clear() = %d, %v; want 2, nil", n, err)
+	}
+
+	if _, ok := cache.get("a"); ok {
+		t.Error("get() after Clear should miss")
+	}
+}
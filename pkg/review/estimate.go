@@ -0,0 +1,100 @@
+package review
+
+import (
+	"strings"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+// ModelPrice is a model's list price in USD per million tokens.
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// Prices maps model name fragments to their list price.
+// A model matches the longest fragment it contains, as with ContextWindows.
+var Prices = map[string]ModelPrice{
+	"opus":   {Input: 15, Output: 75},
+	"sonnet": {Input: 3, Output: 15},
+	"haiku":  {Input: 0.8, Output: 4},
+	"gpt-5":  {Input: 1.25, Output: 10},
+	"codex":  {Input: 1.25, Output: 10},
+}
+
+// DefaultModels is the model assumed for pricing when Options.Model is empty.
+var DefaultModels = map[Backend]string{
+	BackendClaude: "sonnet",
+	BackendCodex:  "gpt-5-codex",
+}
+
+// outputTokensPerFile is the assumed response size per reviewed file.
+// Findings are short, so this is a rough upper bound rather than an average.
+const outputTokensPerFile = 300
+
+// Estimate is a pre-flight token and cost estimate for a review.
+type Estimate struct {
+	// Model is the model the estimate was priced for.
+	Model string
+
+	// InputTokens is the estimated prompt size.
+	InputTokens int
+
+	// OutputTokens is the estimated response size.
+	OutputTokens int
+
+	// Cost is the estimated cost in USD (0 when the model has no known price).
+	Cost float64
+
+	// Priced reports whether the model matched an entry in Prices.
+	Priced bool
+}
+
+// EstimateCost estimates tokens and cost for reviewing reviewCtx without calling the AI.
+// The agent may read files itself, so real usage is typically higher than the prompt alone.
+func EstimateCost(reviewCtx *rcontext.ReviewContext, backend Backend, opts Options) (Estimate, error) {
+	model := opts.Model
+	if model == "" {
+		model = DefaultModels[backend]
+	}
+
+	prompt, err := renderPrompt(reviewCtx, opts)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	est := Estimate{
+		Model:        model,
+		InputTokens:  EstimateTokens(prompt),
+		OutputTokens: len(reviewCtx.ChangedFiles) * outputTokensPerFile,
+	}
+
+	price, ok := PriceFor(model)
+	if !ok {
+		return est, nil
+	}
+
+	est.Priced = true
+	est.Cost = (float64(est.InputTokens)*price.Input + float64(est.OutputTokens)*price.Output) / 1_000_000
+
+	return est, nil
+}
+
+// PriceFor returns the price for a model, matching the longest fragment in Prices.
+func PriceFor(model string) (ModelPrice, bool) {
+	model = strings.ToLower(model)
+
+	var (
+		price   ModelPrice
+		matched string
+	)
+
+	for fragment, p := range Prices {
+		if strings.Contains(model, fragment) && len(fragment) > len(matched) {
+			matched = fragment
+			price = p
+		}
+	}
+
+	return price, matched != ""
+}
@@ -0,0 +1,70 @@
+package review
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestPriceFor(t *testing.T) {
+	tests := []struct {
+		model  string
+		want   ModelPrice
+		wantOK bool
+	}{
+		{"claude-opus-4-6", Prices["opus"], true},
+		{"Sonnet", Prices["sonnet"], true},
+		{"gpt-5-codex", Prices["gpt-5"], true},
+		{"unknown-model", ModelPrice{}, false},
+		{"", ModelPrice{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, ok := PriceFor(tt.model)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("PriceFor(%q) = %+v, %v, want %+v, %v", tt.model, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	reviewCtx := &rcontext.ReviewContext{
+		Diff: strings.Repeat("x", 4_000_000),
+		ChangedFiles: []rcontext.FileContent{
+			{Path: "main.go", Status: "modified"},
+			{Path: "util.go", Status: "modified"},
+		},
+	}
+
+	est, err := EstimateCost(reviewCtx, BackendClaude, Options{})
+	if err != nil {
+		t.Fatalf("EstimateCost() error = %v", err)
+	}
+	if est.Model != "sonnet" {
+		t.Errorf("Model = %q, want backend default sonnet", est.Model)
+	}
+	if est.InputTokens < 1_000_000 {
+		t.Errorf("InputTokens = %d, want at least the diff's 1M tokens", est.InputTokens)
+	}
+	if est.OutputTokens != 2*outputTokensPerFile {
+		t.Errorf("OutputTokens = %d, want %d", est.OutputTokens, 2*outputTokensPerFile)
+	}
+
+	price := Prices["sonnet"]
+	want := (float64(est.InputTokens)*price.Input + float64(est.OutputTokens)*price.Output) / 1_000_000
+	if !est.Priced || math.Abs(est.Cost-want) > 1e-9 {
+		t.Errorf("Cost = %v (priced %v), want %v", est.Cost, est.Priced, want)
+	}
+
+	unpriced, err := EstimateCost(reviewCtx, BackendClaude, Options{Model: "mystery"})
+	if err != nil {
+		t.Fatalf("EstimateCost() error = %v", err)
+	}
+	if unpriced.Priced || unpriced.Cost != 0 {
+		t.Errorf("unknown model estimate = %+v, want unpriced", unpriced)
+	}
+}
@@ -0,0 +1,75 @@
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// NewFileReviewer creates a reviewer that loads findings from a JSON file
+// instead of invoking an agent. Useful for CI re-runs and deterministic tests.
+func NewFileReviewer(path string) *Reviewer {
+	return &Reviewer{
+		backend:      BackendFile,
+		findingsFile: path,
+	}
+}
+
+// reviewFromFile loads findings from the reviewer's findings file.
+func (r *Reviewer) reviewFromFile() (*Result, error) {
+	start := time.Now()
+
+	data, err := os.ReadFile(r.findingsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read findings file: %w", err)
+	}
+
+	findings, err := parseFindingsJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse findings file %s: %w", r.findingsFile, err)
+	}
+
+	for i := range findings {
+		findings[i].File = rcontext.NormalizePath(findings[i].File)
+	}
+
+	return &Result{
+		Findings:    findings,
+		RawResponse: string(data),
+		Duration:    time.Since(start),
+	}, nil
+}
+
+// parseFindingsJSON accepts either a bare array of findings or an object
+// with a "findings" key, such as a previous creareview JSON output.
+func parseFindingsJSON(data []byte) ([]session.Finding, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" {
+		return nil, errors.New("empty file")
+	}
+
+	if strings.HasPrefix(trimmed, "[") {
+		var findings []session.Finding
+		if err := json.Unmarshal(data, &findings); err != nil {
+			return nil, fmt.Errorf("unmarshal findings: %w", err)
+		}
+
+		return findings, nil
+	}
+
+	var wrapped struct {
+		Findings []session.Finding `json:"findings"`
+	}
+
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("unmarshal findings: %w", err)
+	}
+
+	return wrapped.Findings, nil
+}
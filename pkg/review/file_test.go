@@ -0,0 +1,97 @@
+package review
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestParseFindingsJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "bare array",
+			data: `[{"file":"main.go","line":10,"severity":"error","category":"bug","description":"nil deref"}]`,
+			want: 1,
+		},
+		{
+			name: "creareview output object",
+			data: `{"session_id":3,"findings":[{"file":"a.go","line":1},{"file":"b.go","line":2}]}`,
+			want: 2,
+		},
+		{
+			name: "empty array",
+			data: `[]`,
+			want: 0,
+		},
+		{
+			name:    "empty file",
+			data:    "  \n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			data:    `{"findings":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFindingsJSON([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFindingsJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("len(findings) = %d, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFileReviewerReview(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.json")
+	data := `[{"file":"pkg/auth/handler.go","line":42,"severity":"error","category":"security","description":"SQL injection"}]`
+
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("failed to write findings file: %v", err)
+	}
+
+	r := NewFileReviewer(path)
+
+	result, err := r.Review(context.Background(), &rcontext.ReviewContext{}, Options{})
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	if len(result.Findings) != 1 {
+		t.Fatalf("len(Findings) = %d, want 1", len(result.Findings))
+	}
+
+	f := result.Findings[0]
+	if f.File != "pkg/auth/handler.go" || f.Line != 42 || f.Category != "security" {
+		t.Errorf("finding = %+v, want pkg/auth/handler.go:42 security", f)
+	}
+}
+
+func TestFileReviewerMissingFile(t *testing.T) {
+	r := NewFileReviewer(filepath.Join(t.TempDir(), "missing.json"))
+
+	if _, err := r.Review(context.Background(), &rcontext.ReviewContext{}, Options{}); err == nil {
+		t.Error("expected error for missing findings file")
+	}
+}
+
+func TestNewReviewerFileBackend(t *testing.T) {
+	if _, err := NewReviewer(BackendFile); err == nil {
+		t.Error("expected error for file backend without findings file")
+	}
+}
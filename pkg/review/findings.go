@@ -0,0 +1,103 @@
+package review
+
+import (
+	"slices"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// severityRank orders severities from most to least important.
+var severityRank = map[string]int{
+	"error":      0,
+	"warning":    1,
+	"suggestion": 2,
+}
+
+// SortBySeverity stably sorts findings from most to least severe.
+// Unknown severities sort last.
+func SortBySeverity(findings []session.Finding) {
+	slices.SortStableFunc(findings, func(a, b session.Finding) int {
+		return rankOf(a.Severity) - rankOf(b.Severity)
+	})
+}
+
+// rankOf returns the sort rank for a severity.
+func rankOf(severity string) int {
+	if rank, ok := severityRank[severity]; ok {
+		return rank
+	}
+
+	return len(severityRank)
+}
+
+// CapFindings keeps the max most severe findings, returning them and the number omitted.
+// A max of 0 or less means unlimited; findings are returned unchanged.
+func CapFindings(findings []session.Finding, maxFindings int) ([]session.Finding, int) {
+	if maxFindings <= 0 || len(findings) <= maxFindings {
+		return findings, 0
+	}
+
+	sorted := slices.Clone(findings)
+	SortBySeverity(sorted)
+
+	return sorted[:maxFindings], len(findings) - maxFindings
+}
+
+// FilterCategories keeps only findings in the given categories.
+// Returns findings unchanged when categories is empty.
+func FilterCategories(findings []session.Finding, categories []string) []session.Finding {
+	if len(categories) == 0 {
+		return findings
+	}
+
+	kept := make([]session.Finding, 0, len(findings))
+
+	for _, f := range findings {
+		if slices.Contains(categories, f.Category) {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept
+}
+
+// AttachDocURLs sets DocURL on findings whose category has an entry in urls.
+// A DocURL already set is kept.
+func AttachDocURLs(findings []session.Finding, urls map[string]string) {
+	for i := range findings {
+		if findings[i].DocURL == "" {
+			findings[i].DocURL = urls[findings[i].Category]
+		}
+	}
+}
+
+// CollapseRanges merges findings with the same file, category and description
+// on consecutive lines into one finding spanning Line..EndLine.
+// Order follows the first finding of each merged group.
+func CollapseRanges(findings []session.Finding) []session.Finding {
+	type key struct{ file, category, description string }
+
+	var collapsed []session.Finding
+
+	last := make(map[key]int) // key -> index in collapsed of the most recent range
+
+	for _, f := range findings {
+		k := key{f.File, f.Category, f.Description}
+
+		if i, ok := last[k]; ok && f.Line > 0 && f.Line == rangeEnd(collapsed[i])+1 {
+			collapsed[i].EndLine = max(f.Line, f.EndLine)
+
+			continue
+		}
+
+		last[k] = len(collapsed)
+		collapsed = append(collapsed, f)
+	}
+
+	return collapsed
+}
+
+// rangeEnd returns the last line covered by a finding.
+func rangeEnd(f session.Finding) int {
+	return max(f.Line, f.EndLine)
+}
@@ -0,0 +1,134 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestCapFindings(t *testing.T) {
+	findings := []session.Finding{
+		{File: "a.go", Severity: "suggestion"},
+		{File: "b.go", Severity: "error"},
+		{File: "c.go", Severity: "warning"},
+		{File: "d.go", Severity: "error"},
+		{File: "e.go", Severity: "unknown"},
+	}
+
+	tests := []struct {
+		name        string
+		max         int
+		wantFiles   []string
+		wantOmitted int
+	}{
+		{"unlimited", 0, []string{"a.go", "b.go", "c.go", "d.go", "e.go"}, 0},
+		{"under cap", 10, []string{"a.go", "b.go", "c.go", "d.go", "e.go"}, 0},
+		{"keeps most severe, stable", 3, []string{"b.go", "d.go", "c.go"}, 2},
+		{"one", 1, []string{"b.go"}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, omitted := CapFindings(findings, tt.max)
+
+			if omitted != tt.wantOmitted {
+				t.Errorf("omitted = %d, want %d", omitted, tt.wantOmitted)
+			}
+
+			if len(got) != len(tt.wantFiles) {
+				t.Fatalf("got %d findings, want %d", len(got), len(tt.wantFiles))
+			}
+
+			for i, want := range tt.wantFiles {
+				if got[i].File != want {
+					t.Errorf("got[%d].File = %q, want %q", i, got[i].File, want)
+				}
+			}
+		})
+	}
+
+	if findings[0].File != "a.go" {
+		t.Error("CapFindings must not reorder the input slice")
+	}
+}
+
+func TestFilterCategories(t *testing.T) {
+	findings := []session.Finding{
+		{File: "a.go", Category: "security"},
+		{File: "b.go", Category: "style"},
+		{File: "c.go", Category: "bug"},
+	}
+
+	tests := []struct {
+		name       string
+		categories []string
+		wantFiles  []string
+	}{
+		{"no focus", nil, []string{"a.go", "b.go", "c.go"}},
+		{"focus", []string{"security", "bug"}, []string{"a.go", "c.go"}},
+		{"no match", []string{"performance"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterCategories(findings, tt.categories)
+
+			if len(got) != len(tt.wantFiles) {
+				t.Fatalf("got %d findings, want %d", len(got), len(tt.wantFiles))
+			}
+
+			for i, want := range tt.wantFiles {
+				if got[i].File != want {
+					t.Errorf("got[%d].File = %q, want %q", i, got[i].File, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAttachDocURLs(t *testing.T) {
+	findings := []session.Finding{
+		{File: "a.go", Category: "security"},
+		{File: "b.go", Category: "style"},
+		{File: "c.go", Category: "security", DocURL: "https://example.com/own"},
+	}
+
+	AttachDocURLs(findings, map[string]string{"security": "https://example.com/security"})
+
+	want := []string{"https://example.com/security", "", "https://example.com/own"}
+	for i, f := range findings {
+		if f.DocURL != want[i] {
+			t.Errorf("findings[%d].DocURL = %q, want %q", i, f.DocURL, want[i])
+		}
+	}
+}
+
+func TestCollapseRanges(t *testing.T) {
+	findings := []session.Finding{
+		{File: "a.go", Line: 40, Category: "bug", Description: "unchecked error"},
+		{File: "a.go", Line: 41, Category: "bug", Description: "unchecked error"},
+		{File: "b.go", Line: 7, Category: "style", Description: "long line"},
+		{File: "a.go", Line: 42, Category: "bug", Description: "unchecked error"},
+		{File: "a.go", Line: 50, Category: "bug", Description: "unchecked error"},
+		{File: "a.go", Line: 43, Category: "security", Description: "unchecked error"},
+	}
+
+	got := CollapseRanges(findings)
+
+	if len(got) != 4 {
+		t.Fatalf("got %d findings, want 4: %+v", len(got), got)
+	}
+
+	if got[0].Line != 40 || got[0].EndLine != 42 || got[0].Location() != "a.go:40-42" {
+		t.Errorf("got[0] = %+v, want a.go:40-42", got[0])
+	}
+	if got[1].File != "b.go" || got[1].EndLine != 0 {
+		t.Errorf("got[1] = %+v, want untouched b.go finding", got[1])
+	}
+	if got[2].Line != 50 || got[2].EndLine != 0 {
+		t.Errorf("got[2] = %+v, want non-consecutive line 50 kept separate", got[2])
+	}
+	if got[3].Category != "security" {
+		t.Errorf("got[3] = %+v, want different category kept separate", got[3])
+	}
+}
@@ -0,0 +1,96 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// ErrGateFailed indicates findings exceeded a --gate or --fail-on policy.
+var ErrGateFailed = errors.New("review gate failed")
+
+// Gate allows at most Max findings in Category.
+type Gate struct {
+	Category string
+	Max      int
+}
+
+// String returns the gate as "category:max".
+func (g Gate) String() string {
+	return fmt.Sprintf("%s:%d", g.Category, g.Max)
+}
+
+// ParseGate parses a "category:count" spec, e.g. "security:0" or "style:5".
+func ParseGate(spec string) (Gate, error) {
+	category, count, ok := strings.Cut(spec, ":")
+	category = strings.TrimSpace(category)
+
+	if !ok || category == "" {
+		return Gate{}, fmt.Errorf("invalid gate %q, expected category:count", spec)
+	}
+
+	maxCount, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || maxCount < 0 {
+		return Gate{}, fmt.Errorf("invalid gate %q, count must be a non-negative integer", spec)
+	}
+
+	return Gate{Category: category, Max: maxCount}, nil
+}
+
+// ValidSeverity reports whether severity is one of error, warning or suggestion.
+func ValidSeverity(severity string) bool {
+	_, ok := severityRank[severity]
+
+	return ok
+}
+
+// CheckGates evaluates the category gates and the failOn severity threshold
+// (any finding at or above it fails; empty disables it).
+// Returns an error wrapping ErrGateFailed that names every tripped gate, or nil.
+func CheckGates(findings []session.Finding, gates []Gate, failOn string) error {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Category]++
+	}
+
+	var tripped []string
+
+	for _, g := range gates {
+		if n := counts[g.Category]; n > g.Max {
+			tripped = append(tripped, fmt.Sprintf("%s has %d %s (max %d)", g.Category, n, pluralFindings(n), g.Max))
+		}
+	}
+
+	if failOn != "" {
+		threshold := rankOf(failOn)
+
+		n := 0
+		for _, f := range findings {
+			if rankOf(f.Severity) <= threshold {
+				n++
+			}
+		}
+
+		if n > 0 {
+			tripped = append(tripped, fmt.Sprintf("%d %s at %s or above (--fail-on %s)", n, pluralFindings(n), failOn, failOn))
+		}
+	}
+
+	if len(tripped) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrGateFailed, strings.Join(tripped, "; "))
+}
+
+// pluralFindings returns "finding" or "findings" for n.
+func pluralFindings(n int) string {
+	if n == 1 {
+		return "finding"
+	}
+
+	return "findings"
+}
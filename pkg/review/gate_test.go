@@ -0,0 +1,96 @@
+package review
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestParseGate(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Gate
+		wantErr bool
+	}{
+		{spec: "security:0", want: Gate{Category: "security", Max: 0}},
+		{spec: " style : 5 ", want: Gate{Category: "style", Max: 5}},
+		{spec: "security", wantErr: true},
+		{spec: ":3", wantErr: true},
+		{spec: "style:-1", wantErr: true},
+		{spec: "style:many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseGate(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGate(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseGate(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckGates(t *testing.T) {
+	findings := []session.Finding{
+		{Category: "security", Severity: "error"},
+		{Category: "style", Severity: "suggestion"},
+		{Category: "style", Severity: "suggestion"},
+		{Category: "bug", Severity: "warning"},
+	}
+
+	tests := []struct {
+		name     string
+		gates    []Gate
+		failOn   string
+		wantMsgs []string
+	}{
+		{name: "no policy"},
+		{name: "within limits", gates: []Gate{{"security", 1}, {"style", 5}}},
+		{
+			name:     "security tripped, style allowed",
+			gates:    []Gate{{"security", 0}, {"style", 5}},
+			wantMsgs: []string{"security has 1 finding (max 0)"},
+		},
+		{
+			name:     "gate and fail-on combined",
+			gates:    []Gate{{"style", 1}},
+			failOn:   "warning",
+			wantMsgs: []string{"style has 2 findings (max 1)", "2 findings at warning or above"},
+		},
+		{name: "fail-on error", failOn: "error", gates: []Gate{{"bug", 1}}, wantMsgs: []string{"1 finding at error"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckGates(findings, tt.gates, tt.failOn)
+
+			if len(tt.wantMsgs) == 0 {
+				if err != nil {
+					t.Errorf("CheckGates() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrGateFailed) {
+				t.Fatalf("CheckGates() error = %v, want ErrGateFailed", err)
+			}
+
+			for _, msg := range tt.wantMsgs {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("error %q should mention %q", err, msg)
+				}
+			}
+		})
+	}
+
+	if err := CheckGates(nil, nil, "suggestion"); err != nil {
+		t.Errorf("CheckGates(no findings) error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,255 @@
+package review
+
+import "testing"
+
+func TestParseFindings(t *testing.T) {
+	response := `Here are my findings:
+
+FINDING: [pkg/auth/handler.go:42] [error] [security]
+DESCRIPTION: SQL injection vulnerability in user query
+FIX: Use parameterized queries instead of string concatenation
+
+FINDING: [pkg/api/routes.go:100] [warning] [performance]
+DESCRIPTION: N+1 query detected in loop
+FIX: Batch the database queries outside the loop
+
+FINDING: [main.go:15] [suggestion] [style]
+DESCRIPTION: Unused import detected
+FIX: Remove the unused import
+`
+
+	findings := parseFindings(response, newFindingRules(Options{}))
+
+	if len(findings) != 3 {
+		t.Fatalf("len(findings) = %d, want 3", len(findings))
+	}
+
+	// Check first finding
+	f := findings[0]
+	if f.File != "pkg/auth/handler.go" {
+		t.Errorf("f.File = %q, want %q", f.File, "pkg/auth/handler.go")
+	}
+	if f.Line != 42 {
+		t.Errorf("f.Line = %d, want 42", f.Line)
+	}
+	if f.Severity != "error" {
+		t.Errorf("f.Severity = %q, want %q", f.Severity, "error")
+	}
+	if f.Category != "security" {
+		t.Errorf("f.Category = %q, want %q", f.Category, "security")
+	}
+	if f.Description != "SQL injection vulnerability in user query" {
+		t.Errorf("f.Description = %q, want %q", f.Description, "SQL injection vulnerability in user query")
+	}
+	if f.SuggestedFix != "Use parameterized queries instead of string concatenation" {
+		t.Errorf("f.SuggestedFix = %q", f.SuggestedFix)
+	}
+
+	// Check second finding
+	f = findings[1]
+	if f.File != "pkg/api/routes.go" {
+		t.Errorf("f.File = %q, want %q", f.File, "pkg/api/routes.go")
+	}
+	if f.Severity != "warning" {
+		t.Errorf("f.Severity = %q, want %q", f.Severity, "warning")
+	}
+	if f.Category != "performance" {
+		t.Errorf("f.Category = %q, want %q", f.Category, "performance")
+	}
+
+	// Check third finding
+	f = findings[2]
+	if f.Severity != "suggestion" {
+		t.Errorf("f.Severity = %q, want %q", f.Severity, "suggestion")
+	}
+	if f.Category != "style" {
+		t.Errorf("f.Category = %q, want %q", f.Category, "style")
+	}
+}
+
+func TestParseFindingsEmptyResponse(t *testing.T) {
+	for _, response := range []string{"", "   \n\t\n", "Looks good to me.\n" + CompletionMarker} {
+		if findings := parseFindings(response, newFindingRules(Options{})); len(findings) != 0 {
+			t.Errorf("parseFindings(%q) = %v, want none", response, findings)
+		}
+	}
+}
+
+func TestParseFindingLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		file     string
+		lineNum  int
+		severity string
+		category string
+	}{
+		{
+			line:     "FINDING: [pkg/auth/handler.go:42] [error] [security]",
+			file:     "pkg/auth/handler.go",
+			lineNum:  42,
+			severity: "error",
+			category: "security",
+		},
+		{
+			line:     `FINDING: [pkg\auth\handler.go:7] [error] [security]`,
+			file:     "pkg/auth/handler.go",
+			lineNum:  7,
+			severity: "error",
+			category: "security",
+		},
+		{
+			line:     "FINDING: [main.go:10] warning bug",
+			file:     "main.go",
+			lineNum:  10,
+			severity: "warning",
+			category: "bug",
+		},
+		{
+			line:     "FINDING: [test.go] suggestion style",
+			file:     "test.go",
+			lineNum:  0,
+			severity: "suggestion",
+			category: "style",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			f := parseFindingLine(tt.line, newFindingRules(Options{}))
+
+			if f.File != tt.file {
+				t.Errorf("File = %q, want %q", f.File, tt.file)
+			}
+			if f.Line != tt.lineNum {
+				t.Errorf("Line = %d, want %d", f.Line, tt.lineNum)
+			}
+			if f.Severity != tt.severity {
+				t.Errorf("Severity = %q, want %q", f.Severity, tt.severity)
+			}
+			if f.Category != tt.category {
+				t.Errorf("Category = %q, want %q", f.Category, tt.category)
+			}
+		})
+	}
+}
+
+func TestParseFindingLineSeverityDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		defaults map[string]string
+		severity string
+	}{
+		{
+			name:     "security defaults to error",
+			line:     "FINDING: [auth.go:1] [security]",
+			defaults: DefaultSeverities(),
+			severity: "error",
+		},
+		{
+			name:     "style defaults to suggestion",
+			line:     "FINDING: [main.go:5] [style]",
+			defaults: DefaultSeverities(),
+			severity: "suggestion",
+		},
+		{
+			name:     "unlisted category defaults to warning",
+			line:     "FINDING: [main.go:5] [performance]",
+			defaults: DefaultSeverities(),
+			severity: "warning",
+		},
+		{
+			name:     "explicit severity wins",
+			line:     "FINDING: [auth.go:1] [warning] [security]",
+			defaults: DefaultSeverities(),
+			severity: "warning",
+		},
+		{
+			name:     "no category keeps warning",
+			line:     "FINDING: [main.go:5]",
+			defaults: DefaultSeverities(),
+			severity: "warning",
+		},
+		{
+			name:     "custom defaults",
+			line:     "FINDING: [db.go:9] [performance]",
+			defaults: map[string]string{"performance": "error"},
+			severity: "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parseFindingLine(tt.line, findingRules{severities: tt.defaults, categories: builtinCategories})
+			if f.Severity != tt.severity {
+				t.Errorf("Severity = %q, want %q", f.Severity, tt.severity)
+			}
+		})
+	}
+}
+
+func TestParseFindingLineCustomCategories(t *testing.T) {
+	rules := newFindingRules(Options{
+		Categories:       []string{"accessibility"},
+		SeverityDefaults: map[string]string{"accessibility": "error"},
+	})
+
+	f := parseFindingLine("FINDING: [ui/button.tsx:12] [accessibility]", rules)
+
+	if f.Category != "accessibility" {
+		t.Errorf("Category = %q, want %q", f.Category, "accessibility")
+	}
+	if f.Severity != "error" {
+		t.Errorf("Severity = %q, want %q", f.Severity, "error")
+	}
+
+	// Built-ins still default as before
+	f = parseFindingLine("FINDING: [auth.go:1] [security]", rules)
+	if f.Severity != "error" {
+		t.Errorf("Severity = %q, want %q", f.Severity, "error")
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"42", 42},
+		{"100", 100},
+		{"0", 0},
+		{"123abc", 123},
+		{"abc", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := parseInt(tt.input)
+			if got != tt.expected {
+				t.Errorf("parseInt(%q) = %d, want %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFindingsDropsDisputedLinterHits(t *testing.T) {
+	response := `FINDING: [main.go:10] [error] [security]
+DESCRIPTION: DISPUTE L1 the variable is used through reflection
+DISPUTE: L2 console output is intended in this CLI
+FINDING: [main.go:20] [suggestion] [style]
+DESCRIPTION: CONFIRM L3 the import is unused
+FINDING: [web/app.js:3] [error] [security]
+DESCRIPTION: DISPUTE L4 input is already escaped`
+
+	findings := parseFindings(response, newFindingRules(Options{}))
+
+	if len(findings) != 1 || findings[0].Line != 20 {
+		t.Fatalf("parseFindings() = %+v, want only the confirmed main.go:20 finding", findings)
+	}
+
+	// Disputed false positives must not trip a gate
+	if err := CheckGates(findings, []Gate{{Category: "security", Max: 0}}, "error"); err != nil {
+		t.Errorf("CheckGates() error = %v, want nil for disputed linter hits", err)
+	}
+}
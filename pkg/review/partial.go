@@ -0,0 +1,58 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+)
+
+// ErrInterrupted indicates the review was cancelled (e.g. Ctrl-C) before the
+// agent finished. Review returns it together with a partial Result holding
+// the findings parsed from the text streamed so far.
+var ErrInterrupted = errors.New("review interrupted")
+
+// partialText collects the text an agent streams so an interrupted run still
+// has the response so far. Events are passed on to next.
+type partialText struct {
+	mu   sync.Mutex
+	text strings.Builder
+	next func(agent.Event)
+}
+
+// handle records text events and forwards every event.
+func (p *partialText) handle(e agent.Event) {
+	if e.Kind == agent.EventText {
+		p.mu.Lock()
+		p.text.WriteString(e.Text)
+		p.mu.Unlock()
+	}
+
+	if p.next != nil {
+		p.next(e)
+	}
+}
+
+// reset discards the text of a previous attempt.
+func (p *partialText) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.text.Reset()
+}
+
+// String returns the text streamed so far.
+func (p *partialText) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.text.String()
+}
+
+// interrupted reports whether err is from ctx being cancelled, as opposed to
+// a deadline or an agent failure.
+func interrupted(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,75 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+	"github.com/crealfy/crea-pipe/pkg/agent/mock"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestReviewInterruptedKeepsPartialFindings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var forwarded int
+
+	a := mock.New()
+	a.RunFunc = func(ctx context.Context, _ string, cfg *agent.Config) (*agent.Response, error) {
+		cfg.StreamHandler(agent.Event{Kind: agent.EventText, Text: "FINDING: [main.go:3] [error] [bug]\n"})
+		cfg.StreamHandler(agent.Event{Kind: agent.EventText, Text: "DESCRIPTION: nil dereference\n"})
+		cfg.StreamHandler(agent.Event{Kind: agent.EventToolStart, Tool: &agent.ToolEvent{Name: "Read"}})
+		cancel()
+
+		return nil, ctx.Err()
+	}
+
+	r := &Reviewer{agent: a, backend: BackendClaude}
+
+	result, err := r.Review(ctx, &rcontext.ReviewContext{}, Options{
+		StreamHandler: func(agent.Event) { forwarded++ },
+	})
+	if !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("Review() error = %v, want ErrInterrupted", err)
+	}
+
+	if result == nil || !result.Partial {
+		t.Fatalf("Review() result = %+v, want a partial result", result)
+	}
+
+	if len(result.Findings) != 1 || result.Findings[0].Description != "nil dereference" {
+		t.Errorf("Findings = %+v, want the streamed finding", result.Findings)
+	}
+
+	if forwarded != 3 {
+		t.Errorf("forwarded %d events to StreamHandler, want 3", forwarded)
+	}
+}
+
+func TestReviewAgentErrorIsNotInterrupted(t *testing.T) {
+	a := mock.New().WithError(errors.New("boom"))
+	r := &Reviewer{agent: a, backend: BackendClaude}
+
+	result, err := r.Review(context.Background(), &rcontext.ReviewContext{}, Options{})
+	if err == nil || errors.Is(err, ErrInterrupted) {
+		t.Fatalf("Review() error = %v, want a plain agent error", err)
+	}
+
+	if result != nil {
+		t.Errorf("Review() result = %+v, want nil", result)
+	}
+}
+
+func TestPartialTextReset(t *testing.T) {
+	var p partialText
+
+	p.handle(agent.Event{Kind: agent.EventText, Text: "first attempt"})
+	p.reset()
+	p.handle(agent.Event{Kind: agent.EventText, Text: "second"})
+	p.handle(agent.Event{Kind: agent.EventDone})
+
+	if got := p.String(); got != "second" {
+		t.Errorf("String() = %q, want %q", got, "second")
+	}
+}
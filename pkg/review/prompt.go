@@ -0,0 +1,342 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// CompletionMarker is the line the model is asked to end its response with,
+// so an empty or cut-off response can be told apart from a clean review.
+const CompletionMarker = "REVIEW COMPLETE"
+
+// reviewInstructions combines the user instructions, the path instructions
+// matching reviewCtx's files and any extra and focus categories.
+func reviewInstructions(reviewCtx *rcontext.ReviewContext, opts Options) string {
+	instructions := opts.Instructions
+
+	var files []string
+	for _, f := range reviewCtx.ChangedFiles {
+		files = append(files, f.Path)
+	}
+
+	for _, rule := range rcontext.MatchingPathInstructions(opts.PathInstructions, files) {
+		if instructions != "" {
+			instructions += "\n\n"
+		}
+
+		instructions += fmt.Sprintf("For files matching %s:\n%s", rule.Path, strings.TrimSpace(rule.Instructions))
+	}
+
+	if len(opts.Categories) > 0 {
+		if instructions != "" {
+			instructions += "\n\n"
+		}
+
+		instructions += "Additional finding categories: " + strings.Join(opts.Categories, ", ")
+	}
+
+	if len(opts.Focus) > 0 {
+		if instructions != "" {
+			instructions += "\n\n"
+		}
+
+		instructions += "Only report findings in these categories: " + strings.Join(opts.Focus, ", ")
+	}
+
+	return instructions
+}
+
+// DefaultPromptTemplate is the built-in review prompt, a text/template rendered
+// with the review context. It is the single source for both the prompt sent to
+// the model and --print-prompt-template.
+//
+// Fields: .Instructions, .CompletionMarker and every ReviewContext field
+// (.ChangedFiles, .Diff, .RelatedFiles, .LinterOutput, .PRTemplate, .Commits, ...).
+// Functions: changedFile, fileBlock, editorConfig, linterFindings, trimNewlines.
+const DefaultPromptTemplate = `You are an expert code reviewer. Review the following code changes.
+
+{{if .Instructions}}Additional instructions:
+{{.Instructions}}
+
+{{end}}## Changed Files
+
+{{range .ChangedFiles}}{{changedFile .}}
+{{end}}{{range .ChangedFiles}}{{fileBlock . ""}}{{end}}{{if .Diff}}
+## Diff
+
+` + "```diff" + `
+{{trimNewlines .Diff}}
+` + "```" + `
+{{end}}{{if .RelatedFiles}}
+## Related Files
+{{range .RelatedFiles}}{{fileBlock . .RelatedReason}}{{end}}{{end}}{{editorConfig .ChangedFiles}}{{linterFindings .LinterOutput}}{{if .PRTemplate}}
+## Reviewer Checklist
+
+This is the team's pull request template, the checklist human reviewers use. Check each item that applies to these changes and report any that are not met as findings.
+
+{{.PRTemplate}}
+{{end}}{{if .Commits}}
+## Commit Messages
+
+Also review these commit messages. Report any that are unclear, don't describe the change, or don't follow Conventional Commits (` + "`type(scope): subject`" + `) as findings with category commit, using the commit hash as the location: FINDING: [hash] [severity] [commit]
+
+{{range .Commits}}### {{.Hash}}
+{{.Subject}}
+{{if .Body}}
+{{.Body}}
+{{end}}
+{{end}}{{end}}
+Read these files and identify bugs, security issues, performance problems, and improvements.
+
+Format each finding as:
+FINDING: [file:line] [severity] [category]
+DESCRIPTION: <description>
+FIX: <suggested fix>
+
+When you have finished, end your response with a line containing only {{.CompletionMarker}}, even if you found no issues.
+`
+
+// promptFuncs are the helper functions available to prompt templates.
+var promptFuncs = template.FuncMap{
+	"changedFile":    changedFileLine,
+	"fileBlock":      fileBlock,
+	"editorConfig":   editorConfigSection,
+	"linterFindings": linterFindings,
+	"trimNewlines":   func(s string) string { return strings.TrimRight(s, "\n") },
+}
+
+// defaultPrompt is the parsed DefaultPromptTemplate.
+var defaultPrompt = template.Must(ParsePromptTemplate(DefaultPromptTemplate))
+
+// promptData is the value prompt templates are executed with.
+type promptData struct {
+	*rcontext.ReviewContext
+
+	// Instructions are the combined user instructions and extra categories.
+	Instructions string
+
+	// CompletionMarker is the line the model must end its response with.
+	CompletionMarker string
+}
+
+// ParsePromptTemplate parses a review prompt template with the prompt helper functions.
+func ParsePromptTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("prompt").Funcs(promptFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse prompt template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// renderPrompt renders the review prompt using opts.PromptTemplate, or the default template when empty.
+// File contents are embedded only when gathered; otherwise the agent reads files itself.
+// Invalid UTF-8, e.g. from a latin-1 file in the diff, becomes U+FFFD.
+func renderPrompt(reviewCtx *rcontext.ReviewContext, opts Options) (string, error) {
+	tmpl := defaultPrompt
+
+	if opts.PromptTemplate != "" {
+		var err error
+
+		tmpl, err = ParsePromptTemplate(opts.PromptTemplate)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	prompt, err := executePrompt(tmpl, reviewCtx, reviewInstructions(reviewCtx, opts))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToValidUTF8(prompt, string(utf8.RuneError)), nil
+}
+
+// executePrompt executes tmpl for reviewCtx and instructions.
+func executePrompt(tmpl *template.Template, reviewCtx *rcontext.ReviewContext, instructions string) (string, error) {
+	var sb strings.Builder
+
+	data := promptData{
+		ReviewContext:    reviewCtx,
+		Instructions:     instructions,
+		CompletionMarker: CompletionMarker,
+	}
+
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render prompt template: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
+// changedFileLine describes a changed file in the file list, without a trailing newline.
+func changedFileLine(f rcontext.FileContent) string {
+	switch {
+	case f.IsSubmodule:
+		return fmt.Sprintf("- %s (%s)", f.Path, f.Note)
+	case f.Status == rcontext.StatusWholeFile:
+		return fmt.Sprintf("- %s (whole file, %d lines)", f.Path, f.LinesTotal)
+	case f.IsPureRename():
+		return fmt.Sprintf("- %s (renamed from %s, no content changes)", f.Path, f.OldPath)
+	case f.IsRename():
+		return fmt.Sprintf("- %s (renamed from %s and modified, +%d/-%d lines)",
+			f.Path, f.OldPath, f.LinesAdded, f.LinesDeleted)
+	default:
+		return fmt.Sprintf("- %s (%s, +%d/-%d lines)",
+			f.Path, f.Status, f.LinesAdded, f.LinesDeleted)
+	}
+}
+
+// fileBlock returns a fenced code block for a file with embedded content.
+// Files without content produce nothing.
+func fileBlock(f rcontext.FileContent, reason string) string {
+	var sb strings.Builder
+
+	writeFileBlock(&sb, f, reason)
+
+	return sb.String()
+}
+
+// editorConfigSection returns the project style section listing each changed
+// file's .editorconfig settings, grouping files with identical settings.
+// Returns nothing when no file has any.
+func editorConfigSection(files []rcontext.FileContent) string {
+	var (
+		order  []string
+		groups = make(map[string][]string)
+	)
+
+	for _, f := range files {
+		if len(f.EditorConfig) == 0 {
+			continue
+		}
+
+		var settings []string
+
+		for _, key := range rcontext.EditorConfigKeys {
+			if v, ok := f.EditorConfig[key]; ok {
+				settings = append(settings, key+"="+v)
+			}
+		}
+
+		line := strings.Join(settings, ", ")
+		if _, seen := groups[line]; !seen {
+			order = append(order, line)
+		}
+
+		groups[line] = append(groups[line], f.Path)
+	}
+
+	if len(order) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("\n## Project Style\n\n")
+	sb.WriteString("The repository's .editorconfig sets these conventions for the changed files. ")
+	sb.WriteString("Don't report style findings that contradict them.\n\n")
+
+	for _, line := range order {
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", strings.Join(groups[line], ", "), line))
+	}
+
+	return sb.String()
+}
+
+// linterFindings returns the linter findings section, or nothing when there are none.
+func linterFindings(findings []rcontext.LinterFinding) string {
+	var sb strings.Builder
+
+	writeLinterFindings(&sb, findings)
+
+	return sb.String()
+}
+
+// writeFileBlock writes a fenced code block for a file with embedded content.
+// Files without content are skipped.
+func writeFileBlock(sb *strings.Builder, f rcontext.FileContent, reason string) {
+	if f.Content == "" {
+		return
+	}
+
+	sb.WriteString("\n### ")
+	sb.WriteString(f.Path)
+
+	if reason != "" {
+		sb.WriteString(" (")
+		sb.WriteString(reason)
+		sb.WriteString(")")
+	}
+
+	sb.WriteString("\n\n")
+
+	if f.Truncated {
+		sb.WriteString(fmt.Sprintf("(Truncated to %d lines, read the file for the full content)\n\n", f.LinesTotal))
+	}
+
+	if f.Scoped {
+		sb.WriteString("(Changed regions only, each line prefixed with its line number; read the file for the rest)\n\n")
+	}
+
+	if f.InvalidUTF8 {
+		sb.WriteString("(Not valid UTF-8; undecodable bytes are shown as \uFFFD)\n\n")
+	}
+
+	sb.WriteString("```")
+	sb.WriteString(fenceLanguage(f.Language))
+	sb.WriteString("\n")
+	sb.WriteString(strings.TrimRight(f.Content, "\n"))
+	sb.WriteString("\n```\n")
+}
+
+// fenceLanguage returns the info string for a code fence.
+// Unknown languages ("text") get a plain fence.
+func fenceLanguage(language string) string {
+	if language == "text" {
+		return ""
+	}
+
+	return language
+}
+
+// writeLinterFindings writes linter output as numbered, file:line-anchored
+// entries and asks the model to confirm, expand, or dispute each one rather
+// than re-deriving it. Disputes use a DISPUTE: line, not a FINDING, so a
+// false positive never becomes a finding.
+func writeLinterFindings(sb *strings.Builder, findings []rcontext.LinterFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	sb.WriteString("\n## Linter Findings\n\n")
+	sb.WriteString("These were reported by static analysis. Treat each one as real unless you can show it is a false positive. ")
+	sb.WriteString("For a real one, emit a FINDING at the same [file:line] whose DESCRIPTION starts with ")
+	sb.WriteString("CONFIRM L<n> (explain the impact) or EXPAND L<n> (add root cause or related problems). ")
+	sb.WriteString("For a false positive, emit no FINDING; write a line DISPUTE: L<n> followed by why. ")
+	sb.WriteString("Spend the rest of the review on issues linters can't see.\n\n")
+
+	for i, lf := range findings {
+		sb.WriteString(fmt.Sprintf("L%d. [%s:%d] [%s] %s", i+1, lf.File, lf.Line, lf.Level, lf.Tool))
+
+		if lf.RuleID != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", lf.RuleID))
+		}
+
+		sb.WriteString(": ")
+		sb.WriteString(lf.Message)
+		sb.WriteString("\n")
+	}
+}
+
+// disputed reports whether a finding only disputes a linter entry, which
+// models sometimes write as a FINDING despite the DISPUTE: line the prompt
+// asks for. A disputed linter hit is a false positive, not a finding.
+func disputed(f *session.Finding) bool {
+	return strings.HasPrefix(f.Description, "DISPUTE L")
+}
@@ -0,0 +1,235 @@
+package review
+
+import (
+	"strings"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+// buildReviewPrompt renders the default prompt template, panicking on error.
+func buildReviewPrompt(reviewCtx *rcontext.ReviewContext, instructions string) string {
+	prompt, err := renderPrompt(reviewCtx, Options{Instructions: instructions})
+	if err != nil {
+		panic(err)
+	}
+
+	return prompt
+}
+
+func TestDefaultPromptTemplate(t *testing.T) {
+	if !strings.Contains(DefaultPromptTemplate, "{{range .ChangedFiles}}") {
+		t.Error("DefaultPromptTemplate should iterate changed files")
+	}
+
+	if _, err := ParsePromptTemplate(DefaultPromptTemplate); err != nil {
+		t.Fatalf("ParsePromptTemplate(DefaultPromptTemplate) error = %v", err)
+	}
+}
+
+func TestRenderPromptCustomTemplate(t *testing.T) {
+	reviewCtx := &rcontext.ReviewContext{
+		ChangedFiles: []rcontext.FileContent{
+			{Path: "main.go", Status: "modified", LinesAdded: 3},
+		},
+	}
+
+	opts := Options{
+		Instructions:   "Be brief",
+		PromptTemplate: "{{.Instructions}}|{{range .ChangedFiles}}{{changedFile .}}{{end}}|{{.CompletionMarker}}",
+	}
+
+	got, err := renderPrompt(reviewCtx, opts)
+	if err != nil {
+		t.Fatalf("renderPrompt() error = %v", err)
+	}
+
+	want := "Be brief|- main.go (modified, +3/-0 lines)|" + CompletionMarker
+	if got != want {
+		t.Errorf("renderPrompt() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderPromptTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"parse error", "{{.Instructions"},
+		{"unknown field", "{{.NoSuchField}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderPrompt(&rcontext.ReviewContext{}, Options{PromptTemplate: tt.template})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestBuildReviewPromptPRTemplate(t *testing.T) {
+	reviewCtx := &rcontext.ReviewContext{
+		ChangedFiles: []rcontext.FileContent{{Path: "main.go", Status: "modified"}},
+		PRTemplate:   "- [ ] Migrations are reversible\n- [ ] Docs updated",
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	if !strings.Contains(prompt, "## Reviewer Checklist") {
+		t.Errorf("prompt should include the checklist section:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "- [ ] Migrations are reversible\n- [ ] Docs updated\n") {
+		t.Errorf("prompt should include the template content:\n%s", prompt)
+	}
+
+	reviewCtx.PRTemplate = ""
+	if strings.Contains(buildReviewPrompt(reviewCtx, ""), "Reviewer Checklist") {
+		t.Error("prompt should omit the checklist without a template")
+	}
+}
+
+func TestBuildReviewPromptCommits(t *testing.T) {
+	reviewCtx := &rcontext.ReviewContext{
+		ChangedFiles: []rcontext.FileContent{{Path: "main.go", Status: "modified"}},
+		Commits: []rcontext.Commit{
+			{Hash: "a1b2c3d", Subject: "feat(auth): add token refresh", Body: "Refresh tokens a minute before expiry."},
+			{Hash: "e4f5a6b", Subject: "wip"},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	for _, want := range []string{
+		"## Commit Messages",
+		"category commit",
+		"### a1b2c3d\nfeat(auth): add token refresh\n\nRefresh tokens a minute before expiry.\n",
+		"### e4f5a6b\nwip\n",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	reviewCtx.Commits = nil
+	if strings.Contains(buildReviewPrompt(reviewCtx, ""), "Commit Messages") {
+		t.Error("prompt should omit the commit section without commits")
+	}
+}
+
+func TestParseCommitFinding(t *testing.T) {
+	findings := parseFindings("FINDING: [e4f5a6b] [commit]\nDESCRIPTION: Subject doesn't describe the change", newFindingRules(Options{}))
+
+	if len(findings) != 1 {
+		t.Fatalf("len(findings) = %d, want 1", len(findings))
+	}
+
+	f := findings[0]
+	if f.File != "e4f5a6b" || f.Category != "commit" || f.Severity != "suggestion" {
+		t.Errorf("finding = %+v, want e4f5a6b commit suggestion", f)
+	}
+}
+
+func TestBuildReviewPromptWholeFile(t *testing.T) {
+	reviewCtx := &rcontext.ReviewContext{
+		ChangedFiles: []rcontext.FileContent{
+			{Path: "main.go", Language: "go", Content: "package main", LinesTotal: 1, Status: rcontext.StatusWholeFile},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+	if !strings.Contains(prompt, "- main.go (whole file, 1 lines)") {
+		t.Errorf("prompt should list the whole file:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "## Diff") {
+		t.Error("prompt should not include an empty diff section")
+	}
+}
+
+func TestBuildReviewPromptEditorConfig(t *testing.T) {
+	reviewCtx := &rcontext.ReviewContext{
+		ChangedFiles: []rcontext.FileContent{
+			{Path: "main.go", EditorConfig: map[string]string{"indent_style": "tab"}},
+			{Path: "web/app.ts", EditorConfig: map[string]string{"indent_size": "2", "indent_style": "space"}},
+			{Path: "util.go", EditorConfig: map[string]string{"indent_style": "tab"}},
+			{Path: "README.md"},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	for _, want := range []string{
+		"## Project Style",
+		"- main.go, util.go: indent_style=tab\n",
+		"- web/app.ts: indent_style=space, indent_size=2\n",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	if strings.Contains(prompt, "README.md:") {
+		t.Error("prompt should not list files without settings")
+	}
+
+	reviewCtx.ChangedFiles = reviewCtx.ChangedFiles[3:]
+	if strings.Contains(buildReviewPrompt(reviewCtx, ""), "Project Style") {
+		t.Error("prompt should omit the style section without settings")
+	}
+}
+
+func TestRenderPromptPathInstructions(t *testing.T) {
+	opts := Options{
+		Instructions: "Check error handling.",
+		PathInstructions: []rcontext.PathInstruction{
+			{Path: "migrations/", Instructions: "Check for destructive operations."},
+			{Path: "*.proto", Instructions: "Keep field numbers stable."},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		files   []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "migration present",
+			files:   []string{"main.go", "db/migrations/0042_drop_users.sql"},
+			want:    []string{"Check error handling.\n\nFor files matching migrations/:\nCheck for destructive operations."},
+			notWant: []string{"Keep field numbers stable."},
+		},
+		{
+			name:    "no matching paths",
+			files:   []string{"main.go"},
+			notWant: []string{"For files matching"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewCtx := &rcontext.ReviewContext{}
+			for _, f := range tt.files {
+				reviewCtx.ChangedFiles = append(reviewCtx.ChangedFiles, rcontext.FileContent{Path: f, Status: "modified"})
+			}
+
+			prompt, err := renderPrompt(reviewCtx, opts)
+			if err != nil {
+				t.Fatalf("renderPrompt() error = %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(prompt, want) {
+					t.Errorf("prompt should contain %q:\n%s", want, prompt)
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if strings.Contains(prompt, notWant) {
+					t.Errorf("prompt should not contain %q:\n%s", notWant, prompt)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package review
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestBuildReviewPrompt(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		RepoPath:   "/test/repo",
+		BaseCommit: "abc123",
+		HeadCommit: "def456",
+		Diff:       "diff --git a/main.go b/main.go\n+ new line",
+		ChangedFiles: []context.FileContent{
+			{
+				Path:     "main.go",
+				Language: "go",
+				Content:  "package main\n\nfunc main() {}",
+				Status:   "modified",
+			},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "Focus on security")
+
+	// Check prompt contains expected sections
+	if !contains(prompt, "code reviewer") {
+		t.Error("prompt should mention code reviewer")
+	}
+	if !contains(prompt, "Focus on security") {
+		t.Error("prompt should include instructions")
+	}
+	if !contains(prompt, "## Diff") {
+		t.Error("prompt should include diff section")
+	}
+	if !contains(prompt, "## Changed Files") {
+		t.Error("prompt should include changed files section")
+	}
+	if !contains(prompt, "main.go") {
+		t.Error("prompt should include file name")
+	}
+	if !contains(prompt, "```go") {
+		t.Error("prompt should include language-specific code block")
+	}
+}
+
+func TestBuildReviewPromptSubmodule(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		ChangedFiles: []context.FileContent{
+			{Path: "vendor/lib", Status: "modified", IsSubmodule: true, Note: "submodule 3f786850e387 → 89e6c98d9288"},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+	if !contains(prompt, "- vendor/lib (submodule 3f786850e387 → 89e6c98d9288)") {
+		t.Errorf("prompt should describe the submodule bump:\n%s", prompt)
+	}
+}
+
+func TestBuildReviewPromptRenames(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		Diff: "diff --git a/pkg/old.go b/pkg/new.go\nsimilarity index 80%\nrename from pkg/old.go\nrename to pkg/new.go\n",
+		ChangedFiles: []context.FileContent{
+			{Path: "pkg/new.go", OldPath: "pkg/old.go", Status: "renamed", LinesAdded: 4, LinesDeleted: 2},
+			{Path: "pkg/moved.go", OldPath: "lib/moved.go", Status: "renamed"},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	for _, want := range []string{
+		"- pkg/new.go (renamed from pkg/old.go and modified, +4/-2 lines)",
+		"- pkg/moved.go (renamed from lib/moved.go, no content changes)",
+		"rename from pkg/old.go",
+	} {
+		if !contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
+
+func TestBuildReviewPromptFenceLanguage(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  context.FileContent
+		fence string
+	}{
+		{"python", context.FileContent{Path: "app.py", Language: "python", Content: "x = 1"}, "```python\nx = 1\n```"},
+		{"yaml", context.FileContent{Path: "ci.yaml", Language: "yaml", Content: "on: push"}, "```yaml\non: push\n```"},
+		{"text", context.FileContent{Path: "LICENSE", Language: "text", Content: "MIT"}, "```\nMIT\n```"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewCtx := &context.ReviewContext{ChangedFiles: []context.FileContent{tt.file}}
+
+			prompt := buildReviewPrompt(reviewCtx, "")
+			if !contains(prompt, tt.fence) {
+				t.Errorf("prompt missing fence %q:\n%s", tt.fence, prompt)
+			}
+		})
+	}
+}
+
+func TestBuildReviewPromptWithRelatedFiles(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		RepoPath: "/test/repo",
+		Diff:     "diff",
+		ChangedFiles: []context.FileContent{
+			{Path: "main.go", Language: "go", Content: "code"},
+		},
+		RelatedFiles: []context.FileContent{
+			{
+				Path:          "utils.go",
+				Language:      "go",
+				Content:       "utils code",
+				RelatedReason: "co-changed 5 times",
+			},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	if !contains(prompt, "## Related Files") {
+		t.Error("prompt should include related files section")
+	}
+	if !contains(prompt, "co-changed 5 times") {
+		t.Error("prompt should include related reason")
+	}
+}
+
+func TestBuildReviewPromptWithLinterOutput(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		RepoPath:     "/test/repo",
+		Diff:         "diff",
+		ChangedFiles: []context.FileContent{},
+		LinterOutput: []context.LinterFinding{
+			{
+				Tool:    "golangci-lint",
+				File:    "main.go",
+				Line:    10,
+				Column:  5,
+				Level:   "error",
+				Message: "unused variable",
+			},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	if !contains(prompt, "## Linter Findings") {
+		t.Error("prompt should include linter findings section")
+	}
+	if !contains(prompt, "golangci-lint") {
+		t.Error("prompt should include linter name")
+	}
+	if !contains(prompt, "unused variable") {
+		t.Error("prompt should include linter message")
+	}
+}
+
+func TestBuildReviewPromptLinterAnchors(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		LinterOutput: []context.LinterFinding{
+			{Tool: "golangci-lint", File: "main.go", Line: 10, Column: 5, Level: "error", Message: "unused variable", RuleID: "unused"},
+			{Tool: "eslint", File: "web/app.js", Line: 3, Level: "warning", Message: "no-console"},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	for _, want := range []string{
+		"L1. [main.go:10] [error] golangci-lint (unused): unused variable\n",
+		"L2. [web/app.js:3] [warning] eslint: no-console\n",
+		"CONFIRM L<n>",
+		"EXPAND L<n>",
+		"DISPUTE: L<n>",
+	} {
+		if !contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	if contains(prompt, "ground truth") {
+		t.Errorf("prompt calls linter output ground truth while inviting disputes:\n%s", prompt)
+	}
+}
+
+func TestBuildReviewPromptTruncatedFile(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		RepoPath: "/test/repo",
+		Diff:     "diff",
+		ChangedFiles: []context.FileContent{
+			{
+				Path:       "large_file.go",
+				Language:   "go",
+				Content:    "package main",
+				Truncated:  true,
+				LinesTotal: 5000,
+				Status:     "modified",
+			},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	if !contains(prompt, "Truncated to 5000 lines") {
+		t.Error("prompt should indicate file truncation")
+	}
+}
+
+func TestBuildReviewPromptInvalidUTF8File(t *testing.T) {
+	reviewCtx := &context.ReviewContext{
+		RepoPath: "/test/repo",
+		Diff:     "diff",
+		ChangedFiles: []context.FileContent{
+			{
+				Path:        "menu.txt",
+				Language:    "text",
+				Content:     "caf\uFFFD",
+				InvalidUTF8: true,
+				Status:      "modified",
+			},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, "")
+
+	if !contains(prompt, "Not valid UTF-8") {
+		t.Error("prompt should flag the file's invalid UTF-8")
+	}
+}
+
+func TestRenderPromptLatin1Diff(t *testing.T) {
+	// "café" in latin-1 reaches the diff unchanged: é is the lone byte 0xE9
+	reviewCtx := &context.ReviewContext{
+		RepoPath: "/test/repo",
+		Diff:     "diff --git a/menu.txt b/menu.txt\n+caf\xe9\n",
+		ChangedFiles: []context.FileContent{
+			{Path: "menu.txt", Language: "text", Content: "caf\uFFFD", InvalidUTF8: true, Status: "modified"},
+		},
+	}
+
+	prompt, err := renderPrompt(reviewCtx, Options{})
+	if err != nil {
+		t.Fatalf("renderPrompt() error = %v", err)
+	}
+
+	if !utf8.ValidString(prompt) {
+		t.Error("prompt is not valid UTF-8")
+	}
+
+	if !contains(prompt, "+caf\uFFFD") {
+		t.Error("prompt should keep the diff line with U+FFFD for the invalid byte")
+	}
+}
+
+func TestBuildReviewPromptWithInstructions(t *testing.T) {
+	instructions := "Focus on:\n1. SQL injection\n2. XSS vulnerabilities"
+
+	reviewCtx := &context.ReviewContext{
+		RepoPath: "/test/repo",
+		Diff:     "diff",
+		ChangedFiles: []context.FileContent{
+			{Path: "main.go", Language: "go", Content: "code"},
+		},
+	}
+
+	prompt := buildReviewPrompt(reviewCtx, instructions)
+
+	if !contains(prompt, "Additional instructions:") {
+		t.Error("prompt should include additional instructions header")
+	}
+	if !contains(prompt, "Focus on:") {
+		t.Error("prompt should include custom instructions")
+	}
+	if !contains(prompt, "SQL injection") {
+		t.Error("prompt should include SQL injection instruction")
+	}
+}
@@ -0,0 +1,69 @@
+package review
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+)
+
+// registry holds backends added with RegisterBackend.
+var registry = struct {
+	sync.RWMutex
+	factories map[Backend]func() agent.Agent
+}{factories: make(map[Backend]func() agent.Agent)}
+
+// builtinBackends are the names RegisterBackend can't take.
+var builtinBackends = []Backend{BackendClaude, BackendCodex, BackendFile, BackendAuto}
+
+// RegisterBackend makes an agent available as a backend under name, so
+// external packages can add providers; call it from an init function.
+// NewReviewer consults registered backends after the built-in ones.
+// It panics if name is empty or built in, factory is nil, or name is
+// already registered.
+func RegisterBackend(name string, factory func() agent.Agent) {
+	backend := Backend(name)
+
+	switch {
+	case name == "":
+		panic("review: RegisterBackend with empty name")
+	case factory == nil:
+		panic("review: RegisterBackend factory is nil for " + name)
+	case slices.Contains(builtinBackends, backend):
+		panic("review: RegisterBackend cannot replace built-in backend " + name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, dup := registry.factories[backend]; dup {
+		panic("review: RegisterBackend called twice for " + name)
+	}
+
+	registry.factories[backend] = factory
+}
+
+// registeredBackend returns the factory registered for backend, if any.
+func registeredBackend(backend Backend) (func() agent.Agent, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	factory, ok := registry.factories[backend]
+
+	return factory, ok
+}
+
+// RegisteredBackends returns the names added with RegisterBackend, sorted.
+func RegisteredBackends() []Backend {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]Backend, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,78 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+	"github.com/crealfy/crea-pipe/pkg/agent/mock"
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestRegisterBackend(t *testing.T) {
+	const name = "test-registry-fake"
+
+	RegisterBackend(name, func() agent.Agent {
+		return mock.New().WithResponse(&agent.Response{
+			Text: "FINDING: [main.go:7] [error] [bug]\nDESCRIPTION: off by one\n" + CompletionMarker,
+		})
+	})
+
+	if !slices.Contains(RegisteredBackends(), Backend(name)) {
+		t.Fatalf("RegisteredBackends() = %v, want %s", RegisteredBackends(), name)
+	}
+
+	r, err := NewReviewer(Backend(name))
+	if err != nil {
+		t.Fatalf("NewReviewer() error = %v", err)
+	}
+
+	result, err := r.Review(context.Background(), &rcontext.ReviewContext{}, Options{})
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	if len(result.Findings) != 1 || result.Findings[0].Description != "off by one" {
+		t.Errorf("Findings = %+v, want the fake backend's finding", result.Findings)
+	}
+
+	// Auto mode can pick it too
+	if r, err := NewReviewerAuto([]Backend{Backend(name)}); err != nil || r.Backend() != Backend(name) {
+		t.Errorf("NewReviewerAuto() = %v, %v", r, err)
+	}
+
+	if _, err := NewReviewer("test-registry-missing"); !errors.Is(err, ErrUnknownBackend) {
+		t.Errorf("NewReviewer(unregistered) error = %v, want ErrUnknownBackend", err)
+	}
+}
+
+func TestRegisterBackendPanics(t *testing.T) {
+	factory := func() agent.Agent { return mock.New() }
+
+	RegisterBackend("test-registry-dup", factory)
+
+	tests := []struct {
+		name    string
+		backend string
+		factory func() agent.Agent
+	}{
+		{"empty name", "", factory},
+		{"nil factory", "test-registry-nil", nil},
+		{"built-in", string(BackendClaude), factory},
+		{"duplicate", "test-registry-dup", factory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterBackend(%q) did not panic", tt.backend)
+				}
+			}()
+
+			RegisterBackend(tt.backend, tt.factory)
+		})
+	}
+}
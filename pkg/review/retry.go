@@ -0,0 +1,102 @@
+package review
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+)
+
+// DefaultRetryMaxDelayMS caps the backoff between retries when Options.RetryMaxDelayMS is unset.
+const DefaultRetryMaxDelayMS = 30_000
+
+// retryPolicy retries agent runs with exponential backoff and full jitter.
+type retryPolicy struct {
+	// retries is the number of retries after the first attempt.
+	retries int
+
+	// baseDelay is the backoff before the first retry (before jitter).
+	baseDelay time.Duration
+
+	// maxDelay caps the backoff (before jitter).
+	maxDelay time.Duration
+
+	// jitter returns a value in [0, 1) scaling each delay.
+	jitter func() float64
+
+	// sleep waits for d or until ctx is done.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// newRetryPolicy creates a retry policy from millisecond settings.
+func newRetryPolicy(retries, delayMS, maxDelayMS int) retryPolicy {
+	if maxDelayMS <= 0 {
+		maxDelayMS = DefaultRetryMaxDelayMS
+	}
+
+	return retryPolicy{
+		retries:   retries,
+		baseDelay: time.Duration(delayMS) * time.Millisecond,
+		maxDelay:  time.Duration(maxDelayMS) * time.Millisecond,
+		jitter:    rand.Float64, //nolint:gosec // jitter does not need cryptographic randomness
+		sleep:     sleepContext,
+	}
+}
+
+// delay returns the jittered backoff before retry number attempt (0-based).
+func (p retryPolicy) delay(attempt int) time.Duration {
+	d := p.baseDelay
+	for range attempt {
+		if d >= p.maxDelay/2 {
+			d = p.maxDelay
+
+			break
+		}
+
+		d *= 2
+	}
+
+	d = min(d, p.maxDelay)
+
+	return time.Duration(p.jitter() * float64(d))
+}
+
+// run calls fn until it succeeds, retries are exhausted, or ctx is done.
+func (p retryPolicy) run(ctx context.Context, fn func() (*agent.Response, error)) (*agent.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= p.retries; attempt++ {
+		if attempt > 0 {
+			if err := p.sleep(ctx, p.delay(attempt-1)); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := fn()
+		if err == nil {
+			return response, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// sleepContext waits for d, returning early with ctx.Err() if ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
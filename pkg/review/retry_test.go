@@ -0,0 +1,164 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crealfy/crea-pipe/pkg/agent"
+)
+
+// fakeClock records sleeps instead of waiting.
+type fakeClock struct {
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) sleep(ctx context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+
+	return ctx.Err()
+}
+
+func TestRetryPolicyDelaySchedule(t *testing.T) {
+	p := retryPolicy{
+		baseDelay: 100 * time.Millisecond,
+		maxDelay:  time.Second,
+		jitter:    func() float64 { return 1 },
+	}
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+		time.Second,
+	}
+
+	for attempt, w := range want {
+		if got := p.delay(attempt); got != w {
+			t.Errorf("delay(%d) = %v, want %v", attempt, got, w)
+		}
+	}
+
+	// Large attempt counts must not overflow
+	if got := p.delay(200); got != time.Second {
+		t.Errorf("delay(200) = %v, want %v", got, time.Second)
+	}
+}
+
+func TestRetryPolicyFullJitter(t *testing.T) {
+	p := retryPolicy{
+		baseDelay: 100 * time.Millisecond,
+		maxDelay:  time.Second,
+		jitter:    func() float64 { return 0.5 },
+	}
+
+	if got := p.delay(2); got != 200*time.Millisecond {
+		t.Errorf("delay(2) = %v, want %v", got, 200*time.Millisecond)
+	}
+}
+
+func TestRetryPolicyRun(t *testing.T) {
+	clock := &fakeClock{}
+	p := retryPolicy{
+		retries:   3,
+		baseDelay: 100 * time.Millisecond,
+		maxDelay:  250 * time.Millisecond,
+		jitter:    func() float64 { return 1 },
+		sleep:     clock.sleep,
+	}
+
+	calls := 0
+	resp, err := p.run(context.Background(), func() (*agent.Response, error) {
+		calls++
+		if calls < 4 {
+			return nil, errors.New("rate limited")
+		}
+
+		return &agent.Response{Text: "ok"}, nil
+	})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	if resp.Text != "ok" || calls != 4 {
+		t.Errorf("resp = %q after %d calls, want ok after 4", resp.Text, calls)
+	}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond}
+	if len(clock.sleeps) != len(want) {
+		t.Fatalf("sleeps = %v, want %v", clock.sleeps, want)
+	}
+
+	for i := range want {
+		if clock.sleeps[i] != want[i] {
+			t.Errorf("sleeps[%d] = %v, want %v", i, clock.sleeps[i], want[i])
+		}
+	}
+}
+
+func TestRetryPolicyRunExhausted(t *testing.T) {
+	clock := &fakeClock{}
+	p := retryPolicy{
+		retries:   2,
+		baseDelay: time.Millisecond,
+		maxDelay:  time.Second,
+		jitter:    func() float64 { return 0 },
+		sleep:     clock.sleep,
+	}
+
+	wantErr := errors.New("boom")
+	calls := 0
+
+	_, err := p.run(context.Background(), func() (*agent.Response, error) {
+		calls++
+
+		return nil, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("run() error = %v, want %v", err, wantErr)
+	}
+
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestRetryPolicyRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := newRetryPolicy(5, 60_000, 0)
+	calls := 0
+
+	start := time.Now()
+
+	_, err := p.run(ctx, func() (*agent.Response, error) {
+		calls++
+
+		return nil, errors.New("transient")
+	})
+	if err == nil {
+		t.Error("run() error = nil, want failure")
+	}
+
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Error("canceled context should interrupt the backoff sleep")
+	}
+}
+
+func TestSleepContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := sleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("sleepContext() error = %v, want context.Canceled", err)
+	}
+}
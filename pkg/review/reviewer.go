@@ -3,7 +3,11 @@ package review
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,29 +24,42 @@ type Backend string
 const (
 	BackendClaude Backend = "claude"
 	BackendCodex  Backend = "codex"
+
+	// BackendFile loads findings from a JSON file instead of calling an agent.
+	BackendFile Backend = "file"
+
+	// BackendAuto picks the first available backend (see NewReviewerAuto).
+	BackendAuto Backend = "auto"
+)
+
+// DefaultAutoOrder is the order BackendAuto tries backends in.
+var DefaultAutoOrder = []Backend{BackendClaude, BackendCodex}
+
+// Sentinel errors returned by NewReviewer.
+var (
+	// ErrUnknownBackend indicates a backend name that isn't recognized.
+	ErrUnknownBackend = errors.New("unknown backend")
+
+	// ErrBackendUnavailable indicates the backend's CLI isn't installed or accessible.
+	ErrBackendUnavailable = errors.New("backend not available")
 )
 
 // Reviewer performs AI code reviews.
 type Reviewer struct {
-	agent   agent.Agent
-	backend Backend
+	agent        agent.Agent
+	backend      Backend
+	findingsFile string
 }
 
 // NewReviewer creates a new reviewer with the specified backend.
 func NewReviewer(backend Backend) (*Reviewer, error) {
-	var a agent.Agent
-
-	switch backend {
-	case BackendClaude:
-		a = claude.NewSDK()
-	case BackendCodex:
-		a = codex.New()
-	default:
-		return nil, fmt.Errorf("unknown backend: %s", backend)
+	a, err := newAgent(backend)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := a.Available(); err != nil {
-		return nil, fmt.Errorf("%s not available: %w", backend, err)
+		return nil, fmt.Errorf("%w: %s: %w", ErrBackendUnavailable, backend, err)
 	}
 
 	return &Reviewer{
@@ -51,6 +68,100 @@ func NewReviewer(backend Backend) (*Reviewer, error) {
 	}, nil
 }
 
+// newAgent constructs the agent for a built-in or registered backend without
+// checking availability.
+func newAgent(backend Backend) (agent.Agent, error) {
+	switch backend {
+	case BackendClaude:
+		return claude.NewSDK(), nil
+	case BackendCodex:
+		return codex.New(), nil
+	case BackendFile:
+		return nil, fmt.Errorf("%s backend requires a findings file (use NewFileReviewer)", backend)
+	}
+
+	if factory, ok := registeredBackend(backend); ok {
+		return factory(), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownBackend, backend)
+}
+
+// BackendStatus reports whether an AI backend can be used.
+type BackendStatus struct {
+	Backend   Backend
+	Available bool
+	// Reason explains why the backend is unavailable; empty when available.
+	Reason string
+}
+
+// CheckBackends reports the availability of each AI backend in DefaultAutoOrder,
+// then of each registered backend (see RegisterBackend).
+// It only runs each agent's Available check; no review is started.
+func CheckBackends() []BackendStatus {
+	backends := append(slices.Clone(DefaultAutoOrder), RegisteredBackends()...)
+	statuses := make([]BackendStatus, 0, len(backends))
+
+	for _, backend := range backends {
+		status := BackendStatus{Backend: backend}
+
+		a, err := newAgent(backend)
+		if err == nil {
+			err = a.Available()
+		}
+
+		if err != nil {
+			status.Reason = err.Error()
+		} else {
+			status.Available = true
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// NewReviewerAuto creates a reviewer for the first available backend in order.
+// Unknown backend names fail immediately; otherwise the error lists every backend tried.
+func NewReviewerAuto(order []Backend) (*Reviewer, error) {
+	if len(order) == 0 {
+		return nil, fmt.Errorf("%w: no backends to try", ErrBackendUnavailable)
+	}
+
+	var errs []error
+
+	for _, backend := range order {
+		r, err := NewReviewer(backend)
+		if err == nil {
+			return r, nil
+		}
+
+		if !errors.Is(err, ErrBackendUnavailable) {
+			return nil, err
+		}
+
+		errs = append(errs, err)
+	}
+
+	return nil, fmt.Errorf("%w: tried %s: %w", ErrBackendUnavailable, joinBackends(order), errors.Join(errs...))
+}
+
+// joinBackends formats backend names as a comma-separated list.
+func joinBackends(backends []Backend) string {
+	names := make([]string, len(backends))
+	for i, b := range backends {
+		names[i] = string(b)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Backend returns the backend the reviewer uses.
+func (r *Reviewer) Backend() Backend {
+	return r.backend
+}
+
 // Options configures the review behavior.
 type Options struct {
 	// Model overrides the default model.
@@ -59,6 +170,10 @@ type Options struct {
 	// Instructions are additional review instructions.
 	Instructions string
 
+	// PathInstructions add instructions for the paths the reviewed files
+	// match, after Instructions.
+	PathInstructions []rcontext.PathInstruction
+
 	// StreamHandler receives events during execution.
 	StreamHandler func(agent.Event)
 
@@ -68,13 +183,104 @@ type Options struct {
 	// Retries is the number of retries on transient failures (0 = no retries).
 	Retries int
 
-	// RetryDelayMS is the delay between retries in milliseconds.
+	// RetryDelayMS is the base delay between retries in milliseconds.
+	// Each retry doubles it, capped at RetryMaxDelayMS, with full jitter.
 	RetryDelayMS int
+
+	// RetryMaxDelayMS caps the backoff between retries (0 = DefaultRetryMaxDelayMS).
+	RetryMaxDelayMS int
+
+	// SeverityDefaults overrides the severity assigned per category when the
+	// model omits one. Merged over DefaultSeverities.
+	SeverityDefaults map[string]string
+
+	// Categories are extra finding categories recognized beyond the built-in ones.
+	Categories []string
+
+	// Focus limits the review to these categories. The model is told so;
+	// use FilterCategories to drop anything it reports outside them.
+	Focus []string
+
+	// PromptTemplate replaces DefaultPromptTemplate when set.
+	PromptTemplate string
+
+	// Cache, when set, reuses the response of an identical earlier review.
+	Cache *ResponseCache
+
+	// RequireFindingsFormat fails the review with ErrUnparsedResponse when a
+	// substantial response contains no parseable findings.
+	RequireFindingsFormat bool
+
+	// Warnings receives non-fatal warnings, such as a prompt that likely
+	// exceeds the context window. Nil discards them.
+	Warnings io.Writer
+}
+
+// warnings returns where warnings go.
+func (o Options) warnings() io.Writer {
+	if o.Warnings == nil {
+		return io.Discard
+	}
+
+	return o.Warnings
+}
+
+// builtinCategories are the finding categories the parser always recognizes.
+var builtinCategories = []string{"bug", "security", "performance", "style", "testing", "commit"}
+
+// findingRules controls how FINDING lines are classified.
+type findingRules struct {
+	// severities maps a category to the severity used when none is given.
+	severities map[string]string
+
+	// categories are the recognized category names, checked in order.
+	categories []string
+}
+
+// newFindingRules builds classification rules from the review options.
+func newFindingRules(opts Options) findingRules {
+	severities := DefaultSeverities()
+	maps.Copy(severities, opts.SeverityDefaults)
+
+	return findingRules{
+		severities: severities,
+		categories: append(slices.Clone(builtinCategories), opts.Categories...),
+	}
+}
+
+// DefaultSeverities returns the severity assigned to a finding that names a
+// category but no severity. Unlisted categories default to "warning".
+func DefaultSeverities() map[string]string {
+	return map[string]string{
+		"security": "error",
+		"style":    "suggestion",
+		"commit":   "suggestion",
+	}
 }
 
 // Review performs a code review on the given context.
+// When ctx is cancelled mid-run, it returns ErrInterrupted along with a
+// partial Result parsed from the response streamed so far.
 func (r *Reviewer) Review(ctx context.Context, reviewCtx *rcontext.ReviewContext, opts Options) (*Result, error) {
-	prompt := buildReviewPrompt(reviewCtx, opts.Instructions)
+	if r.backend == BackendFile {
+		return r.reviewFromFile()
+	}
+
+	prompt, err := renderPrompt(reviewCtx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(r.backend, opts.Model, prompt)
+	if opts.Cache != nil {
+		if cached, ok := opts.Cache.get(key); ok {
+			result := cached.result(opts)
+
+			return result, checkFindingsFormat(opts.warnings(), result.RawResponse, len(result.Findings), opts)
+		}
+	}
+
+	warnIfOversized(opts.warnings(), prompt, opts.Model)
 
 	agentOpts := []agent.Option{
 		agent.WithWorkDir(reviewCtx.RepoPath),
@@ -84,24 +290,48 @@ func (r *Reviewer) Review(ctx context.Context, reviewCtx *rcontext.ReviewContext
 		agentOpts = append(agentOpts, agent.WithModel(opts.Model))
 	}
 
-	if opts.StreamHandler != nil {
-		agentOpts = append(agentOpts, agent.WithStreaming(opts.StreamHandler))
-	}
+	// Always stream, so an interrupted run keeps the text received so far.
+	partial := &partialText{next: opts.StreamHandler}
+	agentOpts = append(agentOpts, agent.WithStreaming(partial.handle))
 
 	for k, v := range opts.Env {
 		agentOpts = append(agentOpts, agent.WithEnv(k, v))
 	}
 
-	if opts.Retries > 0 {
-		agentOpts = append(agentOpts, agent.WithRetries(opts.Retries, opts.RetryDelayMS))
+	policy := newRetryPolicy(opts.Retries, opts.RetryDelayMS, opts.RetryMaxDelayMS)
+
+	response, err := policy.run(ctx, func() (*agent.Response, error) {
+		partial.reset()
+
+		return r.agent.Run(ctx, prompt, agentOpts...)
+	})
+	if err != nil && interrupted(ctx) {
+		text := partial.String()
+
+		return &Result{
+			Findings:    parseFindings(text, newFindingRules(opts)),
+			RawResponse: text,
+			Partial:     true,
+		}, fmt.Errorf("%w: %w", ErrInterrupted, err)
 	}
 
-	response, err := r.agent.Run(ctx, prompt, agentOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("run agent: %w", err)
 	}
 
-	findings := parseFindings(response.Text)
+	findings := parseFindings(response.Text, newFindingRules(opts))
+	warnIfUnparsed(opts.warnings(), response.Text, len(findings))
+
+	// Don't cache or report a response the model didn't format as findings
+	if err := checkFindingsFormat(opts.warnings(), response.Text, len(findings), opts); err != nil {
+		return nil, err
+	}
+
+	if opts.Cache != nil && cacheable(response.Text, len(findings)) {
+		if err := opts.Cache.put(key, response); err != nil {
+			fmt.Fprintf(opts.warnings(), "warning: %v\n", err)
+		}
+	}
 
 	return &Result{
 		Findings:     findings,
@@ -121,9 +351,22 @@ type Result struct {
 	// Findings contains the parsed review findings.
 	Findings []session.Finding
 
+	// OmittedFindings is the number of findings dropped by a findings cap.
+	OmittedFindings int
+
+	// BaselinedFindings is the number of findings suppressed by a baseline.
+	BaselinedFindings int
+
 	// RawResponse is the raw AI response text.
 	RawResponse string
 
+	// Partial is set when the review was interrupted and the findings come
+	// from an incomplete response.
+	Partial bool
+
+	// Cached is set when the response came from a ResponseCache.
+	Cached bool
+
 	// InputTokens is the number of input tokens used.
 	InputTokens int
 
@@ -146,37 +389,8 @@ type Result struct {
 	Duration time.Duration
 }
 
-// buildReviewPrompt builds the review prompt from context.
-// Keeps it minimal - Claude can read files itself.
-func buildReviewPrompt(reviewCtx *rcontext.ReviewContext, instructions string) string {
-	var sb strings.Builder
-
-	sb.WriteString("Review the following code changes:\n\n")
-
-	if instructions != "" {
-		sb.WriteString("Instructions: ")
-		sb.WriteString(instructions)
-		sb.WriteString("\n\n")
-	}
-
-	sb.WriteString("## Changed Files\n\n")
-
-	for _, f := range reviewCtx.ChangedFiles {
-		sb.WriteString(fmt.Sprintf("- %s (%s, +%d/-%d lines)\n",
-			f.Path, f.Status, f.LinesAdded, f.LinesDeleted))
-	}
-
-	sb.WriteString("\nRead these files and identify bugs, security issues, performance problems, and improvements.\n\n")
-	sb.WriteString("Format each finding as:\n")
-	sb.WriteString("FINDING: [file:line] [severity] [category]\n")
-	sb.WriteString("DESCRIPTION: <description>\n")
-	sb.WriteString("FIX: <suggested fix>\n")
-
-	return sb.String()
-}
-
 // parseFindings parses findings from the AI response.
-func parseFindings(response string) []session.Finding {
+func parseFindings(response string, rules findingRules) []session.Finding {
 	var findings []session.Finding
 
 	lines := strings.Split(response, "\n")
@@ -187,11 +401,11 @@ func parseFindings(response string) []session.Finding {
 
 		if strings.HasPrefix(line, "FINDING:") {
 			// Start a new finding
-			if current != nil {
+			if current != nil && !disputed(current) {
 				findings = append(findings, *current)
 			}
 
-			current = parseFindingLine(line)
+			current = parseFindingLine(line, rules)
 		} else if current != nil && strings.HasPrefix(line, "DESCRIPTION:") {
 			desc := strings.TrimPrefix(line, "DESCRIPTION:")
 			current.Description = strings.TrimSpace(desc)
@@ -202,7 +416,7 @@ func parseFindings(response string) []session.Finding {
 	}
 
 	// Don't forget the last finding
-	if current != nil {
+	if current != nil && !disputed(current) {
 		findings = append(findings, *current)
 	}
 
@@ -211,7 +425,7 @@ func parseFindings(response string) []session.Finding {
 
 // parseFindingLine parses a FINDING: line.
 // Format: FINDING: [file:line] [severity] [category].
-func parseFindingLine(line string) *session.Finding {
+func parseFindingLine(line string, rules findingRules) *session.Finding {
 	line = strings.TrimPrefix(line, "FINDING:")
 	line = strings.TrimSpace(line)
 
@@ -225,34 +439,41 @@ func parseFindingLine(line string) *session.Finding {
 		location := line[1:idx]
 
 		if colonIdx := strings.LastIndex(location, ":"); colonIdx > 0 {
-			finding.File = location[:colonIdx]
+			finding.File = rcontext.NormalizePath(location[:colonIdx])
 
 			if lineNum := parseInt(location[colonIdx+1:]); lineNum > 0 {
 				finding.Line = lineNum
 			}
 		} else {
-			finding.File = location
+			finding.File = rcontext.NormalizePath(location)
 		}
 
 		line = strings.TrimSpace(line[idx+1:])
 	}
 
 	// Parse [severity]
+	hasSeverity := false
 	severities := []string{"error", "warning", "suggestion"}
+
 	for _, sev := range severities {
 		if strings.Contains(strings.ToLower(line), sev) {
 			finding.Severity = sev
+			hasSeverity = true
 
 			break
 		}
 	}
 
 	// Parse [category]
-	categories := []string{"bug", "security", "performance", "style", "testing"}
-	for _, cat := range categories {
-		if strings.Contains(strings.ToLower(line), cat) {
+	for _, cat := range rules.categories {
+		if strings.Contains(strings.ToLower(line), strings.ToLower(cat)) {
 			finding.Category = cat
 
+			// Only a category was given: use its default severity
+			if sev, ok := rules.severities[cat]; ok && !hasSeverity {
+				finding.Severity = sev
+			}
+
 			break
 		}
 	}
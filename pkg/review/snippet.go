@@ -0,0 +1,63 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+// AttachContext sets Context on each finding to its flagged lines plus up to
+// n lines above and below, read from the file under repoRoot. Findings whose
+// file can't be read or whose line is outside the file are left without one.
+func AttachContext(findings []session.Finding, repoRoot string, n int) {
+	if n < 0 {
+		return
+	}
+
+	files := make(map[string][]string)
+
+	for i := range findings {
+		f := &findings[i]
+
+		lines, ok := files[f.File]
+		if !ok {
+			lines = readLines(filepath.Join(repoRoot, filepath.FromSlash(f.File)))
+			files[f.File] = lines
+		}
+
+		f.Context = snippet(lines, f.Line, max(f.EndLine, f.Line), n)
+	}
+}
+
+// snippet returns lines first-n..last+n (1-based, clipped to the file),
+// or nil when first is outside the file.
+func snippet(lines []string, first, last, n int) *session.Snippet {
+	if first < 1 || first > len(lines) {
+		return nil
+	}
+
+	start := max(first-n, 1)
+	end := min(last+n, len(lines))
+
+	return &session.Snippet{
+		StartLine: start,
+		Lines:     lines[start-1 : end],
+	}
+}
+
+// readLines reads a file as lines without line endings; nil if it can't be read.
+func readLines(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,55 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/crealfy/crea-review/pkg/session"
+)
+
+func TestAttachContext(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	src := "one\r\ntwo\r\nthree\r\nfour\r\nfive\r\n"
+	if err := os.WriteFile(filepath.Join(dir, "pkg", "a.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings := []session.Finding{
+		{File: "pkg/a.go", Line: 3},
+		{File: "pkg/a.go", Line: 1},
+		{File: "pkg/a.go", Line: 4, EndLine: 5},
+		{File: "pkg/a.go", Line: 9},
+		{File: "pkg/a.go", Line: 0},
+		{File: "missing.go", Line: 1},
+	}
+
+	AttachContext(findings, dir, 1)
+
+	tests := []struct {
+		start int
+		lines []string
+	}{
+		{2, []string{"two", "three", "four"}},
+		{1, []string{"one", "two"}},
+		{3, []string{"three", "four", "five"}},
+	}
+
+	for i, tt := range tests {
+		got := findings[i].Context
+		if got == nil || got.StartLine != tt.start || !slices.Equal(got.Lines, tt.lines) {
+			t.Errorf("findings[%d].Context = %+v, want start %d lines %v", i, got, tt.start, tt.lines)
+		}
+	}
+
+	for _, i := range []int{3, 4, 5} {
+		if findings[i].Context != nil {
+			t.Errorf("findings[%d].Context = %+v, want nil (out of bounds or unreadable)", i, findings[i].Context)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package review
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// charsPerToken is the rough number of characters per token used for estimates.
+const charsPerToken = 4
+
+// DefaultContextWindow is the token budget used for models not in ContextWindows.
+const DefaultContextWindow = 200_000
+
+// ContextWindows maps model name fragments to their context window in tokens.
+// A model matches the longest fragment it contains.
+var ContextWindows = map[string]int{
+	"opus":   200_000,
+	"sonnet": 200_000,
+	"haiku":  200_000,
+	"gpt-5":  400_000,
+	"codex":  400_000,
+}
+
+// EstimateTokens returns a rough token estimate for text.
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// ContextBudget returns the context window for a model.
+// Unknown or empty models get DefaultContextWindow. When fragments of the
+// same length match, the alphabetically first one wins.
+func ContextBudget(model string) int {
+	model = strings.ToLower(model)
+	budget := DefaultContextWindow
+	matched := ""
+
+	for _, fragment := range slices.Sorted(maps.Keys(ContextWindows)) {
+		if strings.Contains(model, fragment) && len(fragment) > len(matched) {
+			matched = fragment
+			budget = ContextWindows[fragment]
+		}
+	}
+
+	return budget
+}
+
+// warnIfOversized writes a warning to w when the prompt likely exceeds the model's context window.
+func warnIfOversized(w io.Writer, prompt, model string) {
+	estimate := EstimateTokens(prompt)
+	budget := ContextBudget(model)
+
+	if estimate <= budget {
+		return
+	}
+
+	fmt.Fprintf(w, "warning: prompt is ~%d tokens, exceeding the ~%d token context window; "+
+		"reduce --max-files to avoid truncated reviews\n", estimate, budget)
+}
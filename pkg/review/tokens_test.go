@@ -0,0 +1,77 @@
+package review
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+		{strings.Repeat("x", 400), 100},
+	}
+
+	for _, tt := range tests {
+		if got := EstimateTokens(tt.text); got != tt.want {
+			t.Errorf("EstimateTokens(%d chars) = %d, want %d", len(tt.text), got, tt.want)
+		}
+	}
+}
+
+func TestContextBudget(t *testing.T) {
+	tests := []struct {
+		model string
+		want  int
+	}{
+		{"", DefaultContextWindow},
+		{"claude-opus-4-6", 200_000},
+		{"Sonnet", 200_000},
+		{"gpt-5-codex", 400_000},
+		{"unknown-model", DefaultContextWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if got := ContextBudget(tt.model); got != tt.want {
+				t.Errorf("ContextBudget(%q) = %d, want %d", tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextBudgetTieIsDeterministic(t *testing.T) {
+	saved := ContextWindows
+	t.Cleanup(func() { ContextWindows = saved })
+
+	ContextWindows = map[string]int{"alpha": 1, "omega": 2}
+
+	for range 20 {
+		if got := ContextBudget("alpha-omega"); got != 1 {
+			t.Fatalf("ContextBudget(alpha-omega) = %d, want 1 from the alphabetically first fragment", got)
+		}
+	}
+}
+
+func TestWarnIfOversized(t *testing.T) {
+	var buf bytes.Buffer
+
+	warnIfOversized(&buf, "small prompt", "opus")
+
+	if buf.Len() != 0 {
+		t.Errorf("unexpected warning for small prompt: %q", buf.String())
+	}
+
+	huge := strings.Repeat("x", (DefaultContextWindow+1)*charsPerToken)
+	warnIfOversized(&buf, huge, "")
+
+	if !strings.Contains(buf.String(), "--max-files") {
+		t.Errorf("warning = %q, want suggestion to use --max-files", buf.String())
+	}
+}
@@ -0,0 +1,52 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrUnparsedResponse indicates a substantial AI response without a single
+// FINDING block, rejected with Options.RequireFindingsFormat.
+var ErrUnparsedResponse = errors.New("AI response has no parseable findings")
+
+// trivialResponseChars is how long a response without findings may be,
+// not counting CompletionMarker, and still count as a plain "no issues".
+const trivialResponseChars = 200
+
+// warnIfUnparsed writes a warning to w when a response with no findings looks
+// like a failed review rather than a clean one: empty, or missing CompletionMarker.
+func warnIfUnparsed(w io.Writer, response string, findings int) {
+	if findings > 0 {
+		return
+	}
+
+	switch {
+	case strings.TrimSpace(response) == "":
+		fmt.Fprintln(w, "warning: the AI returned an empty response; \"no issues\" is unverified, consider re-running")
+	case !strings.Contains(response, CompletionMarker):
+		fmt.Fprintf(w, "warning: the AI response has no findings and no %s marker (%d chars); "+
+			"the review may have failed or been cut off\n", CompletionMarker, len(response))
+	}
+}
+
+// checkFindingsFormat returns ErrUnparsedResponse when opts require the
+// findings format and a response longer than a short all-clear produced no
+// findings: the model most likely ignored the format. The raw response is
+// written to w for debugging.
+func checkFindingsFormat(w io.Writer, response string, findings int, opts Options) error {
+	if !opts.RequireFindingsFormat || findings > 0 {
+		return nil
+	}
+
+	text := strings.TrimSpace(strings.ReplaceAll(response, CompletionMarker, ""))
+	if len(text) <= trivialResponseChars {
+		return nil
+	}
+
+	fmt.Fprintf(w, "--- raw AI response ---\n%s\n--- end of raw AI response ---\n", strings.TrimRight(response, "\n"))
+
+	return fmt.Errorf("%w: %d chars without a FINDING block; the model may have ignored the output format",
+		ErrUnparsedResponse, len(response))
+}
@@ -0,0 +1,112 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	rcontext "github.com/crealfy/crea-review/pkg/context"
+)
+
+func TestCheckFindingsFormat(t *testing.T) {
+	prose := strings.Repeat("The handler in main.go dereferences a nil map when the config is empty. ", 5)
+
+	tests := []struct {
+		name     string
+		response string
+		findings int
+		require  bool
+		wantErr  bool
+	}{
+		{"not required", prose, 0, false, false},
+		{"findings parsed", prose, 2, true, false},
+		{"short all-clear", "No issues found.\n\n" + CompletionMarker + "\n", 0, true, false},
+		{"empty", "", 0, true, false},
+		{"prose without findings", prose + "\n" + CompletionMarker, 0, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := checkFindingsFormat(&buf, tt.response, tt.findings, Options{RequireFindingsFormat: tt.require})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkFindingsFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && (!errors.Is(err, ErrUnparsedResponse) || !strings.Contains(buf.String(), prose)) {
+				t.Errorf("error = %v, log = %q; want ErrUnparsedResponse and the raw response", err, buf.String())
+			}
+
+			if !tt.wantErr && buf.Len() > 0 {
+				t.Errorf("unexpected log %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestReviewRequireFindingsFormat(t *testing.T) {
+	var runs int
+
+	prose := "Overall the change looks risky: " + strings.Repeat("the retry loop never backs off and the error is dropped. ", 5)
+	r := &Reviewer{agent: countingAgent(prose, &runs), backend: BackendClaude}
+	reviewCtx := &rcontext.ReviewContext{ChangedFiles: []rcontext.FileContent{{Path: "main.go", Status: "modified"}}}
+
+	result, err := r.Review(context.Background(), reviewCtx, Options{})
+	if err != nil || len(result.Findings) != 0 {
+		t.Fatalf("Review() = %v, %v; want an all-clear without the flag", result, err)
+	}
+
+	cache := NewResponseCache(t.TempDir(), 0)
+
+	_, err = r.Review(context.Background(), reviewCtx, Options{RequireFindingsFormat: true, Cache: cache})
+	if !errors.Is(err, ErrUnparsedResponse) {
+		t.Fatalf("Review() error = %v, want ErrUnparsedResponse", err)
+	}
+
+	// The unparsed response isn't cached, so a retry calls the model again
+	if _, err := r.Review(context.Background(), reviewCtx, Options{RequireFindingsFormat: true, Cache: cache}); !errors.Is(err, ErrUnparsedResponse) {
+		t.Fatalf("second Review() error = %v, want ErrUnparsedResponse", err)
+	}
+
+	if runs != 3 {
+		t.Errorf("agent ran %d times, want 3", runs)
+	}
+}
+
+func TestWarnIfUnparsed(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		findings int
+		want     string
+	}{
+		{"empty", "", 0, "empty response"},
+		{"whitespace", "  \n ", 0, "empty response"},
+		{"prose without marker", "I could not access the files.", 0, "no " + CompletionMarker + " marker"},
+		{"clean review", "No issues found.\n" + CompletionMarker + "\n", 0, ""},
+		{"findings without marker", "FINDING: [a.go:1] error bug", 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			warnIfUnparsed(&buf, tt.response, tt.findings)
+
+			if tt.want == "" {
+				if buf.Len() != 0 {
+					t.Errorf("unexpected warning: %q", buf.String())
+				}
+
+				return
+			}
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("warning = %q, want it to mention %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
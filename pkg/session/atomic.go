@@ -0,0 +1,63 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// atomicWrite writes data to a file atomically using temp file + rename.
+// The file is synced before the rename and the directory after it, so a
+// crash right after writing leaves either the old or the new file, never an
+// empty one.
+func atomicWrite(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmpFile, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	syncDir(dir)
+
+	return nil
+}
+
+// syncDir fsyncs a directory so a rename into it is durable. Best effort:
+// some platforms (e.g. Windows) can't sync directories, and the data itself
+// is already synced.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+
+	_ = d.Sync()
+	_ = d.Close()
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAtomicWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "test.txt")
+	content := []byte("test content")
+
+	if err := atomicWrite(path, content); err != nil {
+		t.Fatalf("atomicWrite() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	if string(data) != string(content) {
+		t.Errorf("content = %q, want %q", string(data), string(content))
+	}
+}
+
+func TestAtomicWriteCleansUpOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Renaming a file over a non-empty directory fails
+	path := filepath.Join(tmpDir, "meta.json")
+	if err := os.MkdirAll(filepath.Join(path, "child"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	if err := atomicWrite(path, []byte("{}")); err == nil {
+		t.Fatal("atomicWrite() expected error when the target is a directory")
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".tmp-") {
+			t.Errorf("temp file %s left behind", e.Name())
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package session
+
+import "time"
+
+// BudgetWindow is the rolling window a daily file budget counts over.
+const BudgetWindow = 24 * time.Hour
+
+// FilesReviewedSince sums FilesReviewed over the sessions created at or
+// after since. Failed sessions count too: their files were sent to the model.
+func FilesReviewedSince(sessions []*Session, since time.Time) int {
+	total := 0
+
+	for _, sess := range sessions {
+		if !sess.CreatedAt.Before(since) {
+			total += sess.FilesReviewed
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilesReviewedSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	sessions := []*Session{
+		{ID: 1, CreatedAt: now.Add(-48 * time.Hour), FilesReviewed: 40},
+		{ID: 2, CreatedAt: now.Add(-BudgetWindow), FilesReviewed: 5},
+		{ID: 3, CreatedAt: now.Add(-23 * time.Hour), FilesReviewed: 10, Status: StatusFailed},
+		{ID: 4, CreatedAt: now.Add(-time.Minute), FilesReviewed: 7},
+	}
+
+	tests := []struct {
+		name  string
+		since time.Time
+		want  int
+	}{
+		{"rolling day includes the boundary", now.Add(-BudgetWindow), 22},
+		{"last hour", now.Add(-time.Hour), 7},
+		{"everything", time.Time{}, 62},
+		{"future", now.Add(time.Hour), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FilesReviewedSince(sessions, tt.since); got != tt.want {
+				t.Errorf("FilesReviewedSince() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package session
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStoreContinuedSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore("/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	// Create first session
+	session1 := &Session{
+		BaseCommit:       "abc123",
+		TotalFilesInDiff: 100,
+		FilesReviewed:    50,
+		FilesRemaining:   50,
+		Status:           StatusCompleted,
+	}
+	if err := store.Create(session1); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Create continued session
+	session2 := &Session{
+		BaseCommit:       "abc123",
+		TotalFilesInDiff: 100,
+		FilesReviewed:    50,
+		FilesRemaining:   0,
+		Status:           StatusCompleted,
+		ContinuedFrom:    1,
+	}
+	if err := store.Create(session2); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	loaded, err := store.Load(2)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if loaded.ContinuedFrom != 1 {
+		t.Errorf("ContinuedFrom = %d, want 1", loaded.ContinuedFrom)
+	}
+}
+
+func TestCollectReviewedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore("/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	// Create session chain: 1 -> 2 -> 3
+	session1 := &Session{
+		BaseCommit: "abc123",
+		HeadCommit: "def456",
+		Status:     StatusCompleted,
+		Files:      []string{"b.go", "a.go"},
+	}
+	if err := store.Create(session1); err != nil {
+		t.Fatalf("Create session 1 error = %v", err)
+	}
+
+	session2 := &Session{
+		BaseCommit:    "abc123",
+		HeadCommit:    "def456",
+		Status:        StatusCompleted,
+		ContinuedFrom: 1,
+		Files:         []string{"c.go", "d.go"},
+	}
+	if err := store.Create(session2); err != nil {
+		t.Fatalf("Create session 2 error = %v", err)
+	}
+
+	session3 := &Session{
+		BaseCommit:    "abc123",
+		HeadCommit:    "def456",
+		Status:        StatusCompleted,
+		ContinuedFrom: 2,
+		Files:         []string{"e.go"},
+	}
+	if err := store.Create(session3); err != nil {
+		t.Fatalf("Create session 3 error = %v", err)
+	}
+
+	// Test from session 3
+	files, root, err := store.CollectReviewedFiles(3)
+	if err != nil {
+		t.Fatalf("CollectReviewedFiles(3) error = %v", err)
+	}
+
+	// Root session should be session 1
+	if root == nil {
+		t.Fatal("root session is nil")
+	}
+	if root.ID != 1 {
+		t.Errorf("root.ID = %d, want 1", root.ID)
+	}
+
+	// All files are collected, sorted regardless of chain or map order
+	expected := []string{"a.go", "b.go", "c.go", "d.go", "e.go"}
+	if !slices.Equal(files, expected) {
+		t.Errorf("files = %v, want %v", files, expected)
+	}
+}
+
+func TestCollectReviewedFilesSingleSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore("/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	// Create single session (no chain)
+	session := &Session{
+		BaseCommit: "abc123",
+		HeadCommit: "def456",
+		Status:     StatusCompleted,
+		Files:      []string{"main.go", "util.go"},
+	}
+	if err := store.Create(session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	files, root, err := store.CollectReviewedFiles(1)
+	if err != nil {
+		t.Fatalf("CollectReviewedFiles(1) error = %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Errorf("len(files) = %d, want 2", len(files))
+	}
+
+	// Root should be the same session
+	if root == nil || root.ID != 1 {
+		t.Error("root should be session 1")
+	}
+}
+
+func TestCollectReviewedFilesSkipsFailed(t *testing.T) {
+	store, err := NewStore("/test/project", t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	done := &Session{Status: StatusCompleted, Files: []string{"a.go"}}
+	if err := store.Create(done); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	failed := &Session{
+		Status:        StatusFailed,
+		Error:         "timed out after 10m0s",
+		ContinuedFrom: done.ID,
+		Files:         []string{"b.go"},
+	}
+	if err := store.Create(failed); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	loaded, err := store.Load(failed.ID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Status != StatusFailed || loaded.Error != failed.Error {
+		t.Errorf("loaded = %s %q, want failed with reason", loaded.Status, loaded.Error)
+	}
+
+	files, _, err := store.CollectReviewedFiles(failed.ID)
+	if err != nil {
+		t.Fatalf("CollectReviewedFiles() error = %v", err)
+	}
+
+	if len(files) != 1 || files[0] != "a.go" {
+		t.Errorf("files = %v, want only a.go (failed session files must be re-reviewed)", files)
+	}
+}
+
+func TestCollectReviewedFilesNotFound(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore("/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	_, _, err = store.CollectReviewedFiles(99)
+	if err == nil {
+		t.Error("CollectReviewedFiles(99) should error for non-existent session")
+	}
+}
+
+func TestCollectReviewedFilesSkipsInterrupted(t *testing.T) {
+	store, err := NewStore("/test/project", t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	done := &Session{Status: StatusCompleted, Files: []string{"a.go"}}
+	if err := store.Create(done); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	interrupted := &Session{
+		Status:        StatusInProgress,
+		Error:         "interrupted",
+		ContinuedFrom: done.ID,
+		Files:         []string{"b.go"},
+	}
+	if err := store.Create(interrupted); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	files, _, err := store.CollectReviewedFiles(interrupted.ID)
+	if err != nil {
+		t.Fatalf("CollectReviewedFiles() error = %v", err)
+	}
+
+	if len(files) != 1 || files[0] != "a.go" {
+		t.Errorf("files = %v, want only a.go (unfinished session files must be re-reviewed)", files)
+	}
+}
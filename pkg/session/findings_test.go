@@ -0,0 +1,139 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSessionWithFindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore("/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	session := &Session{
+		BaseCommit:    "abc123",
+		FilesReviewed: 10,
+		Status:        StatusCompleted,
+		Findings: []Finding{
+			{
+				File:         "main.go",
+				Line:         42,
+				Severity:     "error",
+				Category:     "security",
+				Description:  "SQL injection vulnerability",
+				SuggestedFix: "Use parameterized queries",
+			},
+			{
+				File:        "handler.go",
+				Line:        100,
+				Severity:    "warning",
+				Category:    "performance",
+				Description: "N+1 query detected",
+			},
+		},
+	}
+
+	if err := store.Create(session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	loaded, err := store.Load(1)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(loaded.Findings) != 2 {
+		t.Errorf("len(Findings) = %d, want 2", len(loaded.Findings))
+	}
+
+	if loaded.Findings[0].Severity != "error" {
+		t.Errorf("Findings[0].Severity = %q, want %q", loaded.Findings[0].Severity, "error")
+	}
+}
+
+func TestFindingsStoredSeparately(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore("/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	session := &Session{
+		Status:   StatusCompleted,
+		Findings: []Finding{{File: "main.go", Line: 1, Severity: "error", Description: "boom"}},
+	}
+	if err := store.Create(session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	meta, err := os.ReadFile(filepath.Join(tmpDir, "sessions", "1", "meta.json"))
+	if err != nil {
+		t.Fatalf("read meta.json: %v", err)
+	}
+
+	if strings.Contains(string(meta), "boom") {
+		t.Errorf("meta.json contains findings:\n%s", meta)
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(sessions) != 1 || sessions[0].Findings != nil || sessions[0].FindingCount != 1 {
+		t.Errorf("List() = %+v, want one session with FindingCount 1 and no findings", sessions[0])
+	}
+
+	findings, err := store.LoadFindings(1)
+	if err != nil {
+		t.Fatalf("LoadFindings() error = %v", err)
+	}
+
+	if len(findings) != 1 || findings[0].Description != "boom" {
+		t.Errorf("LoadFindings() = %+v", findings)
+	}
+}
+
+func TestLoadFindingsLegacyMeta(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewStore("/test/project", tmpDir)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	dir := filepath.Join(tmpDir, "sessions", "1")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	legacy := `{"id": 1, "status": "completed", "findings": [{"file": "a.go", "line": 3, "severity": "warning"}]}`
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := store.LoadFindings(1)
+	if err != nil {
+		t.Fatalf("LoadFindings() error = %v", err)
+	}
+
+	if len(findings) != 1 || findings[0].File != "a.go" {
+		t.Errorf("LoadFindings() = %+v, want the inline legacy finding", findings)
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(sessions) != 1 || sessions[0].FindingCount != 1 {
+		t.Errorf("List() FindingCount = %d, want 1", sessions[0].FindingCount)
+	}
+
+	if _, err := store.LoadFindings(2); err == nil {
+		t.Error("LoadFindings(2) should error for a missing session")
+	}
+}
@@ -0,0 +1,41 @@
+package session
+
+// Invocation records how a review was run, so it can be reproduced later.
+// Environment variable values are never stored, only their names.
+type Invocation struct {
+	// Backend is the AI backend requested (claude, codex, auto, or file).
+	Backend string `json:"backend"`
+
+	// Model is the model override (empty for the backend default).
+	Model string `json:"model,omitempty"`
+
+	// ReviewType is the review type (all, committed, uncommitted).
+	ReviewType string `json:"review_type,omitempty"`
+
+	// BaseBranch is the --base branch as given.
+	BaseBranch string `json:"base_branch,omitempty"`
+
+	// BaseCommit is the --base-commit as given.
+	BaseCommit string `json:"base_commit,omitempty"`
+
+	// HeadCommit is the --head-commit as given.
+	HeadCommit string `json:"head_commit,omitempty"`
+
+	// MaxFiles is the per-batch file limit.
+	MaxFiles int `json:"max_files"`
+
+	// Sort is the file sort order.
+	Sort string `json:"sort,omitempty"`
+
+	// Profile is the --profile applied, if any.
+	Profile string `json:"profile,omitempty"`
+
+	// Instructions are the effective review instructions from the team config.
+	Instructions string `json:"instructions,omitempty"`
+
+	// ConfigFiles are the extra config files passed with --config.
+	ConfigFiles []string `json:"config_files,omitempty"`
+
+	// EnvKeys are the names of environment variables passed to the agent.
+	EnvKeys []string `json:"env_keys,omitempty"`
+}
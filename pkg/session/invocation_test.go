@@ -0,0 +1,49 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInvocationRoundTrip(t *testing.T) {
+	store, err := NewStore(t.TempDir(), t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	sess := &Session{
+		Invocation: &Invocation{
+			Backend:    "codex",
+			Model:      "gpt-5",
+			ReviewType: "all",
+			BaseBranch: "main",
+			MaxFiles:   15,
+			EnvKeys:    []string{"OPENAI_API_KEY"},
+		},
+	}
+
+	if err := store.Create(sess); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	loaded, err := store.Load(sess.ID)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	inv := loaded.Invocation
+	if inv == nil || inv.Backend != "codex" || inv.BaseBranch != "main" || inv.MaxFiles != 15 {
+		t.Errorf("Invocation = %+v, want the saved invocation", inv)
+	}
+
+	data, err := os.ReadFile(filepath.Join(store.StateDir, "sessions", "1", "meta.json"))
+	if err != nil {
+		t.Fatalf("read session file: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"env_keys"`) {
+		t.Errorf("session file should record env keys:\n%s", data)
+	}
+}
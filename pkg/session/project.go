@@ -0,0 +1,70 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrProjectMismatch indicates a state directory that belongs to another project.
+var ErrProjectMismatch = errors.New("state directory belongs to another project")
+
+// projectMeta is the project.json written in each state directory.
+type projectMeta struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+}
+
+// writeProjectMeta writes project.json if it doesn't exist yet. With verify,
+// an existing project.json must name projectPath.
+func writeProjectMeta(stateDir, projectPath string, verify bool) error {
+	metaPath := filepath.Join(stateDir, "project.json")
+
+	data, err := os.ReadFile(metaPath)
+	if err == nil {
+		if !verify {
+			return nil
+		}
+
+		var meta projectMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return fmt.Errorf("parse project meta %s: %w", metaPath, err)
+		}
+
+		if meta.Path != projectPath {
+			return fmt.Errorf("%w: %s is for %s, not %s (hash collision); use --state-dir",
+				ErrProjectMismatch, stateDir, meta.Path, projectPath)
+		}
+
+		return nil
+	}
+
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("read project meta: %w", err)
+	}
+
+	data, err = json.MarshalIndent(projectMeta{
+		Path: projectPath,
+		Name: filepath.Base(projectPath),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal project meta: %w", err)
+	}
+
+	if err := os.WriteFile(metaPath, data, 0o644); err != nil {
+		return fmt.Errorf("write project meta: %w", err)
+	}
+
+	return nil
+}
+
+// hashPath creates a short hash of a path for directory naming.
+func hashPath(path string) string {
+	h := sha256.Sum256([]byte(path))
+
+	return hex.EncodeToString(h[:8]) // First 16 hex chars
+}
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewStoreProjectMismatch(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	store, err := NewStore("/work/repo-a", "")
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	// Reopening the same project is fine
+	if _, err := NewStore("/work/repo-a", ""); err != nil {
+		t.Fatalf("NewStore() reopen error = %v", err)
+	}
+
+	// Simulate another path hashing to the same directory
+	meta := `{"path": "/work/repo-b", "name": "repo-b"}`
+	if err := os.WriteFile(filepath.Join(store.StateDir, "project.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewStore("/work/repo-a", ""); !errors.Is(err, ErrProjectMismatch) {
+		t.Errorf("NewStore() error = %v, want ErrProjectMismatch", err)
+	}
+
+	// An explicit state dir is the user's choice and isn't verified
+	if _, err := NewStore("/work/repo-a", store.StateDir); err != nil {
+		t.Errorf("NewStore(explicit dir) error = %v, want nil", err)
+	}
+}
+
+func TestHashPath(t *testing.T) {
+	h1 := hashPath("/path/to/project1")
+	h2 := hashPath("/path/to/project2")
+
+	if h1 == h2 {
+		t.Error("different paths should have different hashes")
+	}
+
+	// Same path should produce same hash
+	h3 := hashPath("/path/to/project1")
+	if h1 != h3 {
+		t.Error("same path should have same hash")
+	}
+
+	// Hash should be 16 chars (8 bytes hex encoded)
+	if len(h1) != 16 {
+		t.Errorf("hash length = %d, want 16", len(h1))
+	}
+}
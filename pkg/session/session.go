@@ -2,8 +2,6 @@
 package session
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +19,7 @@ const (
 	StatusPending    Status = "pending"
 	StatusInProgress Status = "in_progress"
 	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
 )
 
 // Session represents a review session.
@@ -49,24 +48,45 @@ type Session struct {
 	// Status is the session status.
 	Status Status `json:"status"`
 
+	// Error is why the session failed (set with StatusFailed).
+	Error string `json:"error,omitempty"`
+
 	// ContinuedFrom is the session ID this continues from (0 if first).
 	ContinuedFrom int `json:"continued_from,omitempty"`
 
+	// Tags are user labels from --tag, for filtering the session list.
+	Tags []string `json:"tags,omitempty"`
+
+	// Invocation is how the review was run (nil for sessions from older versions).
+	Invocation *Invocation `json:"invocation,omitempty"`
+
 	// Files contains the files in this batch.
 	Files []string `json:"files"`
 
-	// Findings contains the review findings.
+	// FindingCount is the number of findings, kept in the metadata so
+	// listing sessions doesn't need to read findings.json.
+	FindingCount int `json:"finding_count"`
+
+	// Findings contains the review findings. They are stored in findings.json,
+	// not meta.json; sessions from List carry none (see Store.LoadFindings).
 	Findings []Finding `json:"findings,omitempty"`
 }
 
 // Finding represents a review finding.
 type Finding struct {
+	// ID is a short stable identifier derived from the finding's fingerprint,
+	// unique within a review (see review.AssignIDs).
+	ID string `json:"id,omitempty"`
+
 	// File is the file path.
 	File string `json:"file"`
 
 	// Line is the line number.
 	Line int `json:"line"`
 
+	// EndLine is the last line of a multi-line range (0 for a single line).
+	EndLine int `json:"end_line,omitempty"`
+
 	// Severity is the severity level (error, warning, suggestion).
 	Severity string `json:"severity"`
 
@@ -78,6 +98,30 @@ type Finding struct {
 
 	// SuggestedFix is the suggested fix.
 	SuggestedFix string `json:"suggested_fix,omitempty"`
+
+	// DocURL links to guidance for the finding's category, from the config's doc_urls.
+	DocURL string `json:"doc_url,omitempty"`
+
+	// Context is the source around the finding, when requested with --context.
+	Context *Snippet `json:"context,omitempty"`
+}
+
+// Snippet is a run of source lines read from a file when the review ran.
+type Snippet struct {
+	// StartLine is the line number of Lines[0].
+	StartLine int `json:"start_line"`
+
+	// Lines are the source lines, without line endings.
+	Lines []string `json:"lines"`
+}
+
+// Location returns "file:line", or "file:line-end" for a range.
+func (f Finding) Location() string {
+	if f.EndLine > f.Line {
+		return fmt.Sprintf("%s:%d-%d", f.File, f.Line, f.EndLine)
+	}
+
+	return fmt.Sprintf("%s:%d", f.File, f.Line)
 }
 
 // Store manages review sessions for a project.
@@ -89,8 +133,22 @@ type Store struct {
 	StateDir string
 }
 
+// RepoStateDir is the state directory, relative to the repo root, used when
+// review state is kept inside the repository.
+const RepoStateDir = ".creareview"
+
+// InRepoStateDir returns the in-repo state directory for a repo root.
+func InRepoStateDir(repoRoot string) string {
+	return filepath.Join(repoRoot, RepoStateDir)
+}
+
 // NewStore creates a new session store.
+// An empty stateDir uses a per-project directory under ~/.valksor/crealfy/review,
+// named by a truncated hash of projectPath. Since two paths could share a hash,
+// the project.json there must name projectPath or ErrProjectMismatch is returned.
 func NewStore(projectPath string, stateDir string) (*Store, error) {
+	verify := stateDir == ""
+
 	if stateDir == "" {
 		// Default state directory
 		home, err := os.UserHomeDir()
@@ -108,25 +166,8 @@ func NewStore(projectPath string, stateDir string) (*Store, error) {
 		return nil, fmt.Errorf("create sessions dir: %w", err)
 	}
 
-	// Write project metadata
-	projectMeta := struct {
-		Path string `json:"path"`
-		Name string `json:"name"`
-	}{
-		Path: projectPath,
-		Name: filepath.Base(projectPath),
-	}
-
-	metaPath := filepath.Join(stateDir, "project.json")
-	if _, err := os.Stat(metaPath); os.IsNotExist(err) {
-		data, err := json.MarshalIndent(projectMeta, "", "  ")
-		if err != nil {
-			return nil, fmt.Errorf("marshal project meta: %w", err)
-		}
-
-		if err := os.WriteFile(metaPath, data, 0o644); err != nil {
-			return nil, fmt.Errorf("write project meta: %w", err)
-		}
+	if err := writeProjectMeta(stateDir, projectPath, verify); err != nil {
+		return nil, err
 	}
 
 	return &Store{
@@ -167,9 +208,29 @@ func (s *Store) Save(session *Session) error {
 		return fmt.Errorf("create session dir: %w", err)
 	}
 
+	// Save findings before the metadata that counts them
+	session.FindingCount = len(session.Findings)
+
+	findings := session.Findings
+	if findings == nil {
+		findings = []Finding{}
+	}
+
+	findingsData, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal findings: %w", err)
+	}
+
+	if err := atomicWrite(filepath.Join(sessionDir, "findings.json"), findingsData); err != nil {
+		return fmt.Errorf("write session findings: %w", err)
+	}
+
 	// Save metadata
+	meta := *session
+	meta.Findings = nil
+
 	metaPath := filepath.Join(sessionDir, "meta.json")
-	metaData, err := json.MarshalIndent(session, "", "  ")
+	metaData, err := json.MarshalIndent(&meta, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal session: %w", err)
 	}
@@ -191,10 +252,66 @@ func (s *Store) Save(session *Session) error {
 	return nil
 }
 
-// Load loads a session by ID.
+// Load loads a session by ID, including its findings.
 func (s *Store) Load(id int) (*Session, error) {
-	metaPath := filepath.Join(s.StateDir, "sessions", strconv.Itoa(id), "meta.json")
+	session, err := s.loadMeta(id)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := s.LoadFindings(id)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Findings = findings
+
+	return session, nil
+}
+
+// LoadFindings loads only the findings of a session. Sessions saved before
+// findings.json existed keep their findings in meta.json; those are returned
+// instead.
+func (s *Store) LoadFindings(id int) ([]Finding, error) {
+	sessionDir := filepath.Join(s.StateDir, "sessions", strconv.Itoa(id))
+
+	data, err := os.ReadFile(filepath.Join(sessionDir, "findings.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		session, err := readMeta(filepath.Join(sessionDir, "meta.json"))
+		if err != nil {
+			return nil, err
+		}
+
+		return session.Findings, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read session findings: %w", err)
+	}
+
+	var findings []Finding
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return nil, fmt.Errorf("unmarshal session findings: %w", err)
+	}
+
+	return findings, nil
+}
+
+// loadMeta loads a session's metadata without its findings.
+func (s *Store) loadMeta(id int) (*Session, error) {
+	session, err := readMeta(filepath.Join(s.StateDir, "sessions", strconv.Itoa(id), "meta.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	session.Findings = nil
+
+	return session, nil
+}
 
+// readMeta reads a meta.json file. Findings inline in older metadata are
+// kept and counted in FindingCount.
+func readMeta(metaPath string) (*Session, error) {
 	data, err := os.ReadFile(metaPath)
 	if err != nil {
 		return nil, fmt.Errorf("read session: %w", err)
@@ -205,6 +322,10 @@ func (s *Store) Load(id int) (*Session, error) {
 		return nil, fmt.Errorf("unmarshal session: %w", err)
 	}
 
+	if session.FindingCount == 0 {
+		session.FindingCount = len(session.Findings)
+	}
+
 	return &session, nil
 }
 
@@ -230,7 +351,7 @@ func (s *Store) LoadLatest() (*Session, error) {
 	return latest, nil
 }
 
-// CollectReviewedFiles returns all files reviewed in a session chain,
+// CollectReviewedFiles returns all files reviewed in a session chain, sorted,
 // along with the root session that contains the original commits.
 // It traverses from the given session back through ContinuedFrom links.
 func (s *Store) CollectReviewedFiles(sessionID int) ([]string, *Session, error) {
@@ -239,14 +360,17 @@ func (s *Store) CollectReviewedFiles(sessionID int) ([]string, *Session, error)
 
 	currentID := sessionID
 	for currentID > 0 {
-		sess, err := s.Load(currentID)
+		sess, err := s.loadMeta(currentID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("load session %d: %w", currentID, err)
 		}
 
-		// Add files from this session
-		for _, f := range sess.Files {
-			seen[f] = true
+		// Add files from this session; a failed or unfinished (e.g. interrupted)
+		// session didn't review its files
+		if sess.Status != StatusFailed && sess.Status != StatusInProgress {
+			for _, f := range sess.Files {
+				seen[f] = true
+			}
 		}
 
 		// Track root session (where chain started)
@@ -257,16 +381,19 @@ func (s *Store) CollectReviewedFiles(sessionID int) ([]string, *Session, error)
 		currentID = sess.ContinuedFrom
 	}
 
-	// Convert map to slice
+	// Sort so the exclude set is the same on every run
 	files := make([]string, 0, len(seen))
 	for f := range seen {
 		files = append(files, f)
 	}
 
+	sort.Strings(files)
+
 	return files, rootSession, nil
 }
 
-// List returns all sessions sorted by ID.
+// List returns all sessions sorted by ID. Only metadata is read; use
+// LoadFindings for a session's findings.
 func (s *Store) List() ([]*Session, error) {
 	sessionsDir := filepath.Join(s.StateDir, "sessions")
 
@@ -290,19 +417,13 @@ func (s *Store) List() ([]*Session, error) {
 			continue
 		}
 
-		metaPath := filepath.Join(sessionsDir, entry.Name(), "meta.json")
-
-		data, err := os.ReadFile(metaPath)
+		session, err := readMeta(filepath.Join(sessionsDir, entry.Name(), "meta.json"))
 		if err != nil {
 			continue // Skip invalid sessions
 		}
 
-		var session Session
-		if err := json.Unmarshal(data, &session); err != nil {
-			continue
-		}
-
-		sessions = append(sessions, &session)
+		session.Findings = nil
+		sessions = append(sessions, session)
 	}
 
 	// Sort by ID
@@ -319,36 +440,3 @@ func (s *Store) Delete(id int) error {
 
 	return os.RemoveAll(sessionDir)
 }
-
-// hashPath creates a short hash of a path for directory naming.
-func hashPath(path string) string {
-	h := sha256.Sum256([]byte(path))
-
-	return hex.EncodeToString(h[:8]) // First 16 hex chars
-}
-
-// atomicWrite writes data to a file atomically using temp file + rename.
-func atomicWrite(path string, data []byte) error {
-	dir := filepath.Dir(path)
-	tmpFile, err := os.CreateTemp(dir, ".tmp-*")
-	if err != nil {
-		return err
-	}
-
-	tmpPath := tmpFile.Name()
-
-	if _, err := tmpFile.Write(data); err != nil {
-		_ = tmpFile.Close()
-		_ = os.Remove(tmpPath)
-
-		return err
-	}
-
-	if err := tmpFile.Close(); err != nil {
-		_ = os.Remove(tmpPath)
-
-		return err
-	}
-
-	return os.Rename(tmpPath, path)
-}
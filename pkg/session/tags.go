@@ -0,0 +1,32 @@
+package session
+
+import "slices"
+
+// HasTags reports whether the session carries every one of tags.
+func (s *Session) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(s.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterByTags returns the sessions that carry every one of tags, in order.
+// No tags returns sessions unchanged.
+func FilterByTags(sessions []*Session, tags []string) []*Session {
+	if len(tags) == 0 {
+		return sessions
+	}
+
+	var kept []*Session
+
+	for _, sess := range sessions {
+		if sess.HasTags(tags) {
+			kept = append(kept, sess)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,63 @@
+package session
+
+import "testing"
+
+func TestTagsPersist(t *testing.T) {
+	store, err := NewStore("/test/project", t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	if err := store.Create(&Session{Tags: []string{"security", "release"}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(sessions) != 1 || len(sessions[0].Tags) != 2 || sessions[0].Tags[0] != "security" {
+		t.Errorf("List() tags = %v, want [security release]", sessions[0].Tags)
+	}
+}
+
+func TestFilterByTags(t *testing.T) {
+	sessions := []*Session{
+		{ID: 1, Tags: []string{"security"}},
+		{ID: 2, Tags: []string{"security", "release"}},
+		{ID: 3},
+	}
+
+	tests := []struct {
+		name string
+		tags []string
+		want []int
+	}{
+		{"no tags", nil, []int{1, 2, 3}},
+		{"one tag", []string{"security"}, []int{1, 2}},
+		{"all tags required", []string{"security", "release"}, []int{2}},
+		{"no match", []string{"perf"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterByTags(sessions, tt.tags)
+
+			var ids []int
+			for _, s := range got {
+				ids = append(ids, s.ID)
+			}
+
+			if len(ids) != len(tt.want) {
+				t.Fatalf("FilterByTags() = %v, want %v", ids, tt.want)
+			}
+
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Errorf("FilterByTags() = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}